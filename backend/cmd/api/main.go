@@ -16,6 +16,7 @@ import (
 	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
 	"github.com/joaosantos/jlpt5/internal/infrastructure/postgres"
 	"github.com/joaosantos/jlpt5/internal/utils"
+	"github.com/joaosantos/jlpt5/pkg/tokenizer"
 )
 
 func main() {
@@ -52,27 +53,64 @@ func main() {
 	vocabRepo := postgres.NewVocabularyRepository(db)
 	grammarRepo := postgres.NewGrammarRepository(db)
 	quizRepo := postgres.NewQuizRepository(db)
+	deckRepo := postgres.NewDeckRepository(db)
+	tagRepo := postgres.NewTagRepository(db)
+	importRepo := postgres.NewImportJobRepository(db)
+	exampleRepo := postgres.NewExampleSentenceRepository(db)
+	conjugationRepo := postgres.NewConjugationRepository(db)
 
 	// Initialize utilities
 	jwtManager := utils.NewJWTManager(&cfg.JWT)
+	tok, err := tokenizer.NewKagome()
+	if err != nil {
+		logger.Error("Failed to load tokenizer", utils.WithContext("error", err.Error()))
+		os.Exit(1)
+	}
+
+	// Background work (quiz sweeps, imports) stops when the server shuts down
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
 
 	// Initialize services
 	authService := services.NewAuthService(userRepo, jwtManager, logger)
 	spacedRepetitionService := services.NewSpacedRepetitionService()
-	vocabService := services.NewVocabularyService(vocabRepo, spacedRepetitionService, logger)
+	answerChecker := services.NewAnswerChecker()
+	vocabService := services.NewVocabularyService(vocabRepo, userRepo, spacedRepetitionService, answerChecker, logger)
 	grammarService := services.NewGrammarService(grammarRepo, logger)
-	quizService := services.NewQuizService(quizRepo, logger)
+	numberService := services.NewNumberService(logger)
+	distractorService := services.NewDistractorService(vocabRepo, userRepo, logger)
+	quizService := services.NewQuizService(quizRepo, answerChecker, map[string]services.QuizSource{
+		services.QuizTypeNumbers:       numberService,
+		services.QuizTypeMeaningToWord: services.NewVocabularyQuizSource(vocabRepo, distractorService, services.QuizTypeMeaningToWord),
+		services.QuizTypeReading:       services.NewVocabularyQuizSource(vocabRepo, distractorService, services.QuizTypeReading),
+		services.QuizTypeGrammarCloze:  services.NewGrammarQuizSource(grammarRepo, distractorService),
+	}, cfg.Quiz.GracePeriod, cfg.Quiz.Retention, logger)
 	progressService := services.NewProgressService(db, logger)
+	toolsService := services.NewToolsService(tok, vocabRepo, logger)
+	furiganaService := services.NewFuriganaService(tok, vocabRepo, logger)
+	deckService := services.NewDeckService(deckRepo, vocabRepo, logger)
+	tagService := services.NewTagService(tagRepo, logger)
+	importService := services.NewImportService(backgroundCtx, importRepo, vocabRepo, vocabService, logger)
+	exportService := services.NewExportService(vocabRepo, deckService, logger)
+	exampleService := services.NewExampleService(exampleRepo, vocabRepo, vocabService, tok, logger)
+	conjugationService := services.NewConjugationService(conjugationRepo, vocabRepo, spacedRepetitionService, answerChecker, logger)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService, logger)
-	vocabHandler := handlers.NewVocabularyHandler(vocabService, logger)
-	grammarHandler := handlers.NewGrammarHandler(grammarService, logger)
-	quizHandler := handlers.NewQuizHandler(quizService, logger)
+	vocabHandler := handlers.NewVocabularyHandler(vocabService, furiganaService, logger)
+	grammarHandler := handlers.NewGrammarHandler(grammarService, furiganaService, logger)
+	quizHandler := handlers.NewQuizHandler(quizService, distractorService, logger)
 	progressHandler := handlers.NewProgressHandler(progressService, logger)
+	toolsHandler := handlers.NewToolsHandler(toolsService, logger)
+	deckHandler := handlers.NewDeckHandler(deckService, logger)
+	tagHandler := handlers.NewTagHandler(tagService, logger)
+	importHandler := handlers.NewImportHandler(importService, logger)
+	exportHandler := handlers.NewExportHandler(exportService, logger)
+	exampleHandler := handlers.NewExampleHandler(exampleService, logger)
+	drillHandler := handlers.NewDrillHandler(conjugationService, numberService, logger)
 
 	// Setup routes
-	router := routes.NewRouter(db, logger, authHandler, vocabHandler, grammarHandler, quizHandler, progressHandler)
+	router := routes.NewRouter(db, logger, authHandler, vocabHandler, grammarHandler, quizHandler, progressHandler, toolsHandler, deckHandler, tagHandler, importHandler, exportHandler, exampleHandler, drillHandler)
 	handler := router.SetupRoutes()
 
 	// Create HTTP server
@@ -84,6 +122,9 @@ func main() {
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
+	// Close quiz sessions whose time limit is over in the background
+	go quizService.SweepExpiredSessions(backgroundCtx, cfg.Quiz.SweepInterval)
+
 	// Start server in a goroutine
 	go func() {
 		logger.Info("Server listening", utils.WithContext("port", cfg.Server.Port))
@@ -99,6 +140,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopBackground()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -0,0 +1,141 @@
+// Command import-anki imports an Anki .apkg or .colpkg package into a user's
+// vocabulary, for example:
+//
+//	go run ./cmd/import-anki -file deck.apkg -user 1 \
+//	    -map "word=Expression,reading=Reading,meaning=Meaning" -reviews
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joaosantos/jlpt5/internal/config"
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
+	"github.com/joaosantos/jlpt5/internal/infrastructure/postgres"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	"github.com/joaosantos/jlpt5/pkg/anki"
+)
+
+func main() {
+	file := flag.String("file", "", "Anki package to import (.apkg or .colpkg)")
+	userID := flag.Int("user", 0, "ID of the user to import into")
+	mapping := flag.String("map", "word=Front,meaning=Back", "vocabulary fields mapped to Anki fields, e.g. word=Expression,reading=Reading,meaning=Meaning")
+	noteType := flag.String("note-type", "", "only import notes of this note type")
+	reviews := flag.Bool("reviews", false, "import scheduling and review history")
+	jlptLevel := flag.Int("jlpt", 5, "JLPT level of new vocabulary")
+	list := flag.Bool("list", false, "list the note types of the package and exit")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *list {
+		if err := listNoteTypes(*file); err != nil {
+			log.Fatalf("Failed to read package: %v", err)
+		}
+		return
+	}
+
+	if *userID <= 0 {
+		log.Fatal("A user ID is required")
+	}
+
+	fieldMap, err := parseFieldMap(*mapping)
+	if err != nil {
+		log.Fatalf("Invalid field mapping: %v", err)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	logger := utils.NewLogger(cfg.Log.Level)
+
+	// Connect to database
+	db, err := database.NewPostgresConnection(&cfg.Database, logger)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	userRepo := postgres.NewUserRepository(db)
+	vocabRepo := postgres.NewVocabularyRepository(db)
+	importRepo := postgres.NewImportJobRepository(db)
+
+	vocabService := services.NewVocabularyService(
+		vocabRepo, userRepo, services.NewSpacedRepetitionService(), services.NewAnswerChecker(), logger,
+	)
+	importService := services.NewImportService(context.Background(), importRepo, vocabRepo, vocabService, logger)
+
+	job, err := importService.RunAnkiImport(context.Background(), *userID, filepath.Base(*file), *file, services.AnkiImportOptions{
+		FieldMap:       fieldMap,
+		NoteType:       *noteType,
+		IncludeReviews: *reviews,
+		JLPTLevel:      *jlptLevel,
+	})
+	if err != nil {
+		log.Fatalf("Failed to import: %v", err)
+	}
+
+	printSummary(job)
+	if job.Status != models.ImportStatusCompleted {
+		os.Exit(1)
+	}
+}
+
+// parseFieldMap parses "field=AnkiField,..." pairs
+func parseFieldMap(s string) (map[string]string, error) {
+	fieldMap := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		field, ankiField, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected field=AnkiField, got %q", pair)
+		}
+		fieldMap[strings.TrimSpace(field)] = strings.TrimSpace(ankiField)
+	}
+	return fieldMap, nil
+}
+
+// listNoteTypes prints the note types of a package and their fields
+func listNoteTypes(path string) error {
+	pkg, err := anki.Open(path)
+	if err != nil {
+		return err
+	}
+	defer pkg.Close()
+
+	for _, nt := range pkg.NoteTypes() {
+		fmt.Printf("%s: %s\n", nt.Name, strings.Join(nt.Fields, ", "))
+	}
+	return nil
+}
+
+// printSummary prints the outcome of an import job
+func printSummary(job *models.ImportJob) {
+	fmt.Printf("Import %d %s\n", job.ID, job.Status)
+	if job.Error != nil {
+		fmt.Printf("Error: %s\n", *job.Error)
+	}
+	fmt.Printf("Notes:    %d\n", job.TotalItems)
+	fmt.Printf("Created:  %d\n", job.CreatedItems)
+	fmt.Printf("Matched:  %d\n", job.MatchedItems)
+	fmt.Printf("Updated:  %d\n", job.UpdatedItems)
+	fmt.Printf("Skipped:  %d\n", job.SkippedItems)
+	fmt.Printf("Failed:   %d\n", job.FailedItems)
+	fmt.Printf("Progress: %d items, %d reviews\n", job.ProgressImported, job.ReviewsImported)
+
+	for _, warning := range job.Warnings {
+		fmt.Printf("  - %s\n", warning)
+	}
+}
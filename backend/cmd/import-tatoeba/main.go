@@ -0,0 +1,129 @@
+// Command import-tatoeba imports Japanese-English example sentences from
+// Tatoeba export files and indexes them by the vocabulary they contain.
+// Either pass the sentence pairs file downloaded from Tatoeba's "sentence
+// pairs" page:
+//
+//	go run ./cmd/import-tatoeba -pairs jpn-eng.tsv
+//
+// or the full sentences.csv and links.csv exports:
+//
+//	go run ./cmd/import-tatoeba -sentences sentences.csv -links links.csv
+//
+// After vocabulary was added or the matching changed, index the sentences
+// imported before again with:
+//
+//	go run ./cmd/import-tatoeba -reindex
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joaosantos/jlpt5/internal/config"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
+	"github.com/joaosantos/jlpt5/internal/infrastructure/postgres"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	"github.com/joaosantos/jlpt5/pkg/tatoeba"
+	"github.com/joaosantos/jlpt5/pkg/tokenizer"
+)
+
+func main() {
+	pairsFile := flag.String("pairs", "", "Tatoeba sentence pairs file (Japanese ID, text, English ID, text)")
+	sentencesFile := flag.String("sentences", "", "Tatoeba sentences.csv export")
+	linksFile := flag.String("links", "", "Tatoeba links.csv export")
+	maxLength := flag.Int("max-length", services.DefaultMaxSentenceLength, "skip Japanese sentences longer than this many characters")
+	reindex := flag.Bool("reindex", false, "index the imported sentences again instead of importing")
+	flag.Parse()
+
+	// Import from exactly one source, or re-index without any
+	noSource := *pairsFile == "" && *sentencesFile == "" && *linksFile == ""
+	oneSource := (*pairsFile == "") != (*sentencesFile == "" || *linksFile == "")
+	if (*reindex && !noSource) || (!*reindex && !oneSource) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var pairs []tatoeba.Pair
+	if !*reindex {
+		fmt.Println("Reading sentences...")
+		var err error
+		pairs, err = readPairs(*pairsFile, *sentencesFile, *linksFile)
+		if err != nil {
+			log.Fatalf("Failed to read sentences: %v", err)
+		}
+		fmt.Printf("Read %d Japanese-English pairs\n", len(pairs))
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	logger := utils.NewLogger(cfg.Log.Level)
+
+	// Connect to database
+	db, err := database.NewPostgresConnection(&cfg.Database, logger)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	userRepo := postgres.NewUserRepository(db)
+	vocabRepo := postgres.NewVocabularyRepository(db)
+	exampleRepo := postgres.NewExampleSentenceRepository(db)
+
+	vocabService := services.NewVocabularyService(
+		vocabRepo, userRepo, services.NewSpacedRepetitionService(), services.NewAnswerChecker(), logger,
+	)
+	tok, err := tokenizer.NewKagome()
+	if err != nil {
+		log.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	exampleService := services.NewExampleService(exampleRepo, vocabRepo, vocabService, tok, logger)
+
+	var result *services.SentenceImportResult
+	if *reindex {
+		result, err = exampleService.ReindexSentences(context.Background())
+	} else {
+		result, err = exampleService.ImportSentences(context.Background(), pairs, *maxLength)
+	}
+	if result != nil {
+		fmt.Printf("Saved:     %d\n", result.Saved)
+		fmt.Printf("Too long:  %d\n", result.TooLong)
+		fmt.Printf("Unmatched: %d\n", result.Unmatched)
+	}
+	if err != nil {
+		log.Fatalf("Failed to import sentences: %v", err)
+	}
+}
+
+// readPairs reads the pairs file, or the sentences and links exports
+func readPairs(pairsFile, sentencesFile, linksFile string) ([]tatoeba.Pair, error) {
+	if pairsFile != "" {
+		f, err := os.Open(pairsFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return tatoeba.ReadPairs(f)
+	}
+
+	sentences, err := os.Open(sentencesFile)
+	if err != nil {
+		return nil, err
+	}
+	defer sentences.Close()
+
+	links, err := os.Open(linksFile)
+	if err != nil {
+		return nil, err
+	}
+	defer links.Close()
+
+	return tatoeba.ReadExport(sentences, links)
+}
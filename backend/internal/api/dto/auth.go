@@ -31,6 +31,7 @@ type UserResponse struct {
 	Email       string  `json:"email"`
 	Username    string  `json:"username"`
 	IsActive    bool    `json:"is_active"`
+	IsAdmin     bool    `json:"is_admin"`
 	CreatedAt   string  `json:"created_at"`
 	LastLoginAt *string `json:"last_login_at,omitempty"`
 }
@@ -0,0 +1,54 @@
+package dto
+
+// DeckRequest represents a deck creation or update request
+type DeckRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+// DeckResponse represents a deck in API responses
+type DeckResponse struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	ItemCount   int     `json:"item_count"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// DeckStatsResponse represents a user's progress on the items of a deck
+type DeckStatsResponse struct {
+	DeckID         int     `json:"deck_id"`
+	TotalItems     int     `json:"total_items"`
+	NewItems       int     `json:"new_items"`
+	LearningItems  int     `json:"learning_items"`
+	DueItems       int     `json:"due_items"`
+	MasteredItems  int     `json:"mastered_items"`
+	TotalReviews   int     `json:"total_reviews"`
+	CorrectReviews int     `json:"correct_reviews"`
+	SuccessRate    float64 `json:"success_rate"`
+}
+
+// ItemsRequest represents a request to add vocabulary items to a deck or tag
+type ItemsRequest struct {
+	VocabularyIDs []int `json:"vocabulary_ids"`
+}
+
+// ItemsResponse reports how many of the requested items were added
+type ItemsResponse struct {
+	Requested int `json:"requested"`
+	Added     int `json:"added"`
+}
+
+// TagRequest represents a tag creation request
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+// TagResponse represents a user-defined tag in API responses
+type TagResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	ItemCount int    `json:"item_count"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+// ConjugationDrillResponse asks for one form of a verb or adjective
+type ConjugationDrillResponse struct {
+	VocabularyID int               `json:"vocabulary_id"`
+	Word         string            `json:"word"`
+	Reading      string            `json:"reading"`
+	Meaning      string            `json:"meaning"`
+	Class        string            `json:"class"`  // e.g. ichidan verb, i-adjective
+	Form         string            `json:"form"`   // e.g. polite_past_negative
+	Prompt       string            `json:"prompt"` // e.g. 食べる → polite past negative
+	IsNew        bool              `json:"is_new"` // The user has not drilled this form of the word yet
+	Progress     *ProgressResponse `json:"progress,omitempty"`
+}
+
+// ConjugationAnswerRequest represents a typed answer to a conjugation drill
+type ConjugationAnswerRequest struct {
+	VocabularyID int    `json:"vocabulary_id"`
+	Form         string `json:"form"`
+	Answer       string `json:"answer"`
+}
+
+// ConjugationAnswerResponse represents the result of a conjugation drill answer
+type ConjugationAnswerResponse struct {
+	IsCorrect       bool              `json:"is_correct"`
+	Verdict         string            `json:"verdict"` // correct, almost, incorrect
+	Hint            string            `json:"hint,omitempty"`
+	ExpectedAnswer  string            `json:"expected_answer"`
+	ExpectedReading string            `json:"expected_reading"`
+	Progress        *ProgressResponse `json:"progress"`
+}
+
+// NumberDrillResponse asks for the reading of a number, count, date or time
+type NumberDrillResponse struct {
+	Kind    string   `json:"kind"`            // number, counter, date or time
+	Prompt  string   `json:"prompt"`          // e.g. 3本
+	Usage   string   `json:"usage,omitempty"` // What a counter counts
+	Answer  string   `json:"answer"`          // The standard reading, e.g. さんぼん
+	Answers []string `json:"answers"`         // Every accepted reading
+}
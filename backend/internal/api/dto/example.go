@@ -0,0 +1,21 @@
+package dto
+
+// ExampleSentenceResponse represents an example sentence in API responses.
+// Sentences come from Tatoeba (CC BY 2.0 FR) and link to their source.
+type ExampleSentenceResponse struct {
+	ID         int     `json:"id"`
+	Japanese   string  `json:"japanese"`
+	English    string  `json:"english"`
+	N5Coverage float64 `json:"n5_coverage"` // Percentage of the sentence readable at N5
+	SourceURL  string  `json:"source_url"`
+}
+
+// ExampleSentenceListResponse represents a paginated list of example sentences
+type ExampleSentenceListResponse struct {
+	Items       []ExampleSentenceResponse `json:"items"`
+	Total       int                       `json:"total"`
+	Page        int                       `json:"page"`
+	PageSize    int                       `json:"page_size"`
+	TotalPages  int                       `json:"total_pages"`
+	Attribution string                    `json:"attribution"`
+}
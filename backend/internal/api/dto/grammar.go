@@ -9,6 +9,7 @@ type GrammarLessonResponse struct {
 	UsageNotes   *string                  `json:"usage_notes,omitempty"`
 	JLPTLevel    int                      `json:"jlpt_level"`
 	LessonOrder  *int                     `json:"lesson_order,omitempty"`
+	Tags         []string                 `json:"tags"`
 	Examples     []GrammarExampleResponse `json:"examples"`
 	Progress     *GrammarProgressResponse `json:"progress,omitempty"`
 }
@@ -19,6 +20,9 @@ type GrammarExampleResponse struct {
 	JapaneseSentence   string  `json:"japanese_sentence"`
 	EnglishTranslation string  `json:"english_translation"`
 	Notes              *string `json:"notes,omitempty"`
+
+	// Furigana, only with ?furigana=true
+	JapaneseSentenceFurigana []RubySegment `json:"japanese_sentence_furigana,omitempty"`
 }
 
 // GrammarProgressResponse represents user progress for a grammar lesson
@@ -29,13 +33,15 @@ type GrammarProgressResponse struct {
 	Notes       *string `json:"notes,omitempty"`
 }
 
-// GrammarListResponse represents a paginated list of grammar lessons
+// GrammarListResponse represents a paginated list of grammar lessons.
+// Total and TotalPages are omitted when counting was not requested.
 type GrammarListResponse struct {
 	Items      []GrammarLessonResponse `json:"items"`
-	Total      int                     `json:"total"`
+	Total      *int                    `json:"total,omitempty"`
 	Page       int                     `json:"page"`
 	PageSize   int                     `json:"page_size"`
-	TotalPages int                     `json:"total_pages"`
+	TotalPages *int                    `json:"total_pages,omitempty"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
 }
 
 // MarkCompletedRequest represents a request to mark a lesson as completed
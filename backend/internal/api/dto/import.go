@@ -0,0 +1,22 @@
+package dto
+
+// ImportJobResponse represents the status of an import job in API responses
+type ImportJobResponse struct {
+	ID               int      `json:"id"`
+	Source           string   `json:"source"`
+	FileName         string   `json:"file_name"`
+	Status           string   `json:"status"`
+	TotalItems       int      `json:"total_items"`
+	CreatedItems     int      `json:"created_items"`
+	MatchedItems     int      `json:"matched_items"`
+	UpdatedItems     int      `json:"updated_items"`
+	SkippedItems     int      `json:"skipped_items"`
+	FailedItems      int      `json:"failed_items"`
+	ProgressImported int      `json:"progress_imported"`
+	ReviewsImported  int      `json:"reviews_imported"`
+	Warnings         []string `json:"warnings"`
+	Error            *string  `json:"error,omitempty"`
+	CreatedAt        string   `json:"created_at"`
+	StartedAt        *string  `json:"started_at,omitempty"`
+	FinishedAt       *string  `json:"finished_at,omitempty"`
+}
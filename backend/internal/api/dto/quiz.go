@@ -2,12 +2,17 @@ package dto
 
 // QuizResponse represents a quiz in API responses
 type QuizResponse struct {
-	ID           int     `json:"id"`
-	Title        string  `json:"title"`
-	Description  *string `json:"description,omitempty"`
-	JLPTLevel    int     `json:"jlpt_level"`
-	QuizType     *string `json:"quiz_type,omitempty"`
-	PassingScore int     `json:"passing_score"`
+	ID                 int     `json:"id"`
+	Title              string  `json:"title"`
+	Description        *string `json:"description,omitempty"`
+	JLPTLevel          int     `json:"jlpt_level"`
+	QuizType           *string `json:"quiz_type,omitempty"`
+	TimeLimitMinutes   *int    `json:"time_limit_minutes,omitempty"`
+	PassingScore       int     `json:"passing_score"`
+	ShuffleQuestions   bool    `json:"shuffle_questions"`
+	ShuffleOptions     bool    `json:"shuffle_options"`
+	WrongAnswerPenalty float64 `json:"wrong_answer_penalty"` // Share of the points lost for a wrong answer
+	PartialCredit      float64 `json:"partial_credit"`       // Share of the points earned by a near-miss typed answer
 }
 
 // QuizListResponse represents a paginated list of quizzes
@@ -47,25 +52,87 @@ type StartQuizResponse struct {
 	Quiz      QuizResponse           `json:"quiz"`
 	Questions []QuizQuestionResponse `json:"questions"`
 	StartedAt string                 `json:"started_at"`
+	Deadline  *string                `json:"deadline,omitempty"` // End of the time limit, omitted when the quiz is not timed
+}
+
+// GenerateQuizRequest represents a request to generate a quiz
+type GenerateQuizRequest struct {
+	Type      string `json:"type"`                 // numbers, meaning_to_word, reading or grammar_cloze
+	JLPTLevel int    `json:"jlpt_level,omitempty"` // Defaults to 5
+	Size      int    `json:"size,omitempty"`       // Number of questions, defaults to 10
+}
+
+// ValidateQuestionRequest represents an authored question to validate
+type ValidateQuestionRequest struct {
+	QuestionType  string  `json:"question_type"`
+	QuestionText  string  `json:"question_text"`
+	OptionA       *string `json:"option_a,omitempty"`
+	OptionB       *string `json:"option_b,omitempty"`
+	OptionC       *string `json:"option_c,omitempty"`
+	OptionD       *string `json:"option_d,omitempty"`
+	CorrectAnswer string  `json:"correct_answer"` // Letter of the correct option
+}
+
+// QuestionIssueResponse represents a problem with an option of a question
+type QuestionIssueResponse struct {
+	Option  string `json:"option"`
+	Problem string `json:"problem"` // missing_answer, duplicate, also_correct or mismatched
+	Message string `json:"message"`
+}
+
+// ValidateQuestionResponse represents the outcome of validating a question
+type ValidateQuestionResponse struct {
+	Valid       bool                    `json:"valid"`
+	Issues      []QuestionIssueResponse `json:"issues"`
+	Suggestions []string                `json:"suggestions"` // Distractors to replace the flagged options
+}
+
+// SaveQuizAnswerRequest represents a request to save the answer to one question
+type SaveQuizAnswerRequest struct {
+	Answer string `json:"answer"` // Option letter or text, or the typed answer
+}
+
+// SavedQuizAnswerResponse represents an answer saved to a session in progress
+type SavedQuizAnswerResponse struct {
+	QuestionID int    `json:"question_id"`
+	UserAnswer string `json:"user_answer"`
+	AnsweredAt string `json:"answered_at"`
+}
+
+// ResumeQuizResponse represents a session in progress with the answers saved so far
+type ResumeQuizResponse struct {
+	StartQuizResponse
+	Answers []SavedQuizAnswerResponse `json:"answers"`
 }
 
 // SubmitQuizRequest represents a request to submit quiz answers
 type SubmitQuizRequest struct {
-	Answers map[int]string `json:"answers"` // questionID -> userAnswer
+	Answers map[int]string `json:"answers"` // questionID -> userAnswer, added to the answers saved before
 }
 
 // QuizAnswerResponse represents a submitted answer with correctness
 type QuizAnswerResponse struct {
-	QuestionID int    `json:"question_id"`
-	UserAnswer string `json:"user_answer"`
-	IsCorrect  bool   `json:"is_correct"`
+	QuestionID    int     `json:"question_id"`
+	UserAnswer    string  `json:"user_answer"`
+	Answered      bool    `json:"answered"` // False for questions left unanswered
+	IsCorrect     bool    `json:"is_correct"`
+	PointsAwarded float64 `json:"points_awarded"` // Negative for a wrong answer under negative marking
+}
+
+// QuizSectionScoreResponse represents the score of one section of a quiz
+type QuizSectionScoreResponse struct {
+	Name        string  `json:"name"` // Empty for the questions outside any section
+	Weight      float64 `json:"weight"`
+	Score       float64 `json:"score"`
+	TotalPoints int     `json:"total_points"`
+	Percentage  float64 `json:"percentage"`
 }
 
 // QuizResultResponse represents the result of a completed quiz
 type QuizResultResponse struct {
 	SessionID      int                          `json:"session_id"`
 	Quiz           QuizResponse                 `json:"quiz"`
-	Score          int                          `json:"score"`          // Points earned
+	Score          float64                      `json:"score"`          // Points earned
 	TotalPoints    int                          `json:"total_points"`   // Total points possible
 	Percentage     float64                      `json:"percentage"`     // Score percentage
 	TotalQuestions int                          `json:"total_questions"` // Number of questions
@@ -74,6 +141,7 @@ type QuizResultResponse struct {
 	CompletedAt    string                       `json:"completed_at"`
 	Questions      []QuizQuestionDetailResponse `json:"questions"`
 	Answers        []QuizAnswerResponse         `json:"answers"`
+	Sections       []QuizSectionScoreResponse   `json:"sections,omitempty"` // Weighted into the percentage
 }
 
 // QuizSessionResponse represents a quiz session summary
@@ -82,14 +150,18 @@ type QuizSessionResponse struct {
 	QuizID      int      `json:"quiz_id"`
 	StartedAt   string   `json:"started_at"`
 	CompletedAt *string  `json:"completed_at,omitempty"`
-	Score       *int     `json:"score,omitempty"`       // Points earned
+	Score       *float64 `json:"score,omitempty"`       // Points earned
 	Percentage  *float64 `json:"percentage,omitempty"`  // Score percentage
 	Passed      *bool    `json:"passed,omitempty"`
 }
 
-// QuizHistoryResponse represents a user's quiz history
+// QuizHistoryResponse represents a user's quiz history.
+// Total and TotalPages are only set when counting was requested.
 type QuizHistoryResponse struct {
-	Sessions []QuizSessionResponse `json:"sessions"`
-	Page     int                   `json:"page"`
-	PageSize int                   `json:"page_size"`
+	Sessions   []QuizSessionResponse `json:"sessions"`
+	Total      *int                  `json:"total,omitempty"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+	TotalPages *int                  `json:"total_pages,omitempty"`
+	NextCursor string                `json:"next_cursor,omitempty"`
 }
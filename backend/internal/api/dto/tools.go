@@ -0,0 +1,84 @@
+package dto
+
+// ConvertResponse represents the result of a romaji/kana conversion
+type ConvertResponse struct {
+	Text   string `json:"text"`
+	To     string `json:"to"`
+	System string `json:"system,omitempty"`
+	Result string `json:"result"`
+}
+
+// TokenizeRequest represents a request to split text into words
+type TokenizeRequest struct {
+	Text string `json:"text"`
+}
+
+// TokenResponse represents a word of a tokenized text
+type TokenResponse struct {
+	Surface      string   `json:"surface"`
+	BaseForm     string   `json:"base_form"`
+	Reading      string   `json:"reading,omitempty"`
+	POS          string   `json:"pos"`
+	POSTags      []string `json:"pos_tags"`
+	Start        int      `json:"start"`
+	End          int      `json:"end"`
+	Known        bool     `json:"known"`
+	VocabularyID *int     `json:"vocabulary_id,omitempty"`
+	Meaning      *string  `json:"meaning,omitempty"`
+	JLPTLevel    *int     `json:"jlpt_level,omitempty"`
+}
+
+// TokenizeResponse represents a text split into words
+type TokenizeResponse struct {
+	Text   string          `json:"text"`
+	Tokens []TokenResponse `json:"tokens"`
+}
+
+// RubySegment is a piece of Japanese text with the furigana shown above it.
+// Reading is omitted for text that needs none, such as kana.
+type RubySegment struct {
+	Text    string `json:"text"`
+	Reading string `json:"reading,omitempty"`
+}
+
+// AnalyzeRequest represents a request to analyze the difficulty of a text
+type AnalyzeRequest struct {
+	Text string `json:"text"`
+}
+
+// AnalyzeResponse reports how much of a text the user can read. Word counts
+// are occurrences; percentages are of TotalWords.
+type AnalyzeResponse struct {
+	TotalWords      int                     `json:"total_words"`
+	UniqueWords     int                     `json:"unique_words"`
+	Known           int                     `json:"known"`
+	Learning        int                     `json:"learning"`
+	Unknown         int                     `json:"unknown"`
+	KnownPercent    float64                 `json:"known_percent"`
+	LearningPercent float64                 `json:"learning_percent"`
+	UnknownPercent  float64                 `json:"unknown_percent"`
+	Readable        bool                    `json:"readable"` // Whether the user knows enough of the words
+	Levels          []LevelCoverageResponse `json:"levels"`
+	UnknownWords    []AnalyzedWordResponse  `json:"unknown_words"` // Words not known yet, including those being learned
+	// UnknownVocabularyIDs are the unknown words that are vocabulary items,
+	// ready to be sent as vocabulary_ids to POST /api/v1/decks/{id}/items
+	UnknownVocabularyIDs []int `json:"unknown_vocabulary_ids"`
+}
+
+// LevelCoverageResponse reports how many words of a text belong to a JLPT level
+type LevelCoverageResponse struct {
+	JLPTLevel *int    `json:"jlpt_level"` // null for words not in the vocabulary
+	Words     int     `json:"words"`
+	Percent   float64 `json:"percent"`
+}
+
+// AnalyzedWordResponse represents a distinct word of an analyzed text
+type AnalyzedWordResponse struct {
+	Word         string  `json:"word"`
+	Reading      string  `json:"reading,omitempty"`
+	State        string  `json:"state"`
+	Count        int     `json:"count"`
+	VocabularyID *int    `json:"vocabulary_id,omitempty"`
+	Meaning      *string `json:"meaning,omitempty"`
+	JLPTLevel    *int    `json:"jlpt_level,omitempty"`
+}
@@ -11,7 +11,42 @@ type VocabularyResponse struct {
 	ExampleSentence    *string                `json:"example_sentence,omitempty"`
 	ExampleTranslation *string                `json:"example_translation,omitempty"`
 	AudioURL           *string                `json:"audio_url,omitempty"`
+	Tags               []string               `json:"tags"`
+	Visibility         string                 `json:"visibility"`
+	Suggested          bool                   `json:"suggested_for_inclusion,omitempty"`
+	OwnerID            *int                   `json:"owner_id,omitempty"` // Only shown to admins
 	Progress           *ProgressResponse      `json:"progress,omitempty"`
+
+	// Furigana, only with ?furigana=true
+	WordFurigana            []RubySegment `json:"word_furigana,omitempty"`
+	ExampleSentenceFurigana []RubySegment `json:"example_sentence_furigana,omitempty"`
+}
+
+// CreateVocabularyRequest represents a user-authored vocabulary entry
+type CreateVocabularyRequest struct {
+	Word                string  `json:"word"`
+	Reading             string  `json:"reading"`
+	Meaning             string  `json:"meaning"`
+	PartOfSpeech        *string `json:"part_of_speech,omitempty"`
+	JLPTLevel           int     `json:"jlpt_level,omitempty"` // Defaults to 5
+	ExampleSentence     *string `json:"example_sentence,omitempty"`
+	ExampleTranslation  *string `json:"example_translation,omitempty"`
+	SuggestForInclusion bool    `json:"suggest_for_inclusion"`
+}
+
+// UpdateVocabularyRequest replaces the editable fields of a user-authored
+// vocabulary entry; word and reading cannot be changed
+type UpdateVocabularyRequest struct {
+	Meaning            string  `json:"meaning"`
+	PartOfSpeech       *string `json:"part_of_speech,omitempty"`
+	JLPTLevel          int     `json:"jlpt_level,omitempty"` // Defaults to 5
+	ExampleSentence    *string `json:"example_sentence,omitempty"`
+	ExampleTranslation *string `json:"example_translation,omitempty"`
+}
+
+// SuggestionRequest flags or unflags a private entry for inclusion in the public vocabulary
+type SuggestionRequest struct {
+	Suggested bool `json:"suggested"`
 }
 
 // ProgressResponse represents user progress for a vocabulary item
@@ -26,26 +61,74 @@ type ProgressResponse struct {
 	CorrectReviews int     `json:"correct_reviews"`
 	SuccessRate    float64 `json:"success_rate"`
 	IsDue          bool    `json:"is_due"`
+
+	Mnemonic        *string `json:"mnemonic,omitempty"`
+	PersonalExample *string `json:"personal_example,omitempty"`
+	ImageURL        *string `json:"image_url,omitempty"`
+}
+
+// NotesRequest sets the personal notes on a vocabulary item. The request
+// replaces all notes; omitted or empty fields are cleared.
+type NotesRequest struct {
+	Mnemonic        *string `json:"mnemonic"`
+	PersonalExample *string `json:"personal_example"`
+	ImageURL        *string `json:"image_url"`
 }
 
-// VocabularyListResponse represents a paginated list of vocabulary
+// VocabularyListResponse represents a paginated list of vocabulary.
+// Total and TotalPages are omitted when counting was not requested.
 type VocabularyListResponse struct {
 	Items      []VocabularyResponse `json:"items"`
-	Total      int                  `json:"total"`
+	Total      *int                 `json:"total,omitempty"`
 	Page       int                  `json:"page"`
 	PageSize   int                  `json:"page_size"`
-	TotalPages int                  `json:"total_pages"`
+	TotalPages *int                 `json:"total_pages,omitempty"`
+	NextCursor string               `json:"next_cursor,omitempty"`
 }
 
-// ReviewRequest represents a vocabulary review submission
+// ReviewRequest represents a vocabulary review submission: either a typed
+// Answer the server checks, or a Quality the learner graded themselves with
+// SelfGraded set
 type ReviewRequest struct {
-	IsCorrect bool `json:"is_correct"`
+	Answer     *string `json:"answer,omitempty"`
+	AnswerType string  `json:"answer_type,omitempty"` // reading, meaning
+	SelfGraded bool    `json:"self_graded,omitempty"`
+	Quality    *int    `json:"quality,omitempty"` // SM-2 quality (0-5) of a self-graded review
 }
 
 // ReviewResponse represents the result of a review submission
 type ReviewResponse struct {
 	Success        bool              `json:"success"`
-	Progress       *ProgressResponse `json:"progress"`
-	NextReviewDate string            `json:"next_review_date"`
+	IsCorrect      bool              `json:"is_correct"`
+	Verdict        string            `json:"verdict,omitempty"`          // correct, almost, incorrect
+	Hint           string            `json:"hint,omitempty"`
+	ExpectedAnswer string            `json:"expected_answer,omitempty"`
+	Progress       *ProgressResponse `json:"progress,omitempty"`         // Omitted for a near miss, which leaves the schedule unchanged
+	NextReviewDate string            `json:"next_review_date,omitempty"` // Omitted for a near miss
 	Message        string            `json:"message"`
 }
+
+// ReviewLogResponse represents a single review in the review log
+type ReviewLogResponse struct {
+	ID               int     `json:"id"`
+	VocabularyID     int     `json:"vocabulary_id"`
+	Quality          int     `json:"quality"`
+	IsCorrect        bool    `json:"is_correct"`
+	SelfGraded       bool    `json:"self_graded"`
+	NearMiss         bool    `json:"near_miss"`
+	PreviousInterval int     `json:"previous_interval_days"`
+	Interval         int     `json:"interval_days"`
+	EaseFactor       float64 `json:"ease_factor"`
+	ReviewedAt       string  `json:"reviewed_at"`
+}
+
+// ReviewLogListResponse represents a paginated list of reviews.
+// Total and TotalPages are omitted when counting was not requested.
+type ReviewLogListResponse struct {
+	Items      []ReviewLogResponse `json:"items"`
+	Total      *int                `json:"total,omitempty"`
+	Page       int                 `json:"page"`
+	PageSize   int                 `json:"page_size"`
+	TotalPages *int                `json:"total_pages,omitempty"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
@@ -113,6 +113,7 @@ func (h *AuthHandler) toAuthResponse(authResp *services.AuthResponse) dto.AuthRe
 			Email:       authResp.User.Email,
 			Username:    authResp.User.Username,
 			IsActive:    authResp.User.IsActive,
+			IsAdmin:     authResp.User.IsAdmin,
 			CreatedAt:   authResp.User.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			LastLoginAt: lastLogin,
 		},
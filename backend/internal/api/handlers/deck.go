@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/joaosantos/jlpt5/internal/api/dto"
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+// DeckHandler handles deck endpoints
+type DeckHandler struct {
+	deckService *services.DeckService
+	logger      *utils.Logger
+}
+
+// NewDeckHandler creates a new deck handler
+func NewDeckHandler(deckService *services.DeckService, logger *utils.Logger) *DeckHandler {
+	return &DeckHandler{
+		deckService: deckService,
+		logger:      logger,
+	}
+}
+
+// ListDecks retrieves the user's decks
+func (h *DeckHandler) ListDecks(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	decks, err := h.deckService.ListDecks(r.Context(), userID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	responses := make([]dto.DeckResponse, len(decks))
+	for i := range decks {
+		responses[i] = toDeckResponse(&decks[i])
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"items": responses,
+		"count": len(responses),
+	})
+}
+
+// CreateDeck creates a deck
+func (h *DeckHandler) CreateDeck(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	var req dto.DeckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	deck, err := h.deckService.CreateDeck(r.Context(), userID, req.Name, req.Description)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusCreated, toDeckResponse(deck))
+}
+
+// GetDeck retrieves a deck
+func (h *DeckHandler) GetDeck(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	deckID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck ID"))
+		return
+	}
+
+	deck, err := h.deckService.GetDeck(r.Context(), userID, deckID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, toDeckResponse(deck))
+}
+
+// UpdateDeck renames a deck and replaces its description
+func (h *DeckHandler) UpdateDeck(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	deckID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck ID"))
+		return
+	}
+
+	var req dto.DeckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	deck, err := h.deckService.UpdateDeck(r.Context(), userID, deckID, req.Name, req.Description)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, toDeckResponse(deck))
+}
+
+// DeleteDeck deletes a deck
+func (h *DeckHandler) DeleteDeck(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	deckID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck ID"))
+		return
+	}
+
+	if err := h.deckService.DeleteDeck(r.Context(), userID, deckID); err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Deck deleted",
+	})
+}
+
+// AddItems adds vocabulary items to a deck
+func (h *DeckHandler) AddItems(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	deckID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck ID"))
+		return
+	}
+
+	var req dto.ItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	added, err := h.deckService.AddItems(r.Context(), userID, deckID, req.VocabularyIDs)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, dto.ItemsResponse{
+		Requested: len(req.VocabularyIDs),
+		Added:     added,
+	})
+}
+
+// RemoveItem removes a vocabulary item from a deck
+func (h *DeckHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	deckID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck ID"))
+		return
+	}
+	vocabID, err := strconv.Atoi(r.PathValue("vocabularyId"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid vocabulary ID"))
+		return
+	}
+
+	if err := h.deckService.RemoveItem(r.Context(), userID, deckID, vocabID); err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Item removed from deck",
+	})
+}
+
+// GetDueItems retrieves the deck's items that are due for review
+func (h *DeckHandler) GetDueItems(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	deckID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck ID"))
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	items, err := h.deckService.GetDueItems(r.Context(), userID, deckID, limit)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"items": toVocabularyResponseList(items),
+		"count": len(items),
+	})
+}
+
+// GetStats retrieves the user's progress on a deck
+func (h *DeckHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	deckID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck ID"))
+		return
+	}
+
+	stats, err := h.deckService.GetDeckStats(r.Context(), userID, deckID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	successRate := 0.0
+	if stats.TotalReviews > 0 {
+		successRate = float64(stats.CorrectReviews) / float64(stats.TotalReviews) * 100
+	}
+
+	sendSuccess(w, http.StatusOK, dto.DeckStatsResponse{
+		DeckID:         stats.DeckID,
+		TotalItems:     stats.TotalItems,
+		NewItems:       stats.NewItems,
+		LearningItems:  stats.LearningItems,
+		DueItems:       stats.DueItems,
+		MasteredItems:  stats.MasteredItems,
+		TotalReviews:   stats.TotalReviews,
+		CorrectReviews: stats.CorrectReviews,
+		SuccessRate:    successRate,
+	})
+}
+
+// Helper functions
+
+func toDeckResponse(deck *models.Deck) dto.DeckResponse {
+	return dto.DeckResponse{
+		ID:          deck.ID,
+		Name:        deck.Name,
+		Description: deck.Description,
+		ItemCount:   deck.ItemCount,
+		CreatedAt:   deck.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   deck.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joaosantos/jlpt5/internal/api/dto"
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	"github.com/joaosantos/jlpt5/pkg/conjugation"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+// DrillHandler handles conjugation and number drill endpoints
+type DrillHandler struct {
+	conjugationService *services.ConjugationService
+	numberService      *services.NumberService
+	logger             *utils.Logger
+}
+
+// NewDrillHandler creates a new drill handler
+func NewDrillHandler(conjugationService *services.ConjugationService, numberService *services.NumberService, logger *utils.Logger) *DrillHandler {
+	return &DrillHandler{
+		conjugationService: conjugationService,
+		numberService:      numberService,
+		logger:             logger,
+	}
+}
+
+// GetConjugationDrills retrieves conjugation drills, due ones first.
+// ?forms=past,te restricts the drills to the listed forms.
+func (h *DrillHandler) GetConjugationDrills(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	query := r.URL.Query()
+
+	count := 10
+	if value := query.Get("count"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			sendError(w, pkgErrors.BadRequest("Invalid count"))
+			return
+		}
+		count = parsed
+	}
+
+	forms, err := services.ParseForms(query.Get("forms"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	drills, err := h.conjugationService.GetDrills(r.Context(), userID, forms, count)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	responses := make([]dto.ConjugationDrillResponse, len(drills))
+	for i := range drills {
+		responses[i] = toConjugationDrillResponse(&drills[i])
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"items": responses,
+		"count": len(responses),
+	})
+}
+
+// SubmitConjugationAnswer checks a typed answer to a conjugation drill
+func (h *DrillHandler) SubmitConjugationAnswer(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	var req dto.ConjugationAnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	forms, err := services.ParseForms(req.Form)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	if len(forms) != 1 {
+		sendError(w, pkgErrors.Validation("A single form is required"))
+		return
+	}
+
+	result, err := h.conjugationService.SubmitAnswer(r.Context(), userID, req.VocabularyID, forms[0], req.Answer)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, dto.ConjugationAnswerResponse{
+		IsCorrect:       result.IsCorrect(),
+		Verdict:         string(result.Verdict),
+		Hint:            result.Hint,
+		ExpectedAnswer:  result.Matched,
+		ExpectedReading: result.ExpectedReading,
+		Progress:        toConjugationProgressResponse(result.Progress),
+	})
+}
+
+// GetNumberDrills generates questions on the readings of numbers, counters,
+// dates and times. ?kinds=counter,date restricts the kinds of questions.
+func (h *DrillHandler) GetNumberDrills(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	count := 10
+	if value := query.Get("count"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			sendError(w, pkgErrors.BadRequest("Invalid count"))
+			return
+		}
+		count = parsed
+	}
+
+	kinds, err := services.ParseNumberKinds(query.Get("kinds"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	questions, err := h.numberService.GetDrills(kinds, count)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	responses := make([]dto.NumberDrillResponse, len(questions))
+	for i, question := range questions {
+		responses[i] = dto.NumberDrillResponse{
+			Kind:    string(question.Kind),
+			Prompt:  question.Prompt,
+			Usage:   question.Usage,
+			Answer:  question.Answer(),
+			Answers: question.Answers,
+		}
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"items": responses,
+		"count": len(responses),
+	})
+}
+
+// Helper functions
+
+func toConjugationDrillResponse(drill *models.ConjugationDrill) dto.ConjugationDrillResponse {
+	label := strings.ReplaceAll(drill.Form, "_", " ")
+
+	response := dto.ConjugationDrillResponse{
+		VocabularyID: drill.Vocabulary.ID,
+		Word:         drill.Vocabulary.Word,
+		Reading:      drill.Vocabulary.Reading,
+		Meaning:      drill.Vocabulary.Meaning,
+		Form:         drill.Form,
+		Prompt:       drill.Vocabulary.Word + " → " + label,
+		IsNew:        drill.Progress == nil,
+	}
+	if drill.Vocabulary.PartOfSpeech != nil {
+		if class, ok := conjugation.ParseClass(*drill.Vocabulary.PartOfSpeech); ok {
+			response.Class = string(class)
+		}
+	}
+	if drill.Progress != nil {
+		response.Progress = toConjugationProgressResponse(drill.Progress)
+	}
+
+	return response
+}
+
+func toConjugationProgressResponse(progress *models.ConjugationProgress) *dto.ProgressResponse {
+	successRate := 0.0
+	if progress.TotalReviews > 0 {
+		successRate = float64(progress.CorrectReviews) / float64(progress.TotalReviews) * 100
+	}
+
+	response := &dto.ProgressResponse{
+		ID:             progress.ID,
+		EaseFactor:     progress.EaseFactor,
+		Interval:       progress.Interval,
+		Repetitions:    progress.Repetitions,
+		NextReviewDate: progress.NextReviewDate.Format(time.RFC3339),
+		TotalReviews:   progress.TotalReviews,
+		CorrectReviews: progress.CorrectReviews,
+		SuccessRate:    successRate,
+		IsDue:          time.Now().After(progress.NextReviewDate),
+	}
+
+	if progress.LastReviewedAt != nil {
+		formatted := progress.LastReviewedAt.Format(time.RFC3339)
+		response.LastReviewedAt = &formatted
+	}
+
+	return response
+}
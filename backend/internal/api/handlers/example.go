@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/joaosantos/jlpt5/internal/api/dto"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/tatoeba"
+)
+
+// tatoebaAttribution credits the source of the example sentences, as required by their license
+const tatoebaAttribution = "Example sentences from Tatoeba (https://tatoeba.org), licensed CC BY 2.0 FR"
+
+// ExampleHandler handles example sentence endpoints
+type ExampleHandler struct {
+	exampleService *services.ExampleService
+	logger         *utils.Logger
+}
+
+// NewExampleHandler creates a new example sentence handler
+func NewExampleHandler(exampleService *services.ExampleService, logger *utils.Logger) *ExampleHandler {
+	return &ExampleHandler{
+		exampleService: exampleService,
+		logger:         logger,
+	}
+}
+
+// ListExamples retrieves example sentences containing a vocabulary item
+func (h *ExampleHandler) ListExamples(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	vocabID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid vocabulary ID"))
+		return
+	}
+
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+	if pageSize < 1 || pageSize > 50 {
+		pageSize = 10
+	}
+
+	sentences, total, err := h.exampleService.GetExamples(r.Context(), userID, vocabID, page, pageSize)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	items := make([]dto.ExampleSentenceResponse, len(sentences))
+	for i, s := range sentences {
+		items[i] = dto.ExampleSentenceResponse{
+			ID:         s.ID,
+			Japanese:   s.Japanese,
+			English:    s.English,
+			N5Coverage: math.Round(s.N5Coverage*1000) / 10,
+			SourceURL:  tatoeba.SentenceURL(s.TatoebaID),
+		}
+	}
+
+	sendSuccess(w, http.StatusOK, dto.ExampleSentenceListResponse{
+		Items:       items,
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+		TotalPages:  (total + pageSize - 1) / pageSize,
+		Attribution: tatoebaAttribution,
+	})
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+// ExportHandler handles vocabulary export endpoints
+type ExportHandler struct {
+	exportService *services.ExportService
+	logger        *utils.Logger
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(exportService *services.ExportService, logger *utils.Logger) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// ExportAnki streams an .apkg package of one of the user's decks (?deck=) or
+// of all vocabulary the user studies or owns. With ?include_progress=true the
+// cards keep the user's scheduling.
+func (h *ExportHandler) ExportAnki(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	query := r.URL.Query()
+
+	deckID, err := parseOptionalID(query.Get("deck"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck"))
+		return
+	}
+
+	includeProgress := false
+	if progressStr := query.Get("include_progress"); progressStr != "" {
+		includeProgress, err = strconv.ParseBool(progressStr)
+		if err != nil {
+			sendError(w, pkgErrors.BadRequest("Invalid include_progress, expected true or false"))
+			return
+		}
+	}
+
+	export, err := h.exportService.ExportAnki(r.Context(), userID, deckID, includeProgress)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	defer export.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": export.FileName,
+	}))
+	w.WriteHeader(http.StatusOK)
+
+	// The response has started, so failures can only be logged
+	if err := export.Write(w); err != nil {
+		h.logger.Error("Failed to write Anki export", utils.WithContext("error", err.Error()))
+	}
+}
+
+// progressCSVColumns are the columns of the progress CSV export
+var progressCSVColumns = []string{
+	"word", "reading", "meaning", "jlpt_level", "state", "interval_days", "ease_factor",
+	"repetitions", "next_review_date", "last_reviewed_at", "total_reviews",
+	"correct_reviews", "success_rate",
+}
+
+// ExportVocabularyCSV streams a vocabulary list as CSV, or TSV with ?format=tsv.
+// It accepts the filters and sort order of the vocabulary list endpoint.
+func (h *ExportHandler) ExportVocabularyCSV(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	stream, err := newCSVStream(w, r, "vocabulary", services.VocabularyCSVColumns)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	lq, err := parseListQuery(r, vocabularyListOptions)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	err = h.exportService.EachVocabulary(r.Context(), userID, lq.vocabularyFilter(), func(item *models.VocabularyWithProgress) error {
+		return stream.Write(services.VocabularyCSVRow(item))
+	})
+	h.finishCSV(w, stream, err)
+}
+
+// ExportProgressCSV streams the user's progress on reviewed items as CSV, or
+// TSV with ?format=tsv, optionally only for one deck (?deck=)
+func (h *ExportHandler) ExportProgressCSV(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	stream, err := newCSVStream(w, r, "progress", progressCSVColumns)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	deckID, err := parseOptionalID(r.URL.Query().Get("deck"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck"))
+		return
+	}
+
+	now := time.Now()
+	err = h.exportService.EachProgress(r.Context(), userID, deckID, func(item *models.VocabularyWithProgress) error {
+		p := item.Progress
+
+		successRate := 0.0
+		if p.TotalReviews > 0 {
+			successRate = float64(p.CorrectReviews) / float64(p.TotalReviews) * 100
+		}
+
+		lastReviewed := ""
+		if p.LastReviewedAt != nil {
+			lastReviewed = p.LastReviewedAt.Format(time.RFC3339)
+		}
+
+		return stream.Write([]string{
+			item.Word,
+			item.Reading,
+			item.Meaning,
+			strconv.Itoa(item.JLPTLevel),
+			progressState(p, now),
+			strconv.Itoa(p.Interval),
+			strconv.FormatFloat(p.EaseFactor, 'f', 2, 64),
+			strconv.Itoa(p.Repetitions),
+			p.NextReviewDate.Format(time.RFC3339),
+			lastReviewed,
+			strconv.Itoa(p.TotalReviews),
+			strconv.Itoa(p.CorrectReviews),
+			strconv.FormatFloat(successRate, 'f', 1, 64),
+		})
+	})
+	h.finishCSV(w, stream, err)
+}
+
+// finishCSV flushes a CSV export, or reports err as a JSON error when no
+// row has been written yet
+func (h *ExportHandler) finishCSV(w http.ResponseWriter, stream *csvStream, err error) {
+	if err != nil && !stream.Started() {
+		sendError(w, err)
+		return
+	}
+	if err == nil {
+		err = stream.Flush()
+	}
+
+	// The response has started, so failures can only be logged
+	if err != nil {
+		h.logger.Error("Failed to write CSV export", utils.WithContext("error", err.Error()))
+	}
+}
+
+// progressState returns the learning state of an item the user has studied
+func progressState(p *models.UserVocabularyProgress, now time.Time) string {
+	switch {
+	case !p.NextReviewDate.After(now):
+		return repository.LearningStateDue
+	case p.Interval >= repository.MasteredIntervalDays:
+		return repository.LearningStateMastered
+	default:
+		return repository.LearningStateLearning
+	}
+}
+
+// csvStream writes a CSV or TSV attachment. Nothing is sent until the first
+// row, so errors found before it can still be reported as JSON.
+type csvStream struct {
+	w        http.ResponseWriter
+	fileName string
+	comma    rune
+	columns  []string
+	writer   *csv.Writer
+}
+
+// newCSVStream prepares a CSV export named after name, or a TSV export with ?format=tsv
+func newCSVStream(w http.ResponseWriter, r *http.Request, name string, columns []string) (*csvStream, error) {
+	stream := &csvStream{w: w, columns: columns}
+
+	switch r.URL.Query().Get("format") {
+	case "", "csv":
+		stream.fileName, stream.comma = name+".csv", ','
+	case "tsv":
+		stream.fileName, stream.comma = name+".tsv", '\t'
+	default:
+		return nil, pkgErrors.BadRequest("Invalid format, expected csv or tsv")
+	}
+
+	return stream, nil
+}
+
+// Started reports whether the response has started
+func (s *csvStream) Started() bool {
+	return s.writer != nil
+}
+
+// Write writes a row, starting the response first if needed. Cells that a
+// spreadsheet would run as a formula are escaped (see escapeFormula).
+func (s *csvStream) Write(row []string) error {
+	if err := s.start(); err != nil {
+		return err
+	}
+
+	escaped := make([]string, len(row))
+	for i, cell := range row {
+		escaped[i] = escapeFormula(cell)
+	}
+	return s.writer.Write(escaped)
+}
+
+// Flush starts the response if no row was written and flushes buffered rows
+func (s *csvStream) Flush() error {
+	if err := s.start(); err != nil {
+		return err
+	}
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+// escapeFormula prefixes a cell starting with =, +, -, @, a tab or a carriage
+// return with an apostrophe, so that spreadsheet applications show it as text
+// instead of running it as a formula
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// start sends the headers, a byte order mark so that Excel reads the file as
+// UTF-8, and the header row
+func (s *csvStream) start() error {
+	if s.writer != nil {
+		return nil
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if s.comma == '\t' {
+		contentType = "text/tab-separated-values; charset=utf-8"
+	}
+	s.w.Header().Set("Content-Type", contentType)
+	s.w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": s.fileName,
+	}))
+	s.w.WriteHeader(http.StatusOK)
+
+	s.writer = csv.NewWriter(s.w)
+	s.writer.Comma = s.comma
+	if _, err := io.WriteString(s.w, "\uFEFF"); err != nil {
+		return err
+	}
+	return s.writer.Write(s.columns)
+}
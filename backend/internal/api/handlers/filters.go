@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+// pageQuery holds the pagination parameters shared by list endpoints
+type pageQuery struct {
+	Page      int
+	PageSize  int
+	Cursor    *repository.Cursor
+	WithTotal bool
+}
+
+// listQuery holds the pagination, filter and sort parameters shared by list endpoints
+type listQuery struct {
+	pageQuery
+	JLPTLevel     *int
+	PartOfSpeech  *string
+	LearningState *string
+	Tags          []string
+	HasAudio      *bool
+	DeckID        *int
+	Visibility    *string
+	Sort          repository.SortOrder
+}
+
+// listOptions describes the filter values and sort fields a list endpoint accepts
+type listOptions struct {
+	States      []string
+	SortFields  []string
+	DefaultSort string
+}
+
+var (
+	vocabularyListOptions = listOptions{
+		States: []string{
+			repository.LearningStateNew, repository.LearningStateLearning,
+			repository.LearningStateDue, repository.LearningStateMastered,
+		},
+		SortFields: []string{
+			repository.SortByID, repository.SortByWord, repository.SortByReading,
+			repository.SortByNextReview, repository.SortBySuccessRate,
+		},
+		DefaultSort: repository.SortByID,
+	}
+
+	grammarListOptions = listOptions{
+		States: []string{
+			repository.LearningStateNew, repository.LearningStateLearning, repository.LearningStateMastered,
+		},
+		SortFields: []string{
+			repository.SortByLessonOrder, repository.SortByID, repository.SortByTitle,
+		},
+		DefaultSort: repository.SortByLessonOrder,
+	}
+)
+
+// parseListQuery parses the query parameters of a list endpoint:
+//
+//	page, page_size, cursor, count    see parsePageQuery
+//	jlpt_level                        1-5, ignored when invalid
+//	part_of_speech                    part of speech; verb or adjective also match their classes
+//	state                             learning state, one of opts.States
+//	tags                              comma-separated, may be repeated; items must have every tag
+//	has_audio                         true or false
+//	deck                              ID of one of the user's decks
+//	visibility                        public, or private for the user's own entries
+//	sort, order                       one of opts.SortFields, asc or desc
+func parseListQuery(r *http.Request, opts listOptions) (listQuery, error) {
+	query := r.URL.Query()
+	lq := listQuery{
+		Sort: repository.SortOrder{Field: opts.DefaultSort},
+	}
+
+	if field := query.Get("sort"); field != "" {
+		if !slices.Contains(opts.SortFields, field) {
+			return lq, pkgErrors.BadRequest("Invalid sort, expected one of: " + strings.Join(opts.SortFields, ", "))
+		}
+		lq.Sort.Field = field
+	}
+
+	switch strings.ToLower(query.Get("order")) {
+	case "", "asc":
+	case "desc":
+		lq.Sort.Descending = true
+	default:
+		return lq, pkgErrors.BadRequest("Invalid order, expected asc or desc")
+	}
+
+	var err error
+	if lq.pageQuery, err = parsePageQuery(query, lq.Sort); err != nil {
+		return lq, err
+	}
+
+	if levelStr := query.Get("jlpt_level"); levelStr != "" {
+		level, err := strconv.Atoi(levelStr)
+		if err == nil && level >= 1 && level <= 5 {
+			lq.JLPTLevel = &level
+		}
+	}
+
+	if pos := strings.TrimSpace(query.Get("part_of_speech")); pos != "" {
+		lq.PartOfSpeech = &pos
+	}
+
+	if state := query.Get("state"); state != "" {
+		if !slices.Contains(opts.States, state) {
+			return lq, pkgErrors.BadRequest("Invalid state, expected one of: " + strings.Join(opts.States, ", "))
+		}
+		lq.LearningState = &state
+	}
+
+	lq.Tags = parseTags(query)
+
+	if audioStr := query.Get("has_audio"); audioStr != "" {
+		hasAudio, err := strconv.ParseBool(audioStr)
+		if err != nil {
+			return lq, pkgErrors.BadRequest("Invalid has_audio, expected true or false")
+		}
+		lq.HasAudio = &hasAudio
+	}
+
+	if lq.DeckID, err = parseOptionalID(query.Get("deck")); err != nil {
+		return lq, pkgErrors.BadRequest("Invalid deck")
+	}
+
+	switch visibility := query.Get("visibility"); visibility {
+	case "":
+	case models.VisibilityPublic, models.VisibilityPrivate:
+		lq.Visibility = &visibility
+	default:
+		return lq, pkgErrors.BadRequest("Invalid visibility, expected public or private")
+	}
+
+	return lq, nil
+}
+
+// parsePageQuery parses the pagination parameters of a list endpoint:
+//
+//	page, page_size    offset pagination (defaults 1 and 20, page_size at most 100)
+//	cursor             next_cursor of a previous page, used instead of page
+//	count              whether to return totals, defaults to true without a cursor
+//
+// A cursor is only valid for the sort order it was issued for.
+func parsePageQuery(query url.Values, sort repository.SortOrder) (pageQuery, error) {
+	var pq pageQuery
+
+	pq.Page, _ = strconv.Atoi(query.Get("page"))
+	if pq.Page < 1 {
+		pq.Page = 1
+	}
+	pq.PageSize, _ = strconv.Atoi(query.Get("page_size"))
+	if pq.PageSize < 1 || pq.PageSize > 100 {
+		pq.PageSize = 20
+	}
+
+	if cursorStr := query.Get("cursor"); cursorStr != "" {
+		cursor, err := repository.DecodeCursor(cursorStr)
+		if err != nil {
+			return pq, pkgErrors.BadRequest("Invalid cursor")
+		}
+		if cursor.Sort != sort.String() {
+			return pq, pkgErrors.BadRequest("Cursor does not match the requested sort order")
+		}
+		pq.Cursor = cursor
+	}
+
+	pq.WithTotal = pq.Cursor == nil
+	if countStr := query.Get("count"); countStr != "" {
+		withTotal, err := strconv.ParseBool(countStr)
+		if err != nil {
+			return pq, pkgErrors.BadRequest("Invalid count, expected true or false")
+		}
+		pq.WithTotal = withTotal
+	}
+
+	return pq, nil
+}
+
+// vocabularyFilter returns the vocabulary repository filter for the query
+func (lq listQuery) vocabularyFilter() repository.VocabularyFilter {
+	return repository.VocabularyFilter{
+		JLPTLevel:     lq.JLPTLevel,
+		PartOfSpeech:  lq.PartOfSpeech,
+		LearningState: lq.LearningState,
+		Tags:          lq.Tags,
+		HasAudio:      lq.HasAudio,
+		DeckID:        lq.DeckID,
+		Visibility:    lq.Visibility,
+		Sort:          lq.Sort,
+		After:         lq.Cursor,
+	}
+}
+
+// grammarFilter returns the grammar repository filter for the query
+func (lq listQuery) grammarFilter() repository.GrammarFilter {
+	return repository.GrammarFilter{
+		JLPTLevel:     lq.JLPTLevel,
+		LearningState: lq.LearningState,
+		Tags:          lq.Tags,
+		Sort:          lq.Sort,
+		After:         lq.Cursor,
+	}
+}
+
+// totals returns the total and total pages of a response, or nils when not counted
+func (pq pageQuery) totals(total *int) (*int, *int) {
+	if total == nil {
+		return nil, nil
+	}
+	totalPages := (*total + pq.PageSize - 1) / pq.PageSize
+	return total, &totalPages
+}
+
+// encodeCursor returns the opaque form of a next page cursor, or "" on the last page
+func encodeCursor(cursor *repository.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return cursor.Encode()
+}
+
+// parseTags collects tags from "tags=a,b" and repeated "tags=" parameters
+func parseTags(query url.Values) []string {
+	var tags []string
+	for _, value := range query["tags"] {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// parseOptionalID parses an optional positive ID parameter, returning nil when empty
+func parseOptionalID(value string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 1 {
+		return nil, strconv.ErrSyntax
+	}
+	return &id, nil
+}
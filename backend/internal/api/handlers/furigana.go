@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/joaosantos/jlpt5/internal/api/dto"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/furigana"
+)
+
+// newFuriganaAnnotator parses the furigana parameters of a request:
+//
+//	furigana       true to annotate Japanese text with ruby segments
+//	hide_mastered  true to leave out furigana over kanji the user has mastered
+//
+// It returns nil when furigana was not requested.
+func newFuriganaAnnotator(r *http.Request, furiganaService *services.FuriganaService, userID int) (*services.FuriganaAnnotator, error) {
+	query := r.URL.Query()
+
+	enabled := false
+	if furiganaStr := query.Get("furigana"); furiganaStr != "" {
+		var err error
+		if enabled, err = strconv.ParseBool(furiganaStr); err != nil {
+			return nil, pkgErrors.BadRequest("Invalid furigana, expected true or false")
+		}
+	}
+
+	hideMastered := false
+	if hideStr := query.Get("hide_mastered"); hideStr != "" {
+		var err error
+		if hideMastered, err = strconv.ParseBool(hideStr); err != nil {
+			return nil, pkgErrors.BadRequest("Invalid hide_mastered, expected true or false")
+		}
+	}
+
+	if !enabled {
+		return nil, nil
+	}
+	return furiganaService.NewAnnotator(r.Context(), userID, hideMastered)
+}
+
+// addVocabularyFurigana annotates the word and example sentence of a
+// vocabulary response; it does nothing without an annotator
+func addVocabularyFurigana(a *services.FuriganaAnnotator, response *dto.VocabularyResponse) {
+	if a == nil {
+		return
+	}
+
+	response.WordFurigana = toRubySegments(a.Word(response.Word, response.Reading))
+	if response.ExampleSentence != nil {
+		response.ExampleSentenceFurigana = toRubySegments(a.Sentence(*response.ExampleSentence, response.Word, response.Reading))
+	}
+}
+
+// addGrammarFurigana annotates the example sentences of a grammar lesson
+// response; it does nothing without an annotator
+func addGrammarFurigana(a *services.FuriganaAnnotator, response *dto.GrammarLessonResponse) {
+	if a == nil {
+		return
+	}
+
+	for i := range response.Examples {
+		example := &response.Examples[i]
+		example.JapaneseSentenceFurigana = toRubySegments(a.Sentence(example.JapaneseSentence, "", ""))
+	}
+}
+
+func toRubySegments(segments []furigana.Segment) []dto.RubySegment {
+	responses := make([]dto.RubySegment, len(segments))
+	for i, s := range segments {
+		responses[i] = dto.RubySegment{Text: s.Text, Reading: s.Reading}
+	}
+	return responses
+}
@@ -15,15 +15,17 @@ import (
 
 // GrammarHandler handles grammar endpoints
 type GrammarHandler struct {
-	grammarService *services.GrammarService
-	logger         *utils.Logger
+	grammarService  *services.GrammarService
+	furiganaService *services.FuriganaService
+	logger          *utils.Logger
 }
 
 // NewGrammarHandler creates a new grammar handler
-func NewGrammarHandler(grammarService *services.GrammarService, logger *utils.Logger) *GrammarHandler {
+func NewGrammarHandler(grammarService *services.GrammarService, furiganaService *services.FuriganaService, logger *utils.Logger) *GrammarHandler {
 	return &GrammarHandler{
-		grammarService: grammarService,
-		logger:         logger,
+		grammarService:  grammarService,
+		furiganaService: furiganaService,
+		logger:          logger,
 	}
 }
 
@@ -36,39 +38,33 @@ func (h *GrammarHandler) ListGrammar(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserIDFromContext(r)
 
-	// Parse query parameters
-	query := r.URL.Query()
-	page, _ := strconv.Atoi(query.Get("page"))
-	if page < 1 {
-		page = 1
-	}
-	pageSize, _ := strconv.Atoi(query.Get("page_size"))
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+	lq, err := parseListQuery(r, grammarListOptions)
+	if err != nil {
+		sendError(w, err)
+		return
 	}
 
-	var jlptLevel *int
-	if levelStr := query.Get("jlpt_level"); levelStr != "" {
-		level, err := strconv.Atoi(levelStr)
-		if err == nil && level >= 1 && level <= 5 {
-			jlptLevel = &level
-		}
+	annotator, err := newFuriganaAnnotator(r, h.furiganaService, userID)
+	if err != nil {
+		sendError(w, err)
+		return
 	}
 
-	lessons, total, err := h.grammarService.GetLessonsList(r.Context(), userID, jlptLevel, page, pageSize)
+	lessons, next, total, err := h.grammarService.GetLessonsList(r.Context(), userID, lq.grammarFilter(), lq.Page, lq.PageSize, lq.WithTotal)
 	if err != nil {
 		sendError(w, err)
 		return
 	}
 
-	totalPages := (total + pageSize - 1) / pageSize
-
 	response := dto.GrammarListResponse{
 		Items:      toGrammarLessonResponseList(lessons),
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
+		Page:       lq.Page,
+		PageSize:   lq.PageSize,
+		NextCursor: encodeCursor(next),
+	}
+	response.Total, response.TotalPages = lq.totals(total)
+	for i := range response.Items {
+		addGrammarFurigana(annotator, &response.Items[i])
 	}
 
 	sendSuccess(w, http.StatusOK, response)
@@ -83,13 +79,22 @@ func (h *GrammarHandler) GetGrammarLesson(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	annotator, err := newFuriganaAnnotator(r, h.furiganaService, userID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
 	lesson, err := h.grammarService.GetLessonByID(r.Context(), userID, lessonID)
 	if err != nil {
 		sendError(w, err)
 		return
 	}
 
-	sendSuccess(w, http.StatusOK, toGrammarLessonResponse(*lesson))
+	response := toGrammarLessonResponse(*lesson)
+	addGrammarFurigana(annotator, &response)
+
+	sendSuccess(w, http.StatusOK, response)
 }
 
 // MarkCompleted marks a grammar lesson as completed
@@ -131,6 +136,7 @@ func toGrammarLessonResponse(lesson models.GrammarLessonWithExamples) dto.Gramma
 		UsageNotes:   lesson.UsageNotes,
 		JLPTLevel:    lesson.JLPTLevel,
 		LessonOrder:  lesson.LessonOrder,
+		Tags:         lesson.Tags,
 		Examples:     toGrammarExampleResponseList(lesson.Examples),
 	}
 
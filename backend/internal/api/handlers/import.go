@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joaosantos/jlpt5/internal/api/dto"
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+const (
+	// maxImportUploadSize limits the size of uploaded Anki packages
+	maxImportUploadSize = 256 << 20
+	// maxCSVUploadSize limits the size of uploaded CSV and TSV files
+	maxCSVUploadSize = 10 << 20
+	// importFormMemory is how much of a multipart form is kept in memory
+	importFormMemory = 1 << 20
+)
+
+// ImportHandler handles vocabulary import endpoints
+type ImportHandler struct {
+	importService *services.ImportService
+	logger        *utils.Logger
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(importService *services.ImportService, logger *utils.Logger) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+		logger:        logger,
+	}
+}
+
+// ImportAnki starts importing an uploaded Anki package. The multipart form
+// holds the package in "file" and the field mapping as a JSON object in
+// "mapping", e.g. {"word":"Expression","reading":"Reading","meaning":"Meaning"}.
+// Optional fields are "note_type", "include_reviews" and "jlpt_level".
+func (h *ImportHandler) ImportAnki(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportUploadSize)
+	if err := r.ParseMultipartForm(importFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			sendError(w, pkgErrors.BadRequest("File is too large"))
+			return
+		}
+		sendError(w, pkgErrors.BadRequest("Invalid multipart form"))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	opts := services.AnkiImportOptions{
+		NoteType:       strings.TrimSpace(r.FormValue("note_type")),
+		IncludeReviews: r.FormValue("include_reviews") == "true",
+	}
+	if err := json.Unmarshal([]byte(r.FormValue("mapping")), &opts.FieldMap); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid field mapping"))
+		return
+	}
+	if level := r.FormValue("jlpt_level"); level != "" {
+		parsed, err := strconv.Atoi(level)
+		if err != nil {
+			sendError(w, pkgErrors.BadRequest("Invalid JLPT level"))
+			return
+		}
+		opts.JLPTLevel = parsed
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Missing file"))
+		return
+	}
+	defer file.Close()
+
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	if ext != ".apkg" && ext != ".colpkg" {
+		sendError(w, pkgErrors.BadRequest("File must be an .apkg or .colpkg package"))
+		return
+	}
+
+	// The import outlives the request, so the upload is copied to a file the
+	// service removes when it is done
+	path, err := saveUpload(file, "anki-import-*"+ext)
+	if err != nil {
+		h.logger.Error("Failed to save upload", utils.WithContext("error", err.Error()))
+		sendError(w, pkgErrors.Internal("Failed to save upload", err))
+		return
+	}
+
+	job, err := h.importService.StartAnkiImport(r.Context(), userID, filepath.Base(header.Filename), path, opts)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusAccepted, toImportJobResponse(job))
+}
+
+// ImportCSV starts importing vocabulary from an uploaded CSV or TSV file and
+// responds with the pending job, whose warnings will list the rows that were
+// skipped. The
+// multipart form holds the file in "file" and optionally the column mapping
+// as a JSON object in "mapping", e.g. {"word":"Kanji","reading":"Kana"},
+// and "format" (csv or tsv, by default from the file extension).
+func (h *ImportHandler) ImportCSV(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCSVUploadSize)
+	if err := r.ParseMultipartForm(importFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			sendError(w, pkgErrors.BadRequest("File is too large"))
+			return
+		}
+		sendError(w, pkgErrors.BadRequest("Invalid multipart form"))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	var opts services.CSVImportOptions
+	if mapping := r.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &opts.FieldMap); err != nil {
+			sendError(w, pkgErrors.BadRequest("Invalid field mapping"))
+			return
+		}
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Missing file"))
+		return
+	}
+	defer file.Close()
+
+	format := r.FormValue("format")
+	if format == "" {
+		format = "csv"
+		if ext := strings.ToLower(filepath.Ext(header.Filename)); ext == ".tsv" || ext == ".tab" {
+			format = "tsv"
+		}
+	}
+	switch format {
+	case "csv":
+		opts.Comma = ','
+	case "tsv":
+		opts.Comma = '\t'
+	default:
+		sendError(w, pkgErrors.BadRequest("Invalid format, expected csv or tsv"))
+		return
+	}
+
+	job, err := h.importService.StartCSVImport(r.Context(), userID, filepath.Base(header.Filename), file, opts)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusAccepted, toImportJobResponse(job))
+}
+
+// ListImports retrieves the user's recent import jobs
+func (h *ImportHandler) ListImports(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	jobs, err := h.importService.ListJobs(r.Context(), userID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	responses := make([]dto.ImportJobResponse, len(jobs))
+	for i := range jobs {
+		responses[i] = toImportJobResponse(&jobs[i])
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"items": responses,
+		"count": len(responses),
+	})
+}
+
+// GetImport retrieves the status of an import job
+func (h *ImportHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	jobID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid import ID"))
+		return
+	}
+
+	job, err := h.importService.GetJob(r.Context(), userID, jobID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, toImportJobResponse(job))
+}
+
+// saveUpload copies an uploaded file to a new temporary file and returns its path
+func saveUpload(src io.Reader, pattern string) (string, error) {
+	out, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(out.Name())
+		return "", err
+	}
+
+	return out.Name(), nil
+}
+
+// toImportJobResponse converts an import job to its API representation
+func toImportJobResponse(job *models.ImportJob) dto.ImportJobResponse {
+	resp := dto.ImportJobResponse{
+		ID:               job.ID,
+		Source:           job.Source,
+		FileName:         job.FileName,
+		Status:           job.Status,
+		TotalItems:       job.TotalItems,
+		CreatedItems:     job.CreatedItems,
+		MatchedItems:     job.MatchedItems,
+		UpdatedItems:     job.UpdatedItems,
+		SkippedItems:     job.SkippedItems,
+		FailedItems:      job.FailedItems,
+		ProgressImported: job.ProgressImported,
+		ReviewsImported:  job.ReviewsImported,
+		Warnings:         job.Warnings,
+		Error:            job.Error,
+		CreatedAt:        job.CreatedAt.Format(time.RFC3339),
+	}
+	if resp.Warnings == nil {
+		resp.Warnings = []string{}
+	}
+
+	if job.StartedAt != nil {
+		formatted := job.StartedAt.Format(time.RFC3339)
+		resp.StartedAt = &formatted
+	}
+	if job.FinishedAt != nil {
+		formatted := job.FinishedAt.Format(time.RFC3339)
+		resp.FinishedAt = &formatted
+	}
+
+	return resp
+}
@@ -7,22 +7,28 @@ import (
 
 	"github.com/joaosantos/jlpt5/internal/api/dto"
 	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
 	"github.com/joaosantos/jlpt5/internal/domain/services"
 	"github.com/joaosantos/jlpt5/internal/utils"
 	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
 )
 
+// maxIdempotencyKeyLength is the longest Idempotency-Key a submit may carry
+const maxIdempotencyKeyLength = 255
+
 // QuizHandler handles quiz endpoints
 type QuizHandler struct {
-	quizService *services.QuizService
-	logger      *utils.Logger
+	quizService       *services.QuizService
+	distractorService *services.DistractorService
+	logger            *utils.Logger
 }
 
 // NewQuizHandler creates a new quiz handler
-func NewQuizHandler(quizService *services.QuizService, logger *utils.Logger) *QuizHandler {
+func NewQuizHandler(quizService *services.QuizService, distractorService *services.DistractorService, logger *utils.Logger) *QuizHandler {
 	return &QuizHandler{
-		quizService: quizService,
-		logger:      logger,
+		quizService:       quizService,
+		distractorService: distractorService,
+		logger:            logger,
 	}
 }
 
@@ -79,7 +85,7 @@ func (h *QuizHandler) GetQuiz(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	quiz, err := h.quizService.GetQuizByID(r.Context(), quizID)
+	quiz, err := h.quizService.GetQuizByID(r.Context(), getUserIDFromContext(r), quizID)
 	if err != nil {
 		sendError(w, err)
 		return
@@ -103,17 +109,87 @@ func (h *QuizHandler) StartQuiz(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := dto.StartQuizResponse{
-		SessionID: session.ID,
-		Quiz:      toQuizResponse(quizWithQuestions.Quiz),
-		Questions: toQuizQuestionResponseList(quizWithQuestions.Questions),
-		StartedAt: session.StartedAt.Format("2006-01-02T15:04:05Z07:00"),
+	response := toStartQuizResponse(quizWithQuestions, session)
+	sendSuccess(w, http.StatusOK, response)
+}
+
+// GenerateQuiz generates a quiz from a question source and starts a session of it
+func (h *QuizHandler) GenerateQuiz(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	var req dto.GenerateQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
 	}
 
-	sendSuccess(w, http.StatusOK, response)
+	if req.JLPTLevel == 0 {
+		req.JLPTLevel = 5
+	}
+	if req.Size == 0 {
+		req.Size = 10
+	}
+
+	quizWithQuestions, session, err := h.quizService.GenerateQuiz(r.Context(), userID, req.Type, req.JLPTLevel, req.Size)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	response := toStartQuizResponse(quizWithQuestions, session)
+	sendSuccess(w, http.StatusCreated, response)
 }
 
-// SubmitQuiz submits answers for a quiz session
+// ValidateQuestion checks the options of an authored multiple choice
+// question and suggests better distractors (admin)
+func (h *QuizHandler) ValidateQuestion(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	var req dto.ValidateQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	question := models.QuizQuestion{
+		QuestionType:  models.QuestionType(req.QuestionType),
+		QuestionText:  req.QuestionText,
+		OptionA:       req.OptionA,
+		OptionB:       req.OptionB,
+		OptionC:       req.OptionC,
+		OptionD:       req.OptionD,
+		CorrectAnswer: req.CorrectAnswer,
+	}
+
+	validation, err := h.distractorService.ValidateQuestion(r.Context(), userID, question)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	issues := make([]dto.QuestionIssueResponse, len(validation.Issues))
+	for i, issue := range validation.Issues {
+		issues[i] = dto.QuestionIssueResponse{
+			Option:  issue.Option,
+			Problem: issue.Problem,
+			Message: issue.Message,
+		}
+	}
+
+	suggestions := validation.Suggestions
+	if suggestions == nil {
+		suggestions = []string{}
+	}
+
+	sendSuccess(w, http.StatusOK, dto.ValidateQuestionResponse{
+		Valid:       len(issues) == 0,
+		Issues:      issues,
+		Suggestions: suggestions,
+	})
+}
+
+// SubmitQuiz submits answers for a quiz session. A submit retried with the
+// same Idempotency-Key header returns the result of the first one.
 func (h *QuizHandler) SubmitQuiz(w http.ResponseWriter, r *http.Request) {
 	userID := getUserIDFromContext(r)
 	sessionID, err := strconv.Atoi(r.PathValue("id"))
@@ -128,12 +204,13 @@ func (h *QuizHandler) SubmitQuiz(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(req.Answers) == 0 {
-		sendError(w, pkgErrors.BadRequest("No answers provided"))
+	submitKey := r.Header.Get("Idempotency-Key")
+	if len(submitKey) > maxIdempotencyKeyLength {
+		sendError(w, pkgErrors.BadRequest("Idempotency-Key is too long"))
 		return
 	}
 
-	result, err := h.quizService.SubmitQuizAnswers(r.Context(), userID, sessionID, req.Answers)
+	result, err := h.quizService.SubmitQuizAnswers(r.Context(), userID, sessionID, req.Answers, submitKey)
 	if err != nil {
 		sendError(w, err)
 		return
@@ -143,6 +220,61 @@ func (h *QuizHandler) SubmitQuiz(w http.ResponseWriter, r *http.Request) {
 	sendSuccess(w, http.StatusOK, response)
 }
 
+// SaveQuizAnswer saves the answer to one question of a session in progress
+func (h *QuizHandler) SaveQuizAnswer(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	sessionID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid session ID"))
+		return
+	}
+	questionID, err := strconv.Atoi(r.PathValue("questionId"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid question ID"))
+		return
+	}
+
+	var req dto.SaveQuizAnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	answer, err := h.quizService.SaveQuizAnswer(r.Context(), userID, sessionID, questionID, req.Answer)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, toSavedQuizAnswerResponse(*answer))
+}
+
+// ResumeQuiz retrieves a session in progress with the answers saved so far
+func (h *QuizHandler) ResumeQuiz(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	sessionID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid session ID"))
+		return
+	}
+
+	quizWithQuestions, session, answers, err := h.quizService.ResumeQuizSession(r.Context(), userID, sessionID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	saved := make([]dto.SavedQuizAnswerResponse, len(answers))
+	for i, answer := range answers {
+		saved[i] = toSavedQuizAnswerResponse(answer)
+	}
+
+	sendSuccess(w, http.StatusOK, dto.ResumeQuizResponse{
+		StartQuizResponse: toStartQuizResponse(quizWithQuestions, session),
+		Answers:           saved,
+	})
+}
+
 // GetQuizResult retrieves the result of a quiz session
 func (h *QuizHandler) GetQuizResult(w http.ResponseWriter, r *http.Request) {
 	userID := getUserIDFromContext(r)
@@ -171,28 +303,29 @@ func (h *QuizHandler) GetQuizHistory(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserIDFromContext(r)
 
-	// Parse query parameters
-	query := r.URL.Query()
-	page, _ := strconv.Atoi(query.Get("page"))
-	if page < 1 {
-		page = 1
+	// History has always been served without totals, so only count on request
+	pq, err := parsePageQuery(r.URL.Query(), repository.QuizHistorySort)
+	if err != nil {
+		sendError(w, err)
+		return
 	}
-	pageSize, _ := strconv.Atoi(query.Get("page_size"))
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+	if r.URL.Query().Get("count") == "" {
+		pq.WithTotal = false
 	}
 
-	sessions, err := h.quizService.GetUserQuizHistory(r.Context(), userID, page, pageSize)
+	sessions, next, total, err := h.quizService.GetUserQuizHistory(r.Context(), userID, pq.Cursor, pq.Page, pq.PageSize, pq.WithTotal)
 	if err != nil {
 		sendError(w, err)
 		return
 	}
 
 	response := dto.QuizHistoryResponse{
-		Sessions: toQuizSessionResponseList(sessions),
-		Page:     page,
-		PageSize: pageSize,
+		Sessions:   toQuizSessionResponseList(sessions),
+		Page:       pq.Page,
+		PageSize:   pq.PageSize,
+		NextCursor: encodeCursor(next),
 	}
+	response.Total, response.TotalPages = pq.totals(total)
 
 	sendSuccess(w, http.StatusOK, response)
 }
@@ -201,15 +334,34 @@ func (h *QuizHandler) GetQuizHistory(w http.ResponseWriter, r *http.Request) {
 
 func toQuizResponse(quiz models.Quiz) dto.QuizResponse {
 	return dto.QuizResponse{
-		ID:           quiz.ID,
-		Title:        quiz.Title,
-		Description:  quiz.Description,
-		JLPTLevel:    quiz.JLPTLevel,
-		QuizType:     quiz.QuizType,
-		PassingScore: quiz.PassingScore,
+		ID:                 quiz.ID,
+		Title:              quiz.Title,
+		Description:        quiz.Description,
+		JLPTLevel:          quiz.JLPTLevel,
+		QuizType:           quiz.QuizType,
+		TimeLimitMinutes:   quiz.TimeLimitMinutes,
+		PassingScore:       quiz.PassingScore,
+		ShuffleQuestions:   quiz.ShuffleQuestions,
+		ShuffleOptions:     quiz.ShuffleOptions,
+		WrongAnswerPenalty: quiz.WrongAnswerPenalty,
+		PartialCredit:      quiz.PartialCredit,
 	}
 }
 
+func toStartQuizResponse(quizWithQuestions *services.QuizWithQuestions, session *models.QuizSession) dto.StartQuizResponse {
+	response := dto.StartQuizResponse{
+		SessionID: session.ID,
+		Quiz:      toQuizResponse(quizWithQuestions.Quiz),
+		Questions: toQuizQuestionResponseList(quizWithQuestions.Questions),
+		StartedAt: session.StartedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if session.Deadline != nil {
+		deadline := session.Deadline.Format("2006-01-02T15:04:05Z07:00")
+		response.Deadline = &deadline
+	}
+	return response
+}
+
 func toQuizResponseList(quizzes []models.Quiz) []dto.QuizResponse {
 	responses := make([]dto.QuizResponse, len(quizzes))
 	for i, quiz := range quizzes {
@@ -289,6 +441,7 @@ func toQuizQuestionDetailResponseList(questions []models.QuizQuestion) []dto.Qui
 func toQuizAnswerResponse(answer models.QuizAnswer) dto.QuizAnswerResponse {
 	var userAnswer string
 	var isCorrect bool
+	var pointsAwarded float64
 
 	if answer.UserAnswer != nil {
 		userAnswer = *answer.UserAnswer
@@ -296,11 +449,29 @@ func toQuizAnswerResponse(answer models.QuizAnswer) dto.QuizAnswerResponse {
 	if answer.IsCorrect != nil {
 		isCorrect = *answer.IsCorrect
 	}
+	if answer.PointsAwarded != nil {
+		pointsAwarded = *answer.PointsAwarded
+	}
 
 	return dto.QuizAnswerResponse{
+		QuestionID:    answer.QuizQuestionID,
+		UserAnswer:    userAnswer,
+		Answered:      answer.IsAnswered(),
+		IsCorrect:     isCorrect,
+		PointsAwarded: pointsAwarded,
+	}
+}
+
+func toSavedQuizAnswerResponse(answer models.QuizAnswer) dto.SavedQuizAnswerResponse {
+	var userAnswer string
+	if answer.UserAnswer != nil {
+		userAnswer = *answer.UserAnswer
+	}
+
+	return dto.SavedQuizAnswerResponse{
 		QuestionID: answer.QuizQuestionID,
 		UserAnswer: userAnswer,
-		IsCorrect:  isCorrect,
+		AnsweredAt: answer.AnsweredAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
@@ -313,8 +484,8 @@ func toQuizAnswerResponseList(answers []models.QuizAnswer) []dto.QuizAnswerRespo
 }
 
 func toQuizResultResponse(result *services.QuizSessionResult) dto.QuizResultResponse {
-	var score, totalPoints int
-	var percentage float64
+	var score, percentage float64
+	var totalPoints int
 	var passed bool
 	var completedAt string
 
@@ -346,9 +517,27 @@ func toQuizResultResponse(result *services.QuizSessionResult) dto.QuizResultResp
 		CompletedAt:    completedAt,
 		Questions:      toQuizQuestionDetailResponseList(result.Questions),
 		Answers:        toQuizAnswerResponseList(result.Answers),
+		Sections:       toQuizSectionScoreResponseList(result.Sections),
 	}
 }
 
+func toQuizSectionScoreResponseList(sections []services.SectionScore) []dto.QuizSectionScoreResponse {
+	responses := make([]dto.QuizSectionScoreResponse, len(sections))
+	for i, section := range sections {
+		responses[i] = dto.QuizSectionScoreResponse{
+			Weight:      1,
+			Score:       section.Score,
+			TotalPoints: section.TotalPoints,
+			Percentage:  section.Percentage,
+		}
+		if section.Section != nil {
+			responses[i].Name = section.Section.Name
+			responses[i].Weight = section.Section.Weight
+		}
+	}
+	return responses
+}
+
 func toQuizSessionResponse(session models.QuizSession) dto.QuizSessionResponse {
 	response := dto.QuizSessionResponse{
 		ID:         session.ID,
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/joaosantos/jlpt5/internal/api/dto"
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+// TagHandler handles user tag endpoints
+type TagHandler struct {
+	tagService *services.TagService
+	logger     *utils.Logger
+}
+
+// NewTagHandler creates a new tag handler
+func NewTagHandler(tagService *services.TagService, logger *utils.Logger) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+		logger:     logger,
+	}
+}
+
+// ListTags retrieves the user's tags
+func (h *TagHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	tags, err := h.tagService.ListTags(r.Context(), userID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	responses := make([]dto.TagResponse, len(tags))
+	for i := range tags {
+		responses[i] = toTagResponse(&tags[i])
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"items": responses,
+		"count": len(responses),
+	})
+}
+
+// CreateTag creates a tag
+func (h *TagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	var req dto.TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	tag, err := h.tagService.CreateTag(r.Context(), userID, req.Name)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusCreated, toTagResponse(tag))
+}
+
+// DeleteTag deletes a tag
+func (h *TagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	tagID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid tag ID"))
+		return
+	}
+
+	if err := h.tagService.DeleteTag(r.Context(), userID, tagID); err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tag deleted",
+	})
+}
+
+// TagItems adds a tag to vocabulary items
+func (h *TagHandler) TagItems(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	tagID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid tag ID"))
+		return
+	}
+
+	var req dto.ItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	tagged, err := h.tagService.TagItems(r.Context(), userID, tagID, req.VocabularyIDs)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, dto.ItemsResponse{
+		Requested: len(req.VocabularyIDs),
+		Added:     tagged,
+	})
+}
+
+// UntagItem removes a tag from a vocabulary item
+func (h *TagHandler) UntagItem(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	tagID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid tag ID"))
+		return
+	}
+	vocabID, err := strconv.Atoi(r.PathValue("vocabularyId"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid vocabulary ID"))
+		return
+	}
+
+	if err := h.tagService.UntagItem(r.Context(), userID, tagID, vocabID); err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tag removed from item",
+	})
+}
+
+// Helper functions
+
+func toTagResponse(tag *models.Tag) dto.TagResponse {
+	return dto.TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		ItemCount: tag.ItemCount,
+		CreatedAt: tag.CreatedAt.Format(time.RFC3339),
+	}
+}
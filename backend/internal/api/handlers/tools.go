@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+
+	"github.com/joaosantos/jlpt5/internal/api/dto"
+	"github.com/joaosantos/jlpt5/internal/domain/services"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+// ToolsHandler handles language tool endpoints
+type ToolsHandler struct {
+	toolsService *services.ToolsService
+	logger       *utils.Logger
+}
+
+// NewToolsHandler creates a new tools handler
+func NewToolsHandler(toolsService *services.ToolsService, logger *utils.Logger) *ToolsHandler {
+	return &ToolsHandler{
+		toolsService: toolsService,
+		logger:       logger,
+	}
+}
+
+// Convert converts text between romaji, hiragana and katakana
+func (h *ToolsHandler) Convert(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	text := query.Get("text")
+	to := query.Get("to")
+	system := query.Get("system")
+
+	result, err := h.toolsService.ConvertKana(text, to, system)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	response := dto.ConvertResponse{
+		Text:   text,
+		To:     to,
+		Result: result,
+	}
+	if to == services.ConvertToRomaji {
+		response.System = system
+		if response.System == "" {
+			response.System = "hepburn"
+		}
+	}
+
+	sendSuccess(w, http.StatusOK, response)
+}
+
+// Tokenize splits Japanese text into words linked to vocabulary items
+func (h *ToolsHandler) Tokenize(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	var req dto.TokenizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	tokens, err := h.toolsService.Tokenize(r.Context(), userID, req.Text)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	response := dto.TokenizeResponse{
+		Text:   req.Text,
+		Tokens: make([]dto.TokenResponse, len(tokens)),
+	}
+	for i, t := range tokens {
+		response.Tokens[i] = toTokenResponse(t)
+	}
+
+	sendSuccess(w, http.StatusOK, response)
+}
+
+// toTokenResponse converts a token to its API representation
+func toTokenResponse(t services.TextToken) dto.TokenResponse {
+	response := dto.TokenResponse{
+		Surface:  t.Surface,
+		BaseForm: t.BaseForm,
+		Reading:  t.Reading,
+		POS:      t.POS,
+		POSTags:  t.POSTags,
+		Start:    t.Start,
+		End:      t.End,
+		Known:    t.Known,
+	}
+	if v := t.Vocabulary; v != nil {
+		response.VocabularyID = &v.ID
+		response.Meaning = &v.Meaning
+		response.JLPTLevel = &v.JLPTLevel
+	}
+	return response
+}
+
+// Analyze reports how much of a Japanese text the user can read
+func (h *ToolsHandler) Analyze(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	var req dto.AnalyzeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	analysis, err := h.toolsService.AnalyzeText(r.Context(), userID, req.Text)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, toAnalyzeResponse(analysis))
+}
+
+// toAnalyzeResponse converts a text analysis to its API representation,
+// listing JLPT levels from N5 to N1 followed by words not in the vocabulary
+func toAnalyzeResponse(a *services.TextAnalysis) dto.AnalyzeResponse {
+	percent := func(n int) float64 {
+		if a.TotalWords == 0 {
+			return 0
+		}
+		return math.Round(float64(n)/float64(a.TotalWords)*1000) / 10
+	}
+
+	response := dto.AnalyzeResponse{
+		TotalWords:           a.TotalWords,
+		UniqueWords:          len(a.Words),
+		Known:                a.KnownWords,
+		Learning:             a.LearningWords,
+		Unknown:              a.UnknownWords,
+		KnownPercent:         percent(a.KnownWords),
+		LearningPercent:      percent(a.LearningWords),
+		UnknownPercent:       percent(a.UnknownWords),
+		Readable:             a.TotalWords > 0 && a.KnownCoverage() >= services.ReadableCoverage,
+		Levels:               []dto.LevelCoverageResponse{},
+		UnknownWords:         []dto.AnalyzedWordResponse{},
+		UnknownVocabularyIDs: []int{},
+	}
+
+	for level := 5; level >= 0; level-- {
+		count, ok := a.LevelCounts[level]
+		if !ok {
+			continue
+		}
+		coverage := dto.LevelCoverageResponse{Words: count, Percent: percent(count)}
+		if level > 0 {
+			jlptLevel := level
+			coverage.JLPTLevel = &jlptLevel
+		}
+		response.Levels = append(response.Levels, coverage)
+	}
+
+	for _, word := range a.Words {
+		if word.State == services.WordKnown {
+			continue
+		}
+		item := dto.AnalyzedWordResponse{
+			Word:    word.BaseForm,
+			Reading: word.Reading,
+			State:   word.State,
+			Count:   word.Count,
+		}
+		if v := word.Vocabulary; v != nil {
+			item.VocabularyID = &v.ID
+			item.Meaning = &v.Meaning
+			item.JLPTLevel = &v.JLPTLevel
+			response.UnknownVocabularyIDs = append(response.UnknownVocabularyIDs, v.ID)
+		}
+		response.UnknownWords = append(response.UnknownWords, item)
+	}
+
+	return response
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/joaosantos/jlpt5/internal/api/dto"
 	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
 	"github.com/joaosantos/jlpt5/internal/domain/services"
 	"github.com/joaosantos/jlpt5/internal/utils"
 	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
@@ -16,15 +17,17 @@ import (
 
 // VocabularyHandler handles vocabulary endpoints
 type VocabularyHandler struct {
-	vocabService *services.VocabularyService
-	logger       *utils.Logger
+	vocabService    *services.VocabularyService
+	furiganaService *services.FuriganaService
+	logger          *utils.Logger
 }
 
 // NewVocabularyHandler creates a new vocabulary handler
-func NewVocabularyHandler(vocabService *services.VocabularyService, logger *utils.Logger) *VocabularyHandler {
+func NewVocabularyHandler(vocabService *services.VocabularyService, furiganaService *services.FuriganaService, logger *utils.Logger) *VocabularyHandler {
 	return &VocabularyHandler{
-		vocabService: vocabService,
-		logger:       logger,
+		vocabService:    vocabService,
+		furiganaService: furiganaService,
+		logger:          logger,
 	}
 }
 
@@ -38,6 +41,42 @@ func (h *VocabularyHandler) ListVocabulary(w http.ResponseWriter, r *http.Reques
 	// Extract user ID from context (set by auth middleware)
 	userID := getUserIDFromContext(r)
 
+	lq, err := parseListQuery(r, vocabularyListOptions)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	annotator, err := newFuriganaAnnotator(r, h.furiganaService, userID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	items, next, total, err := h.vocabService.GetVocabularyList(r.Context(), userID, lq.vocabularyFilter(), lq.Page, lq.PageSize, lq.WithTotal)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	response := dto.VocabularyListResponse{
+		Items:      toVocabularyResponseList(items),
+		Page:       lq.Page,
+		PageSize:   lq.PageSize,
+		NextCursor: encodeCursor(next),
+	}
+	response.Total, response.TotalPages = lq.totals(total)
+	for i := range response.Items {
+		addVocabularyFurigana(annotator, &response.Items[i])
+	}
+
+	sendSuccess(w, http.StatusOK, response)
+}
+
+// SearchVocabulary searches vocabulary by word, reading, meaning or romaji
+func (h *VocabularyHandler) SearchVocabulary(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
 	// Parse query parameters
 	query := r.URL.Query()
 	page, _ := strconv.Atoi(query.Get("page"))
@@ -57,7 +96,13 @@ func (h *VocabularyHandler) ListVocabulary(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	items, total, err := h.vocabService.GetVocabularyList(r.Context(), userID, jlptLevel, page, pageSize)
+	annotator, err := newFuriganaAnnotator(r, h.furiganaService, userID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	items, total, err := h.vocabService.SearchVocabulary(r.Context(), userID, query.Get("q"), jlptLevel, page, pageSize)
 	if err != nil {
 		sendError(w, err)
 		return
@@ -67,10 +112,13 @@ func (h *VocabularyHandler) ListVocabulary(w http.ResponseWriter, r *http.Reques
 
 	response := dto.VocabularyListResponse{
 		Items:      toVocabularyResponseList(items),
-		Total:      total,
+		Total:      &total,
 		Page:       page,
 		PageSize:   pageSize,
-		TotalPages: totalPages,
+		TotalPages: &totalPages,
+	}
+	for i := range response.Items {
+		addVocabularyFurigana(annotator, &response.Items[i])
 	}
 
 	sendSuccess(w, http.StatusOK, response)
@@ -90,13 +138,60 @@ func (h *VocabularyHandler) GetVocabulary(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	annotator, err := newFuriganaAnnotator(r, h.furiganaService, userID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
 	item, err := h.vocabService.GetVocabularyByID(r.Context(), userID, vocabID)
 	if err != nil {
 		sendError(w, err)
 		return
 	}
 
-	sendSuccess(w, http.StatusOK, toVocabularyResponse(*item))
+	response := toVocabularyResponse(*item)
+	addVocabularyFurigana(annotator, &response)
+
+	sendSuccess(w, http.StatusOK, response)
+}
+
+// ListReviews retrieves the user's review log, newest first
+func (h *VocabularyHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	query := r.URL.Query()
+	pq, err := parsePageQuery(query, repository.ReviewLogSort)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	var vocabularyID *int
+	if idStr := query.Get("vocabulary_id"); idStr != "" {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			sendError(w, pkgErrors.BadRequest("Invalid vocabulary ID"))
+			return
+		}
+		vocabularyID = &id
+	}
+
+	logs, next, total, err := h.vocabService.GetReviewLog(r.Context(), userID, vocabularyID, pq.Cursor, pq.Page, pq.PageSize, pq.WithTotal)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	response := dto.ReviewLogListResponse{
+		Items:      toReviewLogResponseList(logs),
+		Page:       pq.Page,
+		PageSize:   pq.PageSize,
+		NextCursor: encodeCursor(next),
+	}
+	response.Total, response.TotalPages = pq.totals(total)
+
+	sendSuccess(w, http.StatusOK, response)
 }
 
 // GetDueVocabulary retrieves vocabulary items due for review
@@ -113,14 +208,31 @@ func (h *VocabularyHandler) GetDueVocabulary(w http.ResponseWriter, r *http.Requ
 		limit = 20
 	}
 
-	items, err := h.vocabService.GetDueVocabulary(r.Context(), userID, limit)
+	deckID, err := parseOptionalID(r.URL.Query().Get("deck"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid deck"))
+		return
+	}
+
+	annotator, err := newFuriganaAnnotator(r, h.furiganaService, userID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	items, err := h.vocabService.GetDueVocabulary(r.Context(), userID, deckID, limit)
 	if err != nil {
 		sendError(w, err)
 		return
 	}
 
+	responses := toVocabularyResponseList(items)
+	for i := range responses {
+		addVocabularyFurigana(annotator, &responses[i])
+	}
+
 	sendSuccess(w, http.StatusOK, map[string]interface{}{
-		"items": toVocabularyResponseList(items),
+		"items": responses,
 		"count": len(items),
 	})
 }
@@ -145,29 +257,251 @@ func (h *VocabularyHandler) SubmitReview(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	progress, err := h.vocabService.SubmitReview(r.Context(), userID, vocabID, req.IsCorrect)
+	var response dto.ReviewResponse
+	if req.Answer != nil {
+		progress, result, err := h.vocabService.SubmitTypedReview(r.Context(), userID, vocabID, *req.Answer, req.AnswerType)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+
+		if result.Verdict == services.AnswerAlmost {
+			// The schedule is unchanged: the learner gets another try
+			response = dto.ReviewResponse{
+				Success: true,
+				Message: "Almost! Check the details and try again.",
+			}
+		} else {
+			response = toReviewResponse(progress, result.IsCorrect())
+			response.ExpectedAnswer = result.Matched
+		}
+		response.Verdict = string(result.Verdict)
+		response.Hint = result.Hint
+	} else if req.SelfGraded {
+		if req.Quality == nil {
+			sendError(w, pkgErrors.BadRequest("quality is required for a self-graded review"))
+			return
+		}
+
+		progress, err := h.vocabService.SubmitSelfGradedReview(r.Context(), userID, vocabID, *req.Quality)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+
+		response = toReviewResponse(progress, *req.Quality >= int(services.ReviewQualityCorrectHard))
+	} else {
+		sendError(w, pkgErrors.BadRequest("answer is required, unless the review is self_graded"))
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, response)
+}
+
+// SaveNotes sets the user's personal notes on a vocabulary item
+func (h *VocabularyHandler) SaveNotes(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	vocabID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid vocabulary ID"))
+		return
+	}
+
+	var req dto.NotesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	progress, err := h.vocabService.SaveNotes(r.Context(), userID, vocabID, req.Mnemonic, req.PersonalExample, req.ImageURL)
 	if err != nil {
 		sendError(w, err)
 		return
 	}
 
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"success":  true,
+		"progress": toProgressResponse(progress),
+	})
+}
+
+// CreateVocabulary adds a private, user-authored vocabulary entry
+func (h *VocabularyHandler) CreateVocabulary(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	var req dto.CreateVocabularyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	vocab, err := h.vocabService.CreatePrivateVocabulary(r.Context(), userID, &models.Vocabulary{
+		Word:                  req.Word,
+		Reading:               req.Reading,
+		Meaning:               req.Meaning,
+		PartOfSpeech:          req.PartOfSpeech,
+		JLPTLevel:             req.JLPTLevel,
+		ExampleSentence:       req.ExampleSentence,
+		ExampleTranslation:    req.ExampleTranslation,
+		SuggestedForInclusion: req.SuggestForInclusion,
+	})
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusCreated, toVocabularyResponse(models.VocabularyWithProgress{Vocabulary: *vocab}))
+}
+
+// UpdateVocabulary edits one of the user's private vocabulary entries
+func (h *VocabularyHandler) UpdateVocabulary(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	vocabID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid vocabulary ID"))
+		return
+	}
+
+	var req dto.UpdateVocabularyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	vocab, err := h.vocabService.UpdatePrivateVocabulary(r.Context(), userID, &models.Vocabulary{
+		ID:                 vocabID,
+		Meaning:            req.Meaning,
+		PartOfSpeech:       req.PartOfSpeech,
+		JLPTLevel:          req.JLPTLevel,
+		ExampleSentence:    req.ExampleSentence,
+		ExampleTranslation: req.ExampleTranslation,
+	})
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, toVocabularyResponse(models.VocabularyWithProgress{Vocabulary: *vocab}))
+}
+
+// DeleteVocabulary deletes one of the user's private vocabulary entries
+func (h *VocabularyHandler) DeleteVocabulary(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	vocabID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid vocabulary ID"))
+		return
+	}
+
+	if err := h.vocabService.DeletePrivateVocabulary(r.Context(), userID, vocabID); err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Vocabulary deleted",
+	})
+}
+
+// SetSuggestion flags or unflags a private entry for inclusion in the public vocabulary
+func (h *VocabularyHandler) SetSuggestion(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	vocabID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid vocabulary ID"))
+		return
+	}
+
+	var req dto.SuggestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid request body"))
+		return
+	}
+
+	if err := h.vocabService.SetSuggestion(r.Context(), userID, vocabID, req.Suggested); err != nil {
+		sendError(w, err)
+		return
+	}
+
+	sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"success":   true,
+		"suggested": req.Suggested,
+	})
+}
+
+// ListSuggestions retrieves private entries suggested for inclusion (admin)
+func (h *VocabularyHandler) ListSuggestions(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	items, total, err := h.vocabService.GetSuggestions(r.Context(), userID, page, pageSize)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	responses := make([]dto.VocabularyResponse, len(items))
+	for i, item := range items {
+		responses[i] = toVocabularyResponse(models.VocabularyWithProgress{Vocabulary: item})
+		responses[i].OwnerID = item.OwnerID
+	}
+
+	totalPages := (total + pageSize - 1) / pageSize
+	sendSuccess(w, http.StatusOK, dto.VocabularyListResponse{
+		Items:      responses,
+		Total:      &total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: &totalPages,
+	})
+}
+
+// PromoteVocabulary makes a private entry part of the public vocabulary (admin)
+func (h *VocabularyHandler) PromoteVocabulary(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	vocabID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		sendError(w, pkgErrors.BadRequest("Invalid vocabulary ID"))
+		return
+	}
+
+	vocab, err := h.vocabService.PromoteVocabulary(r.Context(), userID, vocabID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	response := toVocabularyResponse(models.VocabularyWithProgress{Vocabulary: *vocab})
+	response.OwnerID = vocab.OwnerID
+	sendSuccess(w, http.StatusOK, response)
+}
+
+// Helper functions
+
+func toReviewResponse(progress *models.UserVocabularyProgress, isCorrect bool) dto.ReviewResponse {
 	message := "Great job! Keep it up!"
-	if !req.IsCorrect {
+	if !isCorrect {
 		message = "Don't worry, you'll get it next time!"
 	}
 
-	response := dto.ReviewResponse{
+	return dto.ReviewResponse{
 		Success:        true,
+		IsCorrect:      isCorrect,
 		Progress:       toProgressResponse(progress),
 		NextReviewDate: progress.NextReviewDate.Format(time.RFC3339),
 		Message:        message,
 	}
-
-	sendSuccess(w, http.StatusOK, response)
 }
 
-// Helper functions
-
 func toVocabularyResponse(item models.VocabularyWithProgress) dto.VocabularyResponse {
 	response := dto.VocabularyResponse{
 		ID:                 item.ID,
@@ -179,6 +513,9 @@ func toVocabularyResponse(item models.VocabularyWithProgress) dto.VocabularyResp
 		ExampleSentence:    item.ExampleSentence,
 		ExampleTranslation: item.ExampleTranslation,
 		AudioURL:           item.AudioURL,
+		Tags:               item.Tags,
+		Visibility:         item.Visibility,
+		Suggested:          item.SuggestedForInclusion,
 	}
 
 	if item.Progress != nil {
@@ -206,6 +543,10 @@ func toProgressResponse(progress *models.UserVocabularyProgress) *dto.ProgressRe
 		CorrectReviews: progress.CorrectReviews,
 		SuccessRate:    successRate,
 		IsDue:          isDue,
+
+		Mnemonic:        progress.Mnemonic,
+		PersonalExample: progress.PersonalExample,
+		ImageURL:        progress.ImageURL,
 	}
 
 	if progress.LastReviewedAt != nil {
@@ -224,6 +565,25 @@ func toVocabularyResponseList(items []models.VocabularyWithProgress) []dto.Vocab
 	return responses
 }
 
+func toReviewLogResponseList(logs []models.VocabularyReviewLog) []dto.ReviewLogResponse {
+	responses := make([]dto.ReviewLogResponse, len(logs))
+	for i, l := range logs {
+		responses[i] = dto.ReviewLogResponse{
+			ID:               l.ID,
+			VocabularyID:     l.VocabularyID,
+			Quality:          l.Quality,
+			IsCorrect:        l.IsCorrect,
+			SelfGraded:       l.SelfGraded,
+			NearMiss:         l.NearMiss,
+			PreviousInterval: l.PreviousInterval,
+			Interval:         l.Interval,
+			EaseFactor:       l.EaseFactor,
+			ReviewedAt:       l.ReviewedAt.Format(time.RFC3339),
+		}
+	}
+	return responses
+}
+
 func getUserIDFromContext(r *http.Request) int {
 	// This will be set by auth middleware
 	// For now, return a default user ID (we'll implement auth middleware later)
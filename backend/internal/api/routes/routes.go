@@ -18,6 +18,13 @@ type Router struct {
 	grammarHandler  *handlers.GrammarHandler
 	quizHandler     *handlers.QuizHandler
 	progressHandler *handlers.ProgressHandler
+	toolsHandler    *handlers.ToolsHandler
+	deckHandler     *handlers.DeckHandler
+	tagHandler      *handlers.TagHandler
+	importHandler   *handlers.ImportHandler
+	exportHandler   *handlers.ExportHandler
+	exampleHandler  *handlers.ExampleHandler
+	drillHandler    *handlers.DrillHandler
 }
 
 // NewRouter creates a new router with dependencies
@@ -29,6 +36,13 @@ func NewRouter(
 	grammarHandler *handlers.GrammarHandler,
 	quizHandler *handlers.QuizHandler,
 	progressHandler *handlers.ProgressHandler,
+	toolsHandler *handlers.ToolsHandler,
+	deckHandler *handlers.DeckHandler,
+	tagHandler *handlers.TagHandler,
+	importHandler *handlers.ImportHandler,
+	exportHandler *handlers.ExportHandler,
+	exampleHandler *handlers.ExampleHandler,
+	drillHandler *handlers.DrillHandler,
 ) *Router {
 	return &Router{
 		db:              db,
@@ -38,6 +52,13 @@ func NewRouter(
 		grammarHandler:  grammarHandler,
 		quizHandler:     quizHandler,
 		progressHandler: progressHandler,
+		toolsHandler:    toolsHandler,
+		deckHandler:     deckHandler,
+		tagHandler:      tagHandler,
+		importHandler:   importHandler,
+		exportHandler:   exportHandler,
+		exampleHandler:  exampleHandler,
+		drillHandler:    drillHandler,
 	}
 }
 
@@ -56,9 +77,17 @@ func (r *Router) SetupRoutes() http.Handler {
 
 	// Vocabulary routes
 	mux.HandleFunc("GET /api/v1/vocabulary", r.vocabHandler.ListVocabulary)
+	mux.HandleFunc("POST /api/v1/vocabulary", r.vocabHandler.CreateVocabulary)
 	mux.HandleFunc("GET /api/v1/vocabulary/due", r.vocabHandler.GetDueVocabulary)
+	mux.HandleFunc("GET /api/v1/vocabulary/search", r.vocabHandler.SearchVocabulary)
+	mux.HandleFunc("GET /api/v1/vocabulary/reviews", r.vocabHandler.ListReviews)
 	mux.HandleFunc("/api/v1/vocabulary/", r.vocabHandler.GetVocabulary) // Handles GET /api/v1/vocabulary/{id}
 	mux.HandleFunc("POST /api/v1/vocabulary/", r.vocabHandler.SubmitReview) // Handles POST /api/v1/vocabulary/{id}/review
+	mux.HandleFunc("PUT /api/v1/vocabulary/{id}", r.vocabHandler.UpdateVocabulary)
+	mux.HandleFunc("DELETE /api/v1/vocabulary/{id}", r.vocabHandler.DeleteVocabulary)
+	mux.HandleFunc("PUT /api/v1/vocabulary/{id}/suggestion", r.vocabHandler.SetSuggestion)
+	mux.HandleFunc("PUT /api/v1/vocabulary/{id}/notes", r.vocabHandler.SaveNotes)
+	mux.HandleFunc("GET /api/v1/vocabulary/{id}/examples", r.exampleHandler.ListExamples)
 
 	// Grammar routes
 	mux.HandleFunc("GET /api/v1/grammar", r.grammarHandler.ListGrammar)
@@ -68,14 +97,61 @@ func (r *Router) SetupRoutes() http.Handler {
 	// Quiz routes
 	mux.HandleFunc("GET /api/v1/quizzes", r.quizHandler.ListQuizzes)
 	mux.HandleFunc("GET /api/v1/quizzes/history", r.quizHandler.GetQuizHistory)
+	mux.HandleFunc("POST /api/v1/quizzes/generate", r.quizHandler.GenerateQuiz)
 	mux.HandleFunc("GET /api/v1/quizzes/{id}", r.quizHandler.GetQuiz)
 	mux.HandleFunc("POST /api/v1/quizzes/{id}/start", r.quizHandler.StartQuiz)
 	mux.HandleFunc("GET /api/v1/quizzes/sessions/{id}", r.quizHandler.GetQuizResult)
+	mux.HandleFunc("GET /api/v1/quizzes/sessions/{id}/resume", r.quizHandler.ResumeQuiz)
+	mux.HandleFunc("PUT /api/v1/quizzes/sessions/{id}/answers/{questionId}", r.quizHandler.SaveQuizAnswer)
 	mux.HandleFunc("POST /api/v1/quizzes/sessions/{id}/submit", r.quizHandler.SubmitQuiz)
 
+	// Deck routes
+	mux.HandleFunc("GET /api/v1/decks", r.deckHandler.ListDecks)
+	mux.HandleFunc("POST /api/v1/decks", r.deckHandler.CreateDeck)
+	mux.HandleFunc("GET /api/v1/decks/{id}", r.deckHandler.GetDeck)
+	mux.HandleFunc("PUT /api/v1/decks/{id}", r.deckHandler.UpdateDeck)
+	mux.HandleFunc("DELETE /api/v1/decks/{id}", r.deckHandler.DeleteDeck)
+	mux.HandleFunc("POST /api/v1/decks/{id}/items", r.deckHandler.AddItems)
+	mux.HandleFunc("DELETE /api/v1/decks/{id}/items/{vocabularyId}", r.deckHandler.RemoveItem)
+	mux.HandleFunc("GET /api/v1/decks/{id}/due", r.deckHandler.GetDueItems)
+	mux.HandleFunc("GET /api/v1/decks/{id}/stats", r.deckHandler.GetStats)
+
+	// Tag routes
+	mux.HandleFunc("GET /api/v1/tags", r.tagHandler.ListTags)
+	mux.HandleFunc("POST /api/v1/tags", r.tagHandler.CreateTag)
+	mux.HandleFunc("DELETE /api/v1/tags/{id}", r.tagHandler.DeleteTag)
+	mux.HandleFunc("POST /api/v1/tags/{id}/items", r.tagHandler.TagItems)
+	mux.HandleFunc("DELETE /api/v1/tags/{id}/items/{vocabularyId}", r.tagHandler.UntagItem)
+
+	// Import routes
+	mux.HandleFunc("GET /api/v1/imports", r.importHandler.ListImports)
+	mux.HandleFunc("GET /api/v1/imports/{id}", r.importHandler.GetImport)
+	mux.HandleFunc("POST /api/v1/imports/anki", r.importHandler.ImportAnki)
+	mux.HandleFunc("POST /api/v1/imports/csv", r.importHandler.ImportCSV)
+
+	// Export routes
+	mux.HandleFunc("GET /api/v1/export/anki", r.exportHandler.ExportAnki)
+	mux.HandleFunc("GET /api/v1/export/vocabulary", r.exportHandler.ExportVocabularyCSV)
+	mux.HandleFunc("GET /api/v1/export/progress", r.exportHandler.ExportProgressCSV)
+
+	// Conjugation drill routes
+	mux.HandleFunc("GET /api/v1/drills/conjugation", r.drillHandler.GetConjugationDrills)
+	mux.HandleFunc("POST /api/v1/drills/conjugation/answer", r.drillHandler.SubmitConjugationAnswer)
+	mux.HandleFunc("GET /api/v1/drills/numbers", r.drillHandler.GetNumberDrills)
+
 	// Progress routes
 	mux.HandleFunc("GET /api/v1/progress/stats", r.progressHandler.GetStats)
 
+	// Admin routes
+	mux.HandleFunc("GET /api/v1/admin/vocabulary/suggestions", r.vocabHandler.ListSuggestions)
+	mux.HandleFunc("POST /api/v1/admin/vocabulary/{id}/promote", r.vocabHandler.PromoteVocabulary)
+	mux.HandleFunc("POST /api/v1/admin/quizzes/questions/validate", r.quizHandler.ValidateQuestion)
+
+	// Language tool routes
+	mux.HandleFunc("GET /api/v1/tools/convert", r.toolsHandler.Convert)
+	mux.HandleFunc("POST /api/v1/tools/tokenize", r.toolsHandler.Tokenize)
+	mux.HandleFunc("POST /api/v1/tools/analyze", r.toolsHandler.Analyze)
+
 	r.logger.Info("Routes registered successfully")
 
 	// Apply middleware
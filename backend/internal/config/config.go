@@ -13,6 +13,7 @@ type Config struct {
 	Database DatabaseConfig
 	JWT      JWTConfig
 	Log      LogConfig
+	Quiz     QuizConfig
 }
 
 // ServerConfig holds server-specific configuration
@@ -40,6 +41,13 @@ type JWTConfig struct {
 	RefreshExpirationDays int
 }
 
+// QuizConfig holds quiz time limit configuration
+type QuizConfig struct {
+	GracePeriod   time.Duration // Time allowed past the deadline, for network latency
+	SweepInterval time.Duration // How often expired sessions are closed
+	Retention     time.Duration // How long generated quizzes are kept
+}
+
 // LogConfig holds logging configuration
 type LogConfig struct {
 	Level string
@@ -70,6 +78,11 @@ func Load() (*Config, error) {
 		Log: LogConfig{
 			Level: getEnv("LOG_LEVEL", "info"),
 		},
+		Quiz: QuizConfig{
+			GracePeriod:   getDurationEnv("QUIZ_GRACE_PERIOD", 30*time.Second),
+			SweepInterval: getDurationEnv("QUIZ_SWEEP_INTERVAL", time.Minute),
+			Retention:     getDurationEnv("QUIZ_GENERATED_RETENTION", 30*24*time.Hour),
+		},
 	}
 
 	// Validate required configuration
@@ -94,6 +107,15 @@ func (c *Config) Validate() error {
 	if c.JWT.Secret == "" {
 		return fmt.Errorf("JWT secret is required")
 	}
+	if c.Quiz.SweepInterval <= 0 {
+		return fmt.Errorf("quiz sweep interval must be positive")
+	}
+	if c.Quiz.GracePeriod < 0 {
+		return fmt.Errorf("quiz grace period must not be negative")
+	}
+	if c.Quiz.Retention <= 0 {
+		return fmt.Errorf("generated quiz retention must be positive")
+	}
 
 	return nil
 }
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// ConjugationProgress is a user's SM-2 schedule for producing one form of a
+// verb or adjective, e.g. the polite past negative of 食べる
+type ConjugationProgress struct {
+	ID             int        `json:"id"`
+	UserID         int        `json:"user_id"`
+	VocabularyID   int        `json:"vocabulary_id"`
+	Form           string     `json:"form"`
+	EaseFactor     float64    `json:"ease_factor"`
+	Interval       int        `json:"interval"`
+	Repetitions    int        `json:"repetitions"`
+	NextReviewDate time.Time  `json:"next_review_date"`
+	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
+	TotalReviews   int        `json:"total_reviews"`
+	CorrectReviews int        `json:"correct_reviews"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ConjugationDrill asks for one form of a word. Progress is nil for a form
+// the user has not drilled yet.
+type ConjugationDrill struct {
+	Vocabulary Vocabulary           `json:"vocabulary"`
+	Form       string               `json:"form"`
+	Progress   *ConjugationProgress `json:"progress,omitempty"`
+}
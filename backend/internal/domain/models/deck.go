@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Deck represents a user-built collection of vocabulary items
+type Deck struct {
+	ID          int       `json:"id"`
+	UserID      int       `json:"user_id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	ItemCount   int       `json:"item_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DeckStats summarizes a user's progress on the items of a deck
+type DeckStats struct {
+	DeckID         int `json:"deck_id"`
+	TotalItems     int `json:"total_items"`
+	NewItems       int `json:"new_items"`
+	LearningItems  int `json:"learning_items"`
+	DueItems       int `json:"due_items"`
+	MasteredItems  int `json:"mastered_items"`
+	TotalReviews   int `json:"total_reviews"`
+	CorrectReviews int `json:"correct_reviews"`
+}
+
+// Tag represents a user-defined label for vocabulary items
+type Tag struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Name      string    `json:"name"`
+	ItemCount int       `json:"item_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
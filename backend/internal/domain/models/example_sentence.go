@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ExampleSentence is a Japanese sentence with its English translation,
+// imported from the Tatoeba project
+type ExampleSentence struct {
+	ID               int       `json:"id"`
+	TatoebaID        int       `json:"tatoeba_id"`
+	Japanese         string    `json:"japanese"`
+	English          string    `json:"english"`
+	EnglishTatoebaID *int      `json:"english_tatoeba_id,omitempty"`
+	CharCount        int       `json:"char_count"`
+	N5Coverage       float64   `json:"n5_coverage"` // Share of the sentence an N5 learner can read, 0-1
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
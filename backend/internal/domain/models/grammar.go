@@ -11,6 +11,7 @@ type GrammarLesson struct {
 	UsageNotes   *string   `json:"usage_notes,omitempty"`
 	JLPTLevel    int       `json:"jlpt_level"`
 	LessonOrder  *int      `json:"lesson_order,omitempty"`
+	Tags         []string  `json:"tags"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
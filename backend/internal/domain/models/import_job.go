@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Import job statuses
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// Import job sources
+const (
+	ImportSourceAnki = "anki"
+	ImportSourceCSV  = "csv"
+)
+
+// ImportJob tracks a vocabulary import from an external source
+type ImportJob struct {
+	ID               int        `json:"id"`
+	UserID           int        `json:"user_id"`
+	Source           string     `json:"source"`
+	FileName         string     `json:"file_name"`
+	Status           string     `json:"status"`
+	TotalItems       int        `json:"total_items"`
+	CreatedItems     int        `json:"created_items"`     // New private vocabulary entries
+	MatchedItems     int        `json:"matched_items"`     // Items that already existed and were reused
+	UpdatedItems     int        `json:"updated_items"`     // The user's own private items that were updated
+	SkippedItems     int        `json:"skipped_items"`     // Items that could not be mapped
+	FailedItems      int        `json:"failed_items"`      // Items that could not be saved
+	ProgressImported int        `json:"progress_imported"` // Items whose scheduling was carried over
+	ReviewsImported  int        `json:"reviews_imported"`
+	Warnings         []string   `json:"warnings"` // Per-item problems, capped at MaxImportWarnings
+	Error            *string    `json:"error,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+}
+
+// MaxImportWarnings caps the warnings kept on an import job
+const MaxImportWarnings = 100
+
+// Warn records a per-item problem, dropping it once the cap is reached
+func (j *ImportJob) Warn(warning string) {
+	if len(j.Warnings) < MaxImportWarnings {
+		j.Warnings = append(j.Warnings, warning)
+	}
+}
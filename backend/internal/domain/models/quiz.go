@@ -19,10 +19,35 @@ type Quiz struct {
 	JLPTLevel       int       `json:"jlpt_level"`
 	TimeLimitMinutes *int     `json:"time_limit_minutes,omitempty"`
 	PassingScore    int       `json:"passing_score"`
+	OwnerID         *int      `json:"owner_id,omitempty"` // User a generated quiz was made for, nil for authored quizzes
+	ShuffleQuestions bool     `json:"shuffle_questions"`  // Each session shows the questions in its own order
+	ShuffleOptions  bool      `json:"shuffle_options"`    // Each session shows the options in its own order
+	WrongAnswerPenalty float64 `json:"wrong_answer_penalty"` // Share of the points of a question lost for a wrong answer
+	PartialCredit   float64   `json:"partial_credit"`     // Share of the points of a question earned by a near-miss typed answer
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// IsShuffled reports whether sessions of the quiz show questions or options in their own order
+func (q *Quiz) IsShuffled() bool {
+	return q.ShuffleQuestions || q.ShuffleOptions
+}
+
+// IsVisibleTo reports whether a user may see the quiz
+func (q *Quiz) IsVisibleTo(userID int) bool {
+	return q.OwnerID == nil || *q.OwnerID == userID
+}
+
+// QuizSection represents a section of a quiz, weighing its questions in the
+// percentage of a session
+type QuizSection struct {
+	ID           int     `json:"id"`
+	QuizID       int     `json:"quiz_id"`
+	Name         string  `json:"name"`
+	Weight       float64 `json:"weight"`
+	SectionOrder *int    `json:"section_order,omitempty"`
+}
+
 // QuizQuestion represents a question in a quiz
 type QuizQuestion struct {
 	ID           int          `json:"id"`
@@ -37,6 +62,7 @@ type QuizQuestion struct {
 	Explanation  *string      `json:"explanation,omitempty"`
 	Points       int          `json:"points"`
 	QuestionOrder *int        `json:"question_order,omitempty"`
+	SectionID    *int         `json:"section_id,omitempty"`
 	CreatedAt    time.Time    `json:"created_at"`
 }
 
@@ -47,11 +73,26 @@ type QuizSession struct {
 	QuizID          int        `json:"quiz_id"`
 	StartedAt       time.Time  `json:"started_at"`
 	CompletedAt     *time.Time `json:"completed_at,omitempty"`
-	Score           *int       `json:"score,omitempty"`
+	Score           *float64   `json:"score,omitempty"`
 	TotalPoints     *int       `json:"total_points,omitempty"`
 	Percentage      *float64   `json:"percentage,omitempty"`
 	Passed          *bool      `json:"passed,omitempty"`
 	TimeSpentSeconds *int      `json:"time_spent_seconds,omitempty"`
+	ShuffleSeed     *int64     `json:"-"` // Seed of the question and option order, nil when not shuffled
+	Deadline        *time.Time `json:"deadline,omitempty"` // End of the time limit, nil when the quiz is not timed
+	SubmitKey       *string    `json:"-"` // Idempotency key of the submit that completed the session
+}
+
+// IsExpired reports whether the time limit of the session, extended by a
+// grace period, is over at now
+func (s *QuizSession) IsExpired(now time.Time, grace time.Duration) bool {
+	return s.Deadline != nil && now.After(s.Deadline.Add(grace))
+}
+
+// WasSubmittedWith reports whether the session was completed by a submit
+// carrying the idempotency key
+func (s *QuizSession) WasSubmittedWith(key string) bool {
+	return key != "" && s.SubmitKey != nil && *s.SubmitKey == key
 }
 
 // QuizAnswer represents a user's answer to a quiz question
@@ -61,9 +102,16 @@ type QuizAnswer struct {
 	QuizQuestionID int       `json:"quiz_question_id"`
 	UserAnswer     *string   `json:"user_answer,omitempty"`
 	IsCorrect      *bool     `json:"is_correct,omitempty"`
+	PointsAwarded  *float64  `json:"points_awarded,omitempty"` // Set when the session is completed
 	AnsweredAt     time.Time `json:"answered_at"`
 }
 
+// IsAnswered reports whether the question was answered, as opposed to
+// recorded as unanswered when the session was completed
+func (a *QuizAnswer) IsAnswered() bool {
+	return a.UserAnswer != nil
+}
+
 // QuizWithQuestions combines a quiz with its questions
 type QuizWithQuestions struct {
 	Quiz
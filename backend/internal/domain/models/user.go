@@ -12,6 +12,7 @@ type User struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 	LastLoginAt  *time.Time `json:"last_login_at,omitempty"`
 	IsActive     bool      `json:"is_active"`
+	IsAdmin      bool      `json:"is_admin"`
 }
 
 // UserStatistics represents overall user statistics
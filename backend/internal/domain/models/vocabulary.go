@@ -4,17 +4,34 @@ import "time"
 
 // Vocabulary represents a vocabulary item
 type Vocabulary struct {
-	ID                 int       `json:"id"`
-	Word               string    `json:"word"`
-	Reading            string    `json:"reading"`
-	Meaning            string    `json:"meaning"`
-	PartOfSpeech       *string   `json:"part_of_speech,omitempty"`
-	JLPTLevel          int       `json:"jlpt_level"`
-	ExampleSentence    *string   `json:"example_sentence,omitempty"`
-	ExampleTranslation *string   `json:"example_translation,omitempty"`
-	AudioURL           *string   `json:"audio_url,omitempty"`
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
+	ID                    int       `json:"id"`
+	Word                  string    `json:"word"`
+	Reading               string    `json:"reading"`
+	Meaning               string    `json:"meaning"`
+	PartOfSpeech          *string   `json:"part_of_speech,omitempty"`
+	JLPTLevel             int       `json:"jlpt_level"`
+	ExampleSentence       *string   `json:"example_sentence,omitempty"`
+	ExampleTranslation    *string   `json:"example_translation,omitempty"`
+	AudioURL              *string   `json:"audio_url,omitempty"`
+	Tags                  []string  `json:"tags"`
+	OwnerID               *int      `json:"owner_id,omitempty"` // Author of a user-authored entry, nil for curated content
+	Visibility            string    `json:"visibility"`         // VisibilityPublic or VisibilityPrivate
+	SuggestedForInclusion bool      `json:"suggested_for_inclusion"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// Vocabulary visibility values
+const (
+	// VisibilityPublic - curated or promoted content, visible to every user
+	VisibilityPublic = "public"
+	// VisibilityPrivate - a user-authored entry, visible only to its owner
+	VisibilityPrivate = "private"
+)
+
+// IsVisibleTo reports whether a user may see the vocabulary item
+func (v *Vocabulary) IsVisibleTo(userID int) bool {
+	return v.Visibility != VisibilityPrivate || (v.OwnerID != nil && *v.OwnerID == userID)
 }
 
 // UserVocabularyProgress represents a user's progress with a vocabulary item
@@ -30,6 +47,12 @@ type UserVocabularyProgress struct {
 	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
 	TotalReviews   int       `json:"total_reviews"`
 	CorrectReviews int       `json:"correct_reviews"`
+
+	// Personal notes on the item
+	Mnemonic        *string `json:"mnemonic,omitempty"`
+	PersonalExample *string `json:"personal_example,omitempty"`
+	ImageURL        *string `json:"image_url,omitempty"`
+
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 }
@@ -39,3 +62,18 @@ type VocabularyWithProgress struct {
 	Vocabulary
 	Progress *UserVocabularyProgress `json:"progress,omitempty"`
 }
+
+// VocabularyReviewLog records a single review of a vocabulary item
+type VocabularyReviewLog struct {
+	ID               int       `json:"id"`
+	UserID           int       `json:"user_id"`
+	VocabularyID     int       `json:"vocabulary_id"`
+	Quality          int       `json:"quality"`           // SM-2 review quality (0-5)
+	IsCorrect        bool      `json:"is_correct"`
+	SelfGraded       bool      `json:"self_graded"`       // Graded by the learner rather than checked by the server
+	NearMiss         bool      `json:"near_miss"`         // A near-miss typed answer, which leaves the schedule unchanged
+	PreviousInterval int       `json:"previous_interval"` // Days until review before this one
+	Interval         int       `json:"interval"`          // Days until review after this one
+	EaseFactor       float64   `json:"ease_factor"`
+	ReviewedAt       time.Time `json:"reviewed_at"`
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+)
+
+// ConjugationRepository defines the interface for conjugation drill data access
+type ConjugationRepository interface {
+	// GetDueDrills retrieves the user's drills that are due, oldest first,
+	// optionally only for the given forms
+	GetDueDrills(ctx context.Context, userID int, forms []string, limit int) ([]models.ConjugationDrill, error)
+
+	// GetLearnedVocabulary retrieves the vocabulary visible to the user that
+	// the user has reviewed and whose part of speech is one of classes, most
+	// recently reviewed first
+	GetLearnedVocabulary(ctx context.Context, userID int, classes []string, limit int) ([]models.Vocabulary, error)
+
+	// GetProgressForVocabulary retrieves the user's drill progress on every
+	// form of the given vocabulary items
+	GetProgressForVocabulary(ctx context.Context, userID int, vocabularyIDs []int) ([]models.ConjugationProgress, error)
+
+	// GetProgress retrieves the user's progress on one form of a word
+	GetProgress(ctx context.Context, userID, vocabularyID int, form string) (*models.ConjugationProgress, error)
+
+	// SaveProgress creates or updates the user's progress on one form of a word
+	SaveProgress(ctx context.Context, progress *models.ConjugationProgress) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+)
+
+// DeckRepository defines the interface for deck data access
+type DeckRepository interface {
+	// CreateDeck creates a new deck
+	CreateDeck(ctx context.Context, deck *models.Deck) error
+
+	// GetDeckByID retrieves a deck by ID with its item count
+	GetDeckByID(ctx context.Context, deckID int) (*models.Deck, error)
+
+	// GetUserDecks retrieves all decks of a user with their item counts
+	GetUserDecks(ctx context.Context, userID int) ([]models.Deck, error)
+
+	// UpdateDeck updates a deck's name and description
+	UpdateDeck(ctx context.Context, deck *models.Deck) error
+
+	// DeleteDeck deletes a deck and its items
+	DeleteDeck(ctx context.Context, deckID int) error
+
+	// AddItems adds vocabulary items visible to the deck's owner to a deck,
+	// skipping items already in it, and returns the number of items added
+	AddItems(ctx context.Context, deckID int, vocabularyIDs []int) (int, error)
+
+	// RemoveItem removes a vocabulary item from a deck
+	RemoveItem(ctx context.Context, deckID, vocabularyID int) error
+
+	// GetDeckStats summarizes a user's progress on the items of a deck
+	GetDeckStats(ctx context.Context, userID, deckID int) (*models.DeckStats, error)
+}
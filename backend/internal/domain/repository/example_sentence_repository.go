@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+)
+
+// ExampleSentenceRepository defines the interface for example sentence data access
+type ExampleSentenceRepository interface {
+	// SaveSentence creates or updates a sentence by Tatoeba ID and replaces the
+	// vocabulary items it is indexed under
+	SaveSentence(ctx context.Context, sentence *models.ExampleSentence, vocabularyIDs []int) error
+
+	// GetSentences retrieves up to limit sentences with an ID above afterID, in ID order
+	GetSentences(ctx context.Context, afterID, limit int) ([]models.ExampleSentence, error)
+
+	// GetForVocabulary retrieves the sentences containing a vocabulary item,
+	// those with the highest N5 coverage and then the shortest first
+	GetForVocabulary(ctx context.Context, vocabularyID, limit, offset int) ([]models.ExampleSentence, error)
+
+	// CountForVocabulary returns the number of sentences containing a vocabulary item
+	CountForVocabulary(ctx context.Context, vocabularyID int) (int, error)
+}
@@ -0,0 +1,66 @@
+package repository
+
+// Learning states used to filter lists by the user's progress
+const (
+	// LearningStateNew - the user has not started studying the item
+	LearningStateNew = "new"
+	// LearningStateLearning - studied but neither due nor mastered (grammar: started, not completed)
+	LearningStateLearning = "learning"
+	// LearningStateDue - the item is due for review
+	LearningStateDue = "due"
+	// LearningStateMastered - reviewed at an interval of MasteredIntervalDays or more (grammar: completed)
+	LearningStateMastered = "mastered"
+)
+
+// MasteredIntervalDays is the review interval from which a vocabulary item counts as mastered
+const MasteredIntervalDays = 21
+
+// Sort fields accepted by list queries. Repositories map them to SQL through a
+// whitelist, so unknown fields never reach the query text.
+const (
+	SortByID          = "id"
+	SortByWord        = "word"
+	SortByReading     = "reading"
+	SortByNextReview  = "next_review"
+	SortBySuccessRate = "success_rate"
+	SortByTitle       = "title"
+	SortByLessonOrder = "lesson_order"
+	SortByStartedAt   = "started_at"
+	SortByReviewedAt  = "reviewed_at"
+)
+
+// SortOrder describes how a list is sorted
+type SortOrder struct {
+	Field      string
+	Descending bool
+}
+
+// Sort orders of lists that do not accept a sort parameter
+var (
+	// QuizHistorySort lists quiz sessions newest first
+	QuizHistorySort = SortOrder{Field: SortByStartedAt, Descending: true}
+	// ReviewLogSort lists reviews newest first
+	ReviewLogSort = SortOrder{Field: SortByReviewedAt, Descending: true}
+)
+
+// VocabularyFilter describes the filters and sort order of a vocabulary list
+type VocabularyFilter struct {
+	JLPTLevel     *int
+	PartOfSpeech  *string
+	LearningState *string
+	Tags          []string // Items must carry every tag, built-in or user-defined
+	HasAudio      *bool
+	DeckID        *int    // Only items of this deck, which must belong to the user
+	Visibility    *string // Only public items, or only the user's private items
+	Sort          SortOrder
+	After         *Cursor // Continue after this cursor instead of using the offset
+}
+
+// GrammarFilter describes the filters and sort order of a grammar lesson list
+type GrammarFilter struct {
+	JLPTLevel     *int
+	LearningState *string
+	Tags          []string // Lessons must carry every tag
+	Sort          SortOrder
+	After         *Cursor // Continue after this cursor instead of using the offset
+}
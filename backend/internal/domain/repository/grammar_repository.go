@@ -14,6 +14,9 @@ type GrammarRepository interface {
 	// GetLessonByID retrieves a grammar lesson by ID with examples
 	GetLessonByID(ctx context.Context, lessonID int) (*models.GrammarLessonWithExamples, error)
 
+	// GetLessonsWithExamples retrieves every lesson of a JLPT level with its examples
+	GetLessonsWithExamples(ctx context.Context, jlptLevel int) ([]models.GrammarLessonWithExamples, error)
+
 	// GetUserProgress retrieves user's progress for a lesson
 	GetUserProgress(ctx context.Context, userID, lessonID int) (*models.UserGrammarProgress, error)
 
@@ -23,8 +26,12 @@ type GrammarRepository interface {
 	// UpdateUserProgress updates user's progress for a lesson
 	UpdateUserProgress(ctx context.Context, progress *models.UserGrammarProgress) error
 
-	// GetUserLessonsList retrieves lessons with user progress
-	GetUserLessonsList(ctx context.Context, userID int, jlptLevel *int, limit, offset int) ([]models.GrammarLessonWithExamples, error)
+	// GetUserLessonsList retrieves filtered and sorted lessons with user progress,
+	// and the cursor of the next page (nil on the last page)
+	GetUserLessonsList(ctx context.Context, userID int, filter GrammarFilter, limit, offset int) ([]models.GrammarLessonWithExamples, *Cursor, error)
+
+	// CountUserLessonsList returns the number of lessons matching a list filter
+	CountUserLessonsList(ctx context.Context, userID int, filter GrammarFilter) (int, error)
 
 	// CountLessons returns total lesson count
 	CountLessons(ctx context.Context, jlptLevel *int) (int, error)
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+)
+
+// ImportJobRepository defines the interface for import job data access
+type ImportJobRepository interface {
+	// CreateJob creates a new import job
+	CreateJob(ctx context.Context, job *models.ImportJob) error
+
+	// GetJobByID retrieves an import job by ID
+	GetJobByID(ctx context.Context, jobID int) (*models.ImportJob, error)
+
+	// GetUserJobs retrieves a user's most recent import jobs, newest first
+	GetUserJobs(ctx context.Context, userID, limit int) ([]models.ImportJob, error)
+
+	// UpdateJob saves the status, counters and warnings of an import job
+	UpdateJob(ctx context.Context, job *models.ImportJob) error
+}
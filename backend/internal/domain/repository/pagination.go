@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks a position in a keyset-paginated list: the sort key and ID of the
+// last row of a page. Clients receive it as an opaque string.
+type Cursor struct {
+	Sort string  `json:"s"`           // Sort the cursor was issued for, see SortOrder.String
+	Key  *string `json:"k,omitempty"` // Sort key of the last row, nil when it is NULL
+	ID   int     `json:"i"`           // ID of the last row
+}
+
+// Encode returns the opaque string form of the cursor
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// String returns the sort field, prefixed with "-" when descending
+func (s SortOrder) String() string {
+	if s.Descending {
+		return "-" + s.Field
+	}
+	return s.Field
+}
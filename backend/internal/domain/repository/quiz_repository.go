@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/joaosantos/jlpt5/internal/domain/models"
 )
@@ -17,6 +18,12 @@ type QuizRepository interface {
 	// GetQuizQuestions retrieves all questions for a quiz
 	GetQuizQuestions(ctx context.Context, quizID int) ([]models.QuizQuestion, error)
 
+	// GetQuizSections retrieves the sections of a quiz, in order
+	GetQuizSections(ctx context.Context, quizID int) ([]models.QuizSection, error)
+
+	// CreateQuiz creates a quiz with its questions
+	CreateQuiz(ctx context.Context, quiz *models.Quiz, questions []models.QuizQuestion) error
+
 	// CreateQuizSession creates a new quiz session
 	CreateQuizSession(ctx context.Context, session *models.QuizSession) error
 
@@ -26,11 +33,31 @@ type QuizRepository interface {
 	// GetQuizSession retrieves a quiz session by ID
 	GetQuizSession(ctx context.Context, sessionID int) (*models.QuizSession, error)
 
-	// GetUserQuizSessions retrieves all quiz sessions for a user
-	GetUserQuizSessions(ctx context.Context, userID int, limit, offset int) ([]models.QuizSession, error)
+	// WithLockedQuizSession runs fn in a transaction holding a lock on a
+	// session, passing it a repository working within the transaction and the
+	// session as locked. The transaction is rolled back when fn fails.
+	WithLockedQuizSession(ctx context.Context, sessionID int, fn func(repo QuizRepository, session *models.QuizSession) error) error
+
+	// GetExpiredQuizSessions retrieves up to limit sessions still in progress
+	// whose deadline is before the given time, earliest deadline first
+	GetExpiredQuizSessions(ctx context.Context, before time.Time, limit int) ([]models.QuizSession, error)
+
+	// DeleteStaleGeneratedQuizzes deletes up to limit generated quizzes created
+	// before the given time that have no session in progress, with their
+	// completed sessions, and returns how many were deleted
+	DeleteStaleGeneratedQuizzes(ctx context.Context, before time.Time, limit int) (int, error)
+
+	// GetUserQuizSessions retrieves a user's quiz sessions, newest first, and the
+	// cursor of the next page (nil on the last page)
+	GetUserQuizSessions(ctx context.Context, userID int, after *Cursor, limit, offset int) ([]models.QuizSession, *Cursor, error)
+
+	// CountUserQuizSessions returns the number of quiz sessions of a user
+	CountUserQuizSessions(ctx context.Context, userID int) (int, error)
 
-	// CreateQuizAnswer creates a quiz answer
-	CreateQuizAnswer(ctx context.Context, answer *models.QuizAnswer) error
+	// SaveQuizAnswer creates the answer to a question of a session, or
+	// replaces the one saved before. Unanswered questions are saved with a
+	// nil answer.
+	SaveQuizAnswer(ctx context.Context, answer *models.QuizAnswer) error
 
 	// GetSessionAnswers retrieves all answers for a session
 	GetSessionAnswers(ctx context.Context, sessionID int) ([]models.QuizAnswer, error)
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+)
+
+// TagRepository defines the interface for user tag data access
+type TagRepository interface {
+	// CreateTag creates a new tag
+	CreateTag(ctx context.Context, tag *models.Tag) error
+
+	// GetTagByID retrieves a tag by ID with its item count
+	GetTagByID(ctx context.Context, tagID int) (*models.Tag, error)
+
+	// GetUserTags retrieves all tags of a user with their item counts
+	GetUserTags(ctx context.Context, userID int) ([]models.Tag, error)
+
+	// DeleteTag deletes a tag and removes it from all items
+	DeleteTag(ctx context.Context, tagID int) error
+
+	// TagItems adds a tag to vocabulary items visible to the tag's owner,
+	// skipping items already tagged, and returns the number of items tagged
+	TagItems(ctx context.Context, tagID int, vocabularyIDs []int) (int, error)
+
+	// UntagItem removes a tag from a vocabulary item
+	UntagItem(ctx context.Context, tagID, vocabularyID int) error
+}
@@ -6,29 +6,130 @@ import (
 	"github.com/joaosantos/jlpt5/internal/domain/models"
 )
 
+// MaxSearchTerms is the largest number of Terms of a VocabularySearch
+const MaxSearchTerms = 4
+
+// VocabularySearch describes a vocabulary search query
+type VocabularySearch struct {
+	// Text is the raw query, matched against English tokens in the meaning
+	Text string
+	// Terms are the Japanese spellings of the query (as typed, hiragana and
+	// katakana, converted from romaji when needed) matched against word and
+	// reading, at most MaxSearchTerms of them
+	Terms     []string
+	JLPTLevel *int
+}
+
 // VocabularyRepository defines the interface for vocabulary data access
 type VocabularyRepository interface {
-	// GetAll retrieves all vocabulary items with optional filtering
+	// GetAll retrieves public vocabulary items with optional filtering
 	GetAll(ctx context.Context, jlptLevel *int, limit, offset int) ([]models.Vocabulary, error)
 
+	// GetRandom retrieves up to limit public vocabulary items of a JLPT level in random order
+	GetRandom(ctx context.Context, jlptLevel, limit int) ([]models.Vocabulary, error)
+
+	// FindByTerms retrieves up to limit public vocabulary items whose word or
+	// reading is one of terms, or whose meaning contains one of them
+	FindByTerms(ctx context.Context, terms []string, limit int) ([]models.Vocabulary, error)
+
 	// GetByID retrieves a vocabulary item by ID
 	GetByID(ctx context.Context, id int) (*models.Vocabulary, error)
 
-	// GetDueForReview retrieves vocabulary items due for review for a user
-	GetDueForReview(ctx context.Context, userID int, limit int) ([]models.VocabularyWithProgress, error)
+	// FindByWordReading retrieves the vocabulary item visible to the user with
+	// the given spelling and reading, preferring public items
+	FindByWordReading(ctx context.Context, userID int, word, reading string) (*models.Vocabulary, error)
+
+	// FindByWords retrieves the vocabulary items visible to the user spelled
+	// as any of the given words, public items first
+	FindByWords(ctx context.Context, userID int, words []string) ([]models.Vocabulary, error)
+
+	// Create creates a vocabulary item
+	Create(ctx context.Context, vocab *models.Vocabulary) error
+
+	// UpdatePrivate updates the meaning, part of speech, JLPT level and examples
+	// of a private vocabulary item owned by the user
+	UpdatePrivate(ctx context.Context, userID int, vocab *models.Vocabulary) error
+
+	// DeletePrivate deletes a private vocabulary item owned by the user
+	DeletePrivate(ctx context.Context, userID, id int) error
+
+	// SetSuggested flags or unflags a user's private item as suggested for inclusion
+	SetSuggested(ctx context.Context, userID, id int, suggested bool) error
+
+	// GetSuggested retrieves private items suggested for inclusion, oldest first
+	GetSuggested(ctx context.Context, limit, offset int) ([]models.Vocabulary, error)
+
+	// CountSuggested returns the number of private items suggested for inclusion
+	CountSuggested(ctx context.Context) (int, error)
+
+	// Promote makes a private item public, clearing its owner and suggestion
+	// flag. It fails with a conflict when a public item has the same word and
+	// reading.
+	Promote(ctx context.Context, id int) (*models.Vocabulary, error)
+
+	// GetMasteredWords retrieves the spelling of every vocabulary item the user
+	// has mastered (see LearningStateMastered)
+	GetMasteredWords(ctx context.Context, userID int) ([]string, error)
+
+	// GetDueForReview retrieves vocabulary items due for review for a user,
+	// optionally restricted to one of the user's decks
+	GetDueForReview(ctx context.Context, userID int, deckID *int, limit int) ([]models.VocabularyWithProgress, error)
 
 	// GetUserProgress retrieves user's progress for a vocabulary item
 	GetUserProgress(ctx context.Context, userID, vocabularyID int) (*models.UserVocabularyProgress, error)
 
+	// GetUserProgressForVocabulary retrieves the user's progress on the given
+	// vocabulary items; items without progress are left out
+	GetUserProgressForVocabulary(ctx context.Context, userID int, vocabularyIDs []int) ([]models.UserVocabularyProgress, error)
+
 	// CreateUserProgress creates initial progress for a user-vocabulary pair
 	CreateUserProgress(ctx context.Context, progress *models.UserVocabularyProgress) error
 
+	// SaveUserNotes stores the personal notes of a progress row, creating the row
+	// with the given scheduling when the user has no progress on the item yet.
+	// The stored progress is read back into progress.
+	SaveUserNotes(ctx context.Context, progress *models.UserVocabularyProgress) error
+
 	// UpdateUserProgress updates user's progress for a vocabulary item
 	UpdateUserProgress(ctx context.Context, progress *models.UserVocabularyProgress) error
 
-	// GetUserVocabularyList retrieves vocabulary with user progress
-	GetUserVocabularyList(ctx context.Context, userID int, jlptLevel *int, limit, offset int) ([]models.VocabularyWithProgress, error)
+	// GetUserVocabularyList retrieves filtered and sorted vocabulary with user progress,
+	// and the cursor of the next page (nil on the last page)
+	GetUserVocabularyList(ctx context.Context, userID int, filter VocabularyFilter, limit, offset int) ([]models.VocabularyWithProgress, *Cursor, error)
+
+	// GetUserVocabularyForExport retrieves a page, in ID order, of the items of one
+	// of the user's decks or, without a deck, of the items the user studies or owns,
+	// with the user's progress, and the cursor of the next page (nil on the last page)
+	GetUserVocabularyForExport(ctx context.Context, userID int, deckID *int, after *Cursor, limit int) ([]models.VocabularyWithProgress, *Cursor, error)
+
+	// EachReviewedVocabulary calls fn, in ID order, for every item the user has
+	// reviewed with the user's progress, optionally only for the items of one
+	// of the user's decks. Rows are read as fn consumes them, and an error
+	// returned by fn stops the iteration and is returned as is.
+	EachReviewedVocabulary(ctx context.Context, userID int, deckID *int, fn func(*models.VocabularyWithProgress) error) error
+
+	// CountUserVocabularyList returns the number of vocabulary items matching a list filter
+	CountUserVocabularyList(ctx context.Context, userID int, filter VocabularyFilter) (int, error)
 
-	// Count returns total vocabulary count
+	// Count returns the number of public vocabulary items
 	Count(ctx context.Context, jlptLevel *int) (int, error)
+
+	// Search retrieves vocabulary matching a search query with user progress, best matches first
+	Search(ctx context.Context, userID int, search VocabularySearch, limit, offset int) ([]models.VocabularyWithProgress, error)
+
+	// CountSearch returns the number of vocabulary items visible to a user matching a search query
+	CountSearch(ctx context.Context, userID int, search VocabularySearch) (int, error)
+
+	// CreateReviewLog records a review in the user's review log
+	CreateReviewLog(ctx context.Context, log *models.VocabularyReviewLog) error
+
+	// GetLastReviewLog retrieves the user's latest review of a vocabulary item
+	GetLastReviewLog(ctx context.Context, userID, vocabularyID int) (*models.VocabularyReviewLog, error)
+
+	// GetUserReviewLog retrieves the user's reviews, newest first, optionally for one
+	// vocabulary item, and the cursor of the next page (nil on the last page)
+	GetUserReviewLog(ctx context.Context, userID int, vocabularyID *int, after *Cursor, limit, offset int) ([]models.VocabularyReviewLog, *Cursor, error)
+
+	// CountUserReviewLog returns the number of reviews in the user's review log
+	CountUserReviewLog(ctx context.Context, userID int, vocabularyID *int) (int, error)
 }
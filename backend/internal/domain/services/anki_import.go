@@ -0,0 +1,410 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/pkg/anki"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+)
+
+// Vocabulary fields that note fields can be mapped to
+const (
+	ImportFieldWord               = "word"
+	ImportFieldReading            = "reading"
+	ImportFieldMeaning            = "meaning"
+	ImportFieldPartOfSpeech       = "part_of_speech"
+	ImportFieldExampleSentence    = "example_sentence"
+	ImportFieldExampleTranslation = "example_translation"
+)
+
+// ImportFields lists the vocabulary fields an import can map
+var ImportFields = []string{
+	ImportFieldWord, ImportFieldReading, ImportFieldMeaning, ImportFieldPartOfSpeech,
+	ImportFieldExampleSentence, ImportFieldExampleTranslation,
+}
+
+// AnkiImportOptions describes how Anki notes become vocabulary
+type AnkiImportOptions struct {
+	// FieldMap maps vocabulary fields (see ImportFields) to Anki field names.
+	// word and meaning are required. Without a reading field the reading comes
+	// from furigana in the word ("食[た]べ物[もの]") or from a word written in kana.
+	FieldMap map[string]string
+	// NoteType restricts the import to notes of this note type. When empty,
+	// every note type with the mapped word and meaning fields is imported.
+	NoteType string
+	// IncludeReviews carries over scheduling and review history for items the
+	// user has not studied yet
+	IncludeReviews bool
+	// JLPTLevel of new vocabulary entries, 5 when zero
+	JLPTLevel int
+}
+
+// Validate checks the field mapping
+func (o AnkiImportOptions) Validate() error {
+	for field, ankiField := range o.FieldMap {
+		if !slices.Contains(ImportFields, field) {
+			return pkgErrors.Validation(fmt.Sprintf("Unknown vocabulary field %q, expected one of: %s",
+				field, strings.Join(ImportFields, ", ")))
+		}
+		if strings.TrimSpace(ankiField) == "" {
+			return pkgErrors.Validation(fmt.Sprintf("Missing Anki field name for %q", field))
+		}
+	}
+
+	if o.FieldMap[ImportFieldWord] == "" || o.FieldMap[ImportFieldMeaning] == "" {
+		return pkgErrors.Validation("The field mapping must include word and meaning")
+	}
+	if o.JLPTLevel < 0 || o.JLPTLevel > 5 {
+		return pkgErrors.Validation("JLPT level must be between 1 and 5")
+	}
+
+	return nil
+}
+
+// StartAnkiImport starts importing an .apkg or .colpkg file in the background
+// and returns the pending job. The service owns path and removes it when done.
+func (s *ImportService) StartAnkiImport(ctx context.Context, userID int, fileName, path string, opts AnkiImportOptions) (*models.ImportJob, error) {
+	if err := opts.Validate(); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	job, err := s.createJob(ctx, userID, models.ImportSourceAnki, fileName)
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	return s.startJob(job, func(ctx context.Context, job *models.ImportJob) error {
+		defer os.Remove(path)
+		return s.importAnki(ctx, job, path, opts)
+	}), nil
+}
+
+// RunAnkiImport imports an .apkg or .colpkg file and returns the finished job
+func (s *ImportService) RunAnkiImport(ctx context.Context, userID int, fileName, path string, opts AnkiImportOptions) (*models.ImportJob, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	job, err := s.createJob(ctx, userID, models.ImportSourceAnki, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.runJob(ctx, job, func(job *models.ImportJob) error {
+		return s.importAnki(ctx, job, path, opts)
+	})
+
+	return job, nil
+}
+
+// importAnki imports the notes of a package into job's user vocabulary
+func (s *ImportService) importAnki(ctx context.Context, job *models.ImportJob, path string, opts AnkiImportOptions) error {
+	pkg, err := anki.Open(path)
+	if errors.Is(err, anki.ErrNoCollection) {
+		return errors.New("the file is not an Anki package")
+	}
+	if err != nil {
+		return err
+	}
+	defer pkg.Close()
+
+	noteTypes, err := selectNoteTypes(pkg.NoteTypes(), opts)
+	if err != nil {
+		return err
+	}
+
+	notes, err := pkg.Notes(ctx)
+	if err != nil {
+		return err
+	}
+
+	var cards map[int64][]anki.Card
+	var reviews map[int64][]anki.Review
+	if opts.IncludeReviews {
+		if cards, err = pkg.Cards(ctx); err != nil {
+			return err
+		}
+		if reviews, err = pkg.Reviews(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, note := range notes {
+		if _, ok := noteTypes[note.NoteTypeID]; ok {
+			job.TotalItems++
+		}
+	}
+
+	processed := 0
+	for _, note := range notes {
+		nt, ok := noteTypes[note.NoteTypeID]
+		if !ok {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("the import was interrupted: %w", err)
+		}
+
+		vocab, err := s.importAnkiNote(ctx, job, nt, note, opts)
+		if err == nil && vocab != nil && opts.IncludeReviews {
+			err = s.importAnkiProgress(ctx, job, vocab.ID, cards[note.ID], reviews, pkg.CreatedAt())
+		}
+		if err != nil {
+			job.FailedItems++
+			job.Warn(fmt.Sprintf("note %d: %v", note.ID, err))
+		}
+
+		if processed++; processed%importSaveInterval == 0 {
+			s.saveProgress(ctx, job)
+		}
+	}
+
+	return nil
+}
+
+// selectNoteTypes returns the note types to import, keyed by ID
+func selectNoteTypes(all []anki.NoteType, opts AnkiImportOptions) (map[int64]anki.NoteType, error) {
+	selected := make(map[int64]anki.NoteType)
+	var available []string
+	for _, nt := range all {
+		available = append(available, fmt.Sprintf("%s (%s)", nt.Name, strings.Join(nt.Fields, ", ")))
+
+		if opts.NoteType != "" && nt.Name != opts.NoteType {
+			continue
+		}
+		if nt.HasField(opts.FieldMap[ImportFieldWord]) && nt.HasField(opts.FieldMap[ImportFieldMeaning]) {
+			selected[nt.ID] = nt
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no note type has the fields %q and %q; available note types: %s",
+			opts.FieldMap[ImportFieldWord], opts.FieldMap[ImportFieldMeaning], strings.Join(available, "; "))
+	}
+
+	return selected, nil
+}
+
+// importAnkiNote maps a note to a vocabulary item and saves it with
+// importVocabulary. It returns nil when the note is skipped.
+func (s *ImportService) importAnkiNote(ctx context.Context, job *models.ImportJob, nt anki.NoteType, note anki.Note, opts AnkiImportOptions) (*models.Vocabulary, error) {
+	field := func(name string) string {
+		if ankiField, ok := opts.FieldMap[name]; ok {
+			return anki.PlainText(nt.Field(note, ankiField))
+		}
+		return ""
+	}
+
+	word := firstLine(field(ImportFieldWord))
+	reading := firstLine(field(ImportFieldReading))
+	if anki.HasFurigana(word) {
+		if reading == "" {
+			reading = anki.FuriganaReading(word)
+		}
+		word = anki.FuriganaBase(word)
+	}
+	if anki.HasFurigana(reading) {
+		reading = anki.FuriganaReading(reading)
+	}
+	word = kana.NormalizeWidth(word)
+	reading = strings.ReplaceAll(kana.NormalizeWidth(reading), " ", "")
+	if reading == "" && kana.IsAllKana(word) {
+		reading = word
+	}
+
+	return s.importVocabulary(ctx, job, fmt.Sprintf("note %d", note.ID), &models.Vocabulary{
+		Word:               word,
+		Reading:            reading,
+		Meaning:            strings.ReplaceAll(field(ImportFieldMeaning), "\n", "; "),
+		PartOfSpeech:       optionalString(firstLine(field(ImportFieldPartOfSpeech))),
+		JLPTLevel:          opts.JLPTLevel,
+		ExampleSentence:    optionalString(strings.ReplaceAll(field(ImportFieldExampleSentence), "\n", " ")),
+		ExampleTranslation: optionalString(strings.ReplaceAll(field(ImportFieldExampleTranslation), "\n", " ")),
+	})
+}
+
+// importAnkiProgress converts the schedule of a note's most reviewed card into
+// the user's progress and copies that card's answers into the review log.
+// Items the user already studies keep their progress.
+func (s *ImportService) importAnkiProgress(ctx context.Context, job *models.ImportJob, vocabularyID int, cards []anki.Card, reviews map[int64][]anki.Review, collectionCreated time.Time) error {
+	card, ok := primaryCard(cards)
+	if !ok {
+		return nil
+	}
+
+	_, err := s.vocabRepo.GetUserProgress(ctx, job.UserID, vocabularyID)
+	if err == nil {
+		return nil
+	}
+	if appErr, ok := err.(*pkgErrors.AppError); !ok || appErr.Code != pkgErrors.ErrCodeNotFound {
+		return err
+	}
+
+	var answers []anki.Review
+	for _, r := range reviews[card.ID] {
+		if r.IsAnswer() {
+			answers = append(answers, r)
+		}
+	}
+
+	progress := ankiProgress(job.UserID, vocabularyID, card, answers, collectionCreated)
+	if err := s.vocabRepo.CreateUserProgress(ctx, progress); err != nil {
+		return err
+	}
+	// Creating progress does not store the last review time
+	if err := s.vocabRepo.UpdateUserProgress(ctx, progress); err != nil {
+		return err
+	}
+	job.ProgressImported++
+
+	for _, r := range answers {
+		ease := ankiEaseFactor(r.Factor)
+		if r.Factor == 0 {
+			ease = progress.EaseFactor
+		}
+
+		err := s.vocabRepo.CreateReviewLog(ctx, &models.VocabularyReviewLog{
+			UserID:           job.UserID,
+			VocabularyID:     vocabularyID,
+			Quality:          int(ankiQuality(r.Ease)),
+			IsCorrect:        r.Ease > 1,
+			SelfGraded:       true, // Anki reviews are graded by the learner
+			PreviousInterval: ankiIntervalDays(r.LastInterval),
+			Interval:         ankiIntervalDays(r.Interval),
+			EaseFactor:       ease,
+			ReviewedAt:       r.Time(),
+		})
+		if err != nil {
+			return err
+		}
+		job.ReviewsImported++
+	}
+
+	return nil
+}
+
+// primaryCard returns the most reviewed card of a note that is not new
+func primaryCard(cards []anki.Card) (anki.Card, bool) {
+	var studied []anki.Card
+	for _, c := range cards {
+		if c.Type != anki.CardTypeNew {
+			studied = append(studied, c)
+		}
+	}
+	if len(studied) == 0 {
+		return anki.Card{}, false
+	}
+
+	sort.SliceStable(studied, func(i, j int) bool { return studied[i].Reps > studied[j].Reps })
+	return studied[0], true
+}
+
+// ankiProgress converts an Anki card schedule to SM-2 progress. Repetitions
+// count the trailing successful answers, as SM-2 resets them on a lapse.
+func ankiProgress(userID, vocabularyID int, card anki.Card, answers []anki.Review, collectionCreated time.Time) *models.UserVocabularyProgress {
+	progress := &models.UserVocabularyProgress{
+		UserID:         userID,
+		VocabularyID:   vocabularyID,
+		EaseFactor:     ankiEaseFactor(card.Factor),
+		Interval:       ankiIntervalDays(card.Interval),
+		NextReviewDate: card.NextReview(collectionCreated),
+	}
+	if progress.Interval < 1 {
+		progress.Interval = 1
+	}
+	if progress.NextReviewDate.IsZero() {
+		progress.NextReviewDate = time.Now()
+	}
+
+	if len(answers) == 0 {
+		// Exported without a review log: fall back to the card counters
+		progress.TotalReviews = card.Reps
+		progress.CorrectReviews = card.Reps - card.Lapses
+		if progress.CorrectReviews < 0 {
+			progress.CorrectReviews = 0
+		}
+		if card.Type == anki.CardTypeReview {
+			progress.Repetitions = 1
+		}
+		return progress
+	}
+
+	progress.TotalReviews = len(answers)
+	for _, r := range answers {
+		if r.Ease > 1 {
+			progress.CorrectReviews++
+			progress.Repetitions++
+		} else {
+			progress.Repetitions = 0
+		}
+	}
+	last := answers[len(answers)-1].Time()
+	progress.LastReviewedAt = &last
+
+	return progress
+}
+
+// ankiEaseFactor converts an Anki ease in permille to an SM-2 ease factor
+func ankiEaseFactor(factor int) float64 {
+	if factor == 0 {
+		return 2.5
+	}
+	ease := float64(factor) / 1000
+	if ease < 1.3 {
+		return 1.3
+	}
+	if ease > 5 {
+		return 5
+	}
+	return ease
+}
+
+// ankiQuality maps Anki's answer buttons to SM-2 quality
+func ankiQuality(ease int) ReviewQuality {
+	switch ease {
+	case 1:
+		return ReviewQualityIncorrect
+	case 2:
+		return ReviewQualityCorrectHard
+	case 3:
+		return ReviewQualityCorrectEasy
+	default:
+		return ReviewQualityPerfect
+	}
+}
+
+// ankiIntervalDays converts an Anki interval to days; negative intervals are
+// learning steps in seconds and count as zero days
+func ankiIntervalDays(interval int) int {
+	if interval < 0 {
+		return 0
+	}
+	return interval
+}
+
+// firstLine returns the first line of a multi-line field value
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// optionalString returns nil for an empty string
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,332 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+)
+
+// AnswerVerdict describes how a typed answer compares to the accepted answers
+type AnswerVerdict string
+
+const (
+	// AnswerCorrect - the answer matches an accepted answer after normalization
+	AnswerCorrect AnswerVerdict = "correct"
+	// AnswerAlmost - the answer is a near-miss (missing dakuten, small tsu, typo...)
+	AnswerAlmost AnswerVerdict = "almost"
+	// AnswerIncorrect - the answer does not match
+	AnswerIncorrect AnswerVerdict = "incorrect"
+)
+
+// Near-miss hints returned alongside an AnswerAlmost verdict
+const (
+	AnswerHintDakuten   = "dakuten"
+	AnswerHintSmallKana = "small_kana"
+	AnswerHintSokuon    = "small_tsu"
+	AnswerHintLongVowel = "long_vowel"
+	AnswerHintScript    = "kana_script"
+	AnswerHintSpelling  = "spelling"
+)
+
+// Answer types accepted by the typed review endpoint
+const (
+	AnswerTypeReading = "reading"
+	AnswerTypeMeaning = "meaning"
+)
+
+// AnswerCheckOptions configures how lenient the comparison is
+type AnswerCheckOptions struct {
+	// KanaInsensitive treats katakana and hiragana as equivalent
+	KanaInsensitive bool
+	// AllowRomaji converts romaji input to kana before comparing
+	AllowRomaji bool
+}
+
+// AnswerCheckResult is the outcome of checking a typed answer
+type AnswerCheckResult struct {
+	Verdict AnswerVerdict
+	Matched string // The accepted answer the input was matched against
+	Hint    string // Set for AnswerAlmost to explain what was off
+}
+
+// IsCorrect reports whether the verdict is AnswerCorrect
+func (r AnswerCheckResult) IsCorrect() bool {
+	return r.Verdict == AnswerCorrect
+}
+
+// AnswerChecker checks typed answers server-side with Japanese-aware normalization
+type AnswerChecker struct{}
+
+// NewAnswerChecker creates a new answer checker
+func NewAnswerChecker() *AnswerChecker {
+	return &AnswerChecker{}
+}
+
+var (
+	// meaningSeparators splits "to see, to watch; to look" into its alternatives
+	meaningSeparators = regexp.MustCompile(`[,;/、；]`)
+	// parenthetical strips remarks such as "(polite)" from meanings
+	parenthetical = regexp.MustCompile(`\([^)]*\)|（[^）]*）`)
+	// meaningFillers are leading words learners commonly omit
+	meaningFillers = []string{"to ", "a ", "an ", "the "}
+)
+
+// Check compares answer against every accepted answer and returns the best verdict
+func (c *AnswerChecker) Check(answer string, accepted []string, opts AnswerCheckOptions) AnswerCheckResult {
+	input := c.normalizeJapanese(answer, opts)
+	if input == "" {
+		return AnswerCheckResult{Verdict: AnswerIncorrect}
+	}
+
+	best := AnswerCheckResult{Verdict: AnswerIncorrect}
+	for _, candidate := range accepted {
+		expected := c.normalizeJapanese(candidate, AnswerCheckOptions{KanaInsensitive: opts.KanaInsensitive})
+		if expected == "" {
+			continue
+		}
+
+		if input == expected {
+			return AnswerCheckResult{Verdict: AnswerCorrect, Matched: candidate}
+		}
+
+		if best.Verdict == AnswerIncorrect {
+			if hint := kanaNearMiss(input, expected); hint != "" {
+				best = AnswerCheckResult{Verdict: AnswerAlmost, Matched: candidate, Hint: hint}
+			}
+		}
+	}
+
+	return best
+}
+
+// CheckMeaning checks an English answer against the meanings of a vocabulary item
+func (c *AnswerChecker) CheckMeaning(answer string, vocab *models.Vocabulary) AnswerCheckResult {
+	input := normalizeMeaning(answer)
+	if input == "" {
+		return AnswerCheckResult{Verdict: AnswerIncorrect}
+	}
+
+	best := AnswerCheckResult{Verdict: AnswerIncorrect}
+	for _, meaning := range SplitMeanings(vocab.Meaning) {
+		expected := normalizeMeaning(meaning)
+		if expected == "" {
+			continue
+		}
+
+		if input == expected {
+			return AnswerCheckResult{Verdict: AnswerCorrect, Matched: meaning}
+		}
+
+		if best.Verdict == AnswerIncorrect && isTypo(input, expected) {
+			best = AnswerCheckResult{Verdict: AnswerAlmost, Matched: meaning, Hint: AnswerHintSpelling}
+		}
+	}
+
+	return best
+}
+
+// CheckReading checks a kana (or romaji) answer against a vocabulary item's reading.
+// Typing the word itself, e.g. in kanji, is accepted as well.
+func (c *AnswerChecker) CheckReading(answer string, vocab *models.Vocabulary) AnswerCheckResult {
+	return c.Check(answer, []string{vocab.Reading, vocab.Word}, AnswerCheckOptions{
+		KanaInsensitive: true,
+		AllowRomaji:     true,
+	})
+}
+
+// CheckQuizAnswer checks a submitted answer to a quiz question. Multiple choice
+// answers may be given as the option letter or as the option text.
+func (c *AnswerChecker) CheckQuizAnswer(answer string, question models.QuizQuestion) AnswerCheckResult {
+	if question.QuestionType == models.QuestionTypeMultipleChoice {
+		letter := strings.ToUpper(strings.TrimSpace(kana.NormalizeWidth(answer)))
+		expectedLetter := strings.ToUpper(strings.TrimSpace(question.CorrectAnswer))
+
+		if letter == expectedLetter {
+			return AnswerCheckResult{Verdict: AnswerCorrect, Matched: question.CorrectAnswer}
+		}
+		if len(letter) == 1 {
+			return AnswerCheckResult{Verdict: AnswerIncorrect}
+		}
+
+		// The answer is option text: only an exact match with the correct option counts
+		if option := optionForLetter(question, expectedLetter); option != nil {
+			opts := AnswerCheckOptions{}
+			if c.normalizeJapanese(answer, opts) == c.normalizeJapanese(*option, opts) {
+				return AnswerCheckResult{Verdict: AnswerCorrect, Matched: question.CorrectAnswer}
+			}
+		}
+		return AnswerCheckResult{Verdict: AnswerIncorrect}
+	}
+
+	return c.Check(answer, []string{question.CorrectAnswer}, AnswerCheckOptions{AllowRomaji: true})
+}
+
+// SplitMeanings splits a vocabulary meaning such as "to see, to watch" into its alternatives
+func SplitMeanings(meaning string) []string {
+	parts := meaningSeparators.Split(meaning, -1)
+
+	meanings := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			meanings = append(meanings, part)
+		}
+	}
+	return meanings
+}
+
+// normalizeJapanese folds width, case, punctuation and whitespace, optionally
+// converting romaji to hiragana and katakana to hiragana
+func (c *AnswerChecker) normalizeJapanese(s string, opts AnswerCheckOptions) string {
+	s = kana.NormalizeWidth(s)
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if opts.AllowRomaji && kana.IsRomaji(s) {
+		s = kana.FromRomaji(strings.ReplaceAll(s, " ", ""))
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if r == 'ー' {
+			return r
+		}
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	if opts.KanaInsensitive {
+		s = kana.ToHiragana(s)
+	}
+
+	return s
+}
+
+// normalizeMeaning lowercases an English meaning and drops punctuation,
+// parenthetical remarks and leading fillers such as "to" and "the"
+func normalizeMeaning(s string) string {
+	s = kana.NormalizeWidth(s)
+	s = parenthetical.ReplaceAllString(s, " ")
+	s = strings.ToLower(s)
+
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) && r != '-' && r != '\'' {
+			return ' '
+		}
+		return r
+	}, s)
+	s = strings.Join(strings.Fields(s), " ")
+
+	for _, filler := range meaningFillers {
+		s = strings.TrimPrefix(s, filler)
+	}
+
+	return s
+}
+
+// kanaNearMiss returns a hint when input differs from expected only by a
+// common kana slip, or "" when the two are not close
+func kanaNearMiss(input, expected string) string {
+	input = kana.ToHiragana(input)
+	expected = kana.ToHiragana(expected)
+
+	if !kana.IsAllKana(input) || !kana.IsAllKana(expected) {
+		return ""
+	}
+
+	// Hiragana typed for a katakana word (or the reverse) when the script matters
+	if input == expected {
+		return AnswerHintScript
+	}
+
+	checks := []struct {
+		hint string
+		fold func(string) string
+	}{
+		{AnswerHintDakuten, kana.StripDakuten},
+		{AnswerHintSokuon, kana.StripSokuon},
+		{AnswerHintSmallKana, kana.EnlargeSmallKana},
+		{AnswerHintLongVowel, kana.StripLongVowels},
+	}
+
+	for _, check := range checks {
+		if check.fold(input) == check.fold(expected) {
+			return check.hint
+		}
+	}
+
+	// Several slips at once still count as a near-miss
+	foldAll := func(s string) string {
+		for _, check := range checks {
+			s = check.fold(s)
+		}
+		return s
+	}
+	if foldAll(input) == foldAll(expected) {
+		return AnswerHintSpelling
+	}
+
+	return ""
+}
+
+// isTypo reports whether input is within a small edit distance of expected
+func isTypo(input, expected string) bool {
+	length := len([]rune(expected))
+	if length < 4 {
+		return false
+	}
+
+	allowed := 1
+	if length >= 8 {
+		allowed = 2
+	}
+
+	return editDistance(input, expected) <= allowed
+}
+
+// editDistance computes the optimal string alignment distance between two
+// strings: insertions, deletions, substitutions and adjacent transpositions
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
+
+// optionForLetter returns the option text for a multiple choice letter
+func optionForLetter(question models.QuizQuestion, letter string) *string {
+	switch letter {
+	case "A":
+		return question.OptionA
+	case "B":
+		return question.OptionB
+	case "C":
+		return question.OptionC
+	case "D":
+		return question.OptionD
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	"github.com/joaosantos/jlpt5/pkg/conjugation"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+const (
+	// MaxDrillCount limits how many drills are handed out at once
+	MaxDrillCount = 50
+	// maxLearnedWords limits how many learned words are considered for new drills
+	maxLearnedWords = 200
+)
+
+// ConjugationDrillResult is the outcome of answering a conjugation drill
+type ConjugationDrillResult struct {
+	AnswerCheckResult
+	Expected        string // Standard spelling of the form, e.g. 食べませんでした
+	ExpectedReading string // The same form conjugated from the word's reading
+	Progress        *models.ConjugationProgress
+}
+
+// ConjugationService drills the forms of verbs and adjectives the user has
+// learned, scheduling every (word, form) pair separately with SM-2 so that
+// forms the user gets wrong come back sooner
+type ConjugationService struct {
+	conjugationRepo repository.ConjugationRepository
+	vocabRepo       repository.VocabularyRepository
+	srService       *SpacedRepetitionService
+	answerChecker   *AnswerChecker
+	logger          *utils.Logger
+}
+
+// NewConjugationService creates a new conjugation drill service
+func NewConjugationService(
+	conjugationRepo repository.ConjugationRepository,
+	vocabRepo repository.VocabularyRepository,
+	srService *SpacedRepetitionService,
+	answerChecker *AnswerChecker,
+	logger *utils.Logger,
+) *ConjugationService {
+	return &ConjugationService{
+		conjugationRepo: conjugationRepo,
+		vocabRepo:       vocabRepo,
+		srService:       srService,
+		answerChecker:   answerChecker,
+		logger:          logger,
+	}
+}
+
+// ParseForms parses drill forms such as "past,polite_negative", rejecting
+// forms that no conjugation class has
+func ParseForms(value string) ([]conjugation.Form, error) {
+	var forms []conjugation.Form
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		form := conjugation.Form(part)
+		if !isDrillForm(form) {
+			return nil, pkgErrors.Validation(fmt.Sprintf("Unknown form %q", part))
+		}
+		forms = append(forms, form)
+	}
+	return forms, nil
+}
+
+// GetDrills returns up to count drills: due (word, form) pairs first, then
+// forms of learned words the user has not drilled yet, spread across words so
+// that basic forms of many words come before advanced forms of one.
+// If forms is not empty only those forms are drilled.
+func (s *ConjugationService) GetDrills(ctx context.Context, userID int, forms []conjugation.Form, count int) ([]models.ConjugationDrill, error) {
+	if count < 1 || count > MaxDrillCount {
+		return nil, pkgErrors.Validation(fmt.Sprintf("count must be between 1 and %d", MaxDrillCount))
+	}
+
+	formNames := make([]string, len(forms))
+	for i, form := range forms {
+		formNames[i] = string(form)
+	}
+
+	due, err := s.conjugationRepo.GetDueDrills(ctx, userID, formNames, count)
+	if err != nil {
+		s.logger.Error("Failed to get due drills", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve drills", err)
+	}
+
+	drills := make([]models.ConjugationDrill, 0, count)
+	for _, drill := range due {
+		// Skip words whose class was edited since the form was last drilled
+		if _, err := drillAnswers(&drill.Vocabulary, conjugation.Form(drill.Form)); err == nil {
+			drills = append(drills, drill)
+		}
+	}
+
+	if len(drills) < count {
+		fresh, err := s.newDrills(ctx, userID, forms, count-len(drills))
+		if err != nil {
+			return nil, err
+		}
+		drills = append(drills, fresh...)
+	}
+
+	return drills, nil
+}
+
+// SubmitAnswer checks a typed answer to a drill and reschedules the
+// (word, form) pair
+func (s *ConjugationService) SubmitAnswer(ctx context.Context, userID, vocabularyID int, form conjugation.Form, answer string) (*ConjugationDrillResult, error) {
+	vocab, err := s.vocabRepo.GetByID(ctx, vocabularyID)
+	if err != nil {
+		return nil, err
+	}
+	if !vocab.IsVisibleTo(userID) {
+		return nil, pkgErrors.NotFound("Vocabulary not found")
+	}
+
+	accepted, err := drillAnswers(vocab, form)
+	if err != nil {
+		return nil, err
+	}
+
+	check := s.answerChecker.Check(answer, accepted, AnswerCheckOptions{
+		KanaInsensitive: true,
+		AllowRomaji:     true,
+	})
+
+	class, _ := conjugation.ParseClass(*vocab.PartOfSpeech)
+	result := &ConjugationDrillResult{AnswerCheckResult: check}
+	result.Expected, _ = conjugation.Conjugate(vocab.Word, class, form)
+	result.ExpectedReading, _ = conjugation.Conjugate(vocab.Reading, class, form)
+	if result.ExpectedReading == "" {
+		result.ExpectedReading = result.Expected
+	}
+	// Always tell the learner what was expected, even for a miss
+	if result.Matched == "" {
+		result.Matched = result.Expected
+	}
+
+	progress, err := s.conjugationRepo.GetProgress(ctx, userID, vocabularyID, string(form))
+	if err != nil {
+		appErr, ok := err.(*pkgErrors.AppError)
+		if !ok || appErr.Code != pkgErrors.ErrCodeNotFound {
+			s.logger.Error("Failed to get drill progress", utils.WithContext("error", err.Error()))
+			return nil, pkgErrors.Internal("Failed to get drill progress", err)
+		}
+		progress = s.initializeProgress(userID, vocabularyID, form)
+	}
+
+	progress, err = s.scheduleReview(progress, s.srService.GetQualityFromVerdict(check.Verdict))
+	if err != nil {
+		s.logger.Error("Failed to calculate next review", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to calculate review", err)
+	}
+
+	if err := s.conjugationRepo.SaveProgress(ctx, progress); err != nil {
+		s.logger.Error("Failed to save drill progress", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to save drill progress", err)
+	}
+
+	result.Progress = progress
+	return result, nil
+}
+
+// newDrills picks up to count forms of learned words that the user has not
+// drilled yet, taking one form from every word before a second from any
+func (s *ConjugationService) newDrills(ctx context.Context, userID int, forms []conjugation.Form, count int) ([]models.ConjugationDrill, error) {
+	classes := make([]string, len(conjugation.Classes))
+	for i, class := range conjugation.Classes {
+		classes[i] = string(class)
+	}
+
+	words, err := s.conjugationRepo.GetLearnedVocabulary(ctx, userID, classes, maxLearnedWords)
+	if err != nil {
+		s.logger.Error("Failed to get learned vocabulary", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve drills", err)
+	}
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int, len(words))
+	for i, word := range words {
+		ids[i] = word.ID
+	}
+
+	progress, err := s.conjugationRepo.GetProgressForVocabulary(ctx, userID, ids)
+	if err != nil {
+		s.logger.Error("Failed to get drill progress", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve drills", err)
+	}
+
+	type drillKey struct {
+		vocabularyID int
+		form         string
+	}
+	drilled := make(map[drillKey]bool, len(progress))
+	for _, p := range progress {
+		drilled[drillKey{p.VocabularyID, p.Form}] = true
+	}
+
+	// Forms still to be introduced, per word
+	candidates := make([][]conjugation.Form, len(words))
+	for i := range words {
+		class, _ := conjugation.ParseClass(*words[i].PartOfSpeech)
+		for _, form := range class.Forms() {
+			if len(forms) > 0 && !containsForm(forms, form) {
+				continue
+			}
+			if drilled[drillKey{words[i].ID, string(form)}] {
+				continue
+			}
+
+			// The dictionary form and the like only repeat the prompt
+			answer, err := conjugation.Conjugate(words[i].Word, class, form)
+			if err != nil || answer == words[i].Word {
+				continue
+			}
+			candidates[i] = append(candidates[i], form)
+		}
+	}
+
+	var drills []models.ConjugationDrill
+	for round := 0; len(drills) < count; round++ {
+		added := false
+		for i := range words {
+			if round >= len(candidates[i]) {
+				continue
+			}
+			drills = append(drills, models.ConjugationDrill{
+				Vocabulary: words[i],
+				Form:       string(candidates[i][round]),
+			})
+			added = true
+			if len(drills) == count {
+				break
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	return drills, nil
+}
+
+// initializeProgress creates the initial schedule of a form the user has not
+// drilled yet
+func (s *ConjugationService) initializeProgress(userID, vocabularyID int, form conjugation.Form) *models.ConjugationProgress {
+	initial := s.srService.InitializeProgress(userID, vocabularyID)
+	return &models.ConjugationProgress{
+		UserID:         userID,
+		VocabularyID:   vocabularyID,
+		Form:           string(form),
+		EaseFactor:     initial.EaseFactor,
+		Interval:       initial.Interval,
+		Repetitions:    initial.Repetitions,
+		NextReviewDate: initial.NextReviewDate,
+	}
+}
+
+// scheduleReview runs the SM-2 calculation on a drill's schedule
+func (s *ConjugationService) scheduleReview(progress *models.ConjugationProgress, quality ReviewQuality) (*models.ConjugationProgress, error) {
+	next, err := s.srService.CalculateNextReview(&models.UserVocabularyProgress{
+		UserID:         progress.UserID,
+		VocabularyID:   progress.VocabularyID,
+		EaseFactor:     progress.EaseFactor,
+		Interval:       progress.Interval,
+		Repetitions:    progress.Repetitions,
+		NextReviewDate: progress.NextReviewDate,
+		LastReviewedAt: progress.LastReviewedAt,
+		TotalReviews:   progress.TotalReviews,
+		CorrectReviews: progress.CorrectReviews,
+	}, quality)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *progress
+	updated.EaseFactor = next.EaseFactor
+	updated.Interval = next.Interval
+	updated.Repetitions = next.Repetitions
+	updated.NextReviewDate = next.NextReviewDate
+	updated.LastReviewedAt = next.LastReviewedAt
+	updated.TotalReviews = next.TotalReviews
+	updated.CorrectReviews = next.CorrectReviews
+	return &updated, nil
+}
+
+// drillAnswers returns every accepted answer to a drill: the variants of the
+// form conjugated from both the word and its reading
+func drillAnswers(vocab *models.Vocabulary, form conjugation.Form) ([]string, error) {
+	if vocab.PartOfSpeech == nil {
+		return nil, pkgErrors.Validation("Vocabulary item is not a verb or adjective")
+	}
+	class, ok := conjugation.ParseClass(*vocab.PartOfSpeech)
+	if !ok {
+		return nil, pkgErrors.Validation("Vocabulary item is not a verb or adjective")
+	}
+
+	answers, err := conjugation.Variants(vocab.Word, class, form)
+	if err != nil {
+		if errors.Is(err, conjugation.ErrUnsupportedForm) {
+			return nil, pkgErrors.Validation(fmt.Sprintf("The %s form does not apply to %ss", form, class))
+		}
+		return nil, pkgErrors.Validation("Vocabulary item cannot be conjugated")
+	}
+
+	if vocab.Reading != vocab.Word {
+		if readings, err := conjugation.Variants(vocab.Reading, class, form); err == nil {
+			answers = append(answers, readings...)
+		}
+	}
+
+	return answers, nil
+}
+
+// isDrillForm reports whether any conjugation class has the form
+func isDrillForm(form conjugation.Form) bool {
+	return containsForm(conjugation.VerbForms, form) || containsForm(conjugation.AdjectiveForms, form)
+}
+
+func containsForm(forms []conjugation.Form, form conjugation.Form) bool {
+	for _, f := range forms {
+		if f == form {
+			return true
+		}
+	}
+	return false
+}
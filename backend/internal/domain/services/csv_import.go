@@ -0,0 +1,254 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+)
+
+// maxCSVImportRows limits the number of rows of a CSV import
+const maxCSVImportRows = 10000
+
+// ImportFieldJLPTLevel is the JLPT level column of CSV imports
+const ImportFieldJLPTLevel = "jlpt_level"
+
+// csvImportFields lists the vocabulary fields a CSV import can map
+var csvImportFields = append(append([]string{}, ImportFields...), ImportFieldJLPTLevel)
+
+// utf8BOM is the byte order mark spreadsheet applications put in front of UTF-8 files
+const utf8BOM = "\uFEFF"
+
+// CSVImportOptions describes how the columns of a CSV or TSV file become vocabulary
+type CSVImportOptions struct {
+	// FieldMap maps vocabulary fields (see ImportFields, plus jlpt_level) to
+	// column headers. When empty, columns named after a field are used;
+	// headers are matched ignoring case, so "Part of speech" is part_of_speech.
+	FieldMap map[string]string
+	// Comma is the field delimiter, ',' for CSV or '\t' for TSV
+	Comma rune
+}
+
+// StartCSVImport reads a CSV or TSV file with a header row and imports its
+// rows in the background, returning the pending job. Rows are upserted (see
+// importVocabulary) and rows that fail validation are reported as warnings
+// with their line number. A file with more than maxCSVImportRows rows is
+// rejected before anything is imported.
+func (s *ImportService) StartCSVImport(ctx context.Context, userID int, fileName string, r io.Reader, opts CSVImportOptions) (*models.ImportJob, error) {
+	if opts.Comma != ',' && opts.Comma != '\t' {
+		return nil, pkgErrors.Validation("Delimiter must be a comma or a tab")
+	}
+
+	reader := csv.NewReader(skipBOM(r))
+	reader.Comma = opts.Comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, pkgErrors.Validation("The file is empty")
+	}
+	if err != nil {
+		return nil, pkgErrors.Validation("Invalid header row: " + err.Error())
+	}
+
+	columns, err := csvColumns(header, opts.FieldMap)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := readCSVRecords(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.createJob(ctx, userID, models.ImportSourceCSV, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startJob(job, func(ctx context.Context, job *models.ImportJob) error {
+		return s.importCSV(ctx, job, records, columns)
+	}), nil
+}
+
+// csvRecord is a row of a CSV file with its line number, or the error that
+// made it unreadable
+type csvRecord struct {
+	line   int
+	fields []string
+	err    error
+}
+
+// readCSVRecords reads the rows of a CSV reader positioned after the header,
+// skipping blank rows
+func readCSVRecords(reader *csv.Reader) ([]csvRecord, error) {
+	var records []csvRecord
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			records = append(records, csvRecord{line: parseErr.StartLine, err: parseErr.Err})
+		case err != nil:
+			return nil, pkgErrors.BadRequest("Failed to read the file: " + err.Error())
+		case isBlankRecord(fields):
+			continue
+		default:
+			line, _ := reader.FieldPos(0)
+			records = append(records, csvRecord{line: line, fields: fields})
+		}
+
+		if len(records) > maxCSVImportRows {
+			return nil, pkgErrors.Validation(fmt.Sprintf("The file has more than %d rows", maxCSVImportRows))
+		}
+	}
+}
+
+// skipBOM drops a leading UTF-8 byte order mark
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if prefix, err := br.Peek(len(utf8BOM)); err == nil && string(prefix) == utf8BOM {
+		br.Discard(len(utf8BOM))
+	}
+	return br
+}
+
+// csvColumns resolves the column index of every mapped vocabulary field
+func csvColumns(header []string, fieldMap map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		key := normalizeHeader(name)
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	columns := make(map[string]int)
+	if len(fieldMap) == 0 {
+		for _, field := range csvImportFields {
+			if i, ok := index[field]; ok {
+				columns[field] = i
+			}
+		}
+	} else {
+		for field, name := range fieldMap {
+			if !slices.Contains(csvImportFields, field) {
+				return nil, pkgErrors.Validation(fmt.Sprintf("Unknown vocabulary field %q, expected one of: %s",
+					field, strings.Join(csvImportFields, ", ")))
+			}
+			i, ok := index[normalizeHeader(name)]
+			if !ok {
+				return nil, pkgErrors.Validation(fmt.Sprintf("Column %q not found, the header has: %s",
+					name, strings.Join(header, ", ")))
+			}
+			columns[field] = i
+		}
+	}
+
+	for _, field := range []string{ImportFieldWord, ImportFieldMeaning} {
+		if _, ok := columns[field]; !ok {
+			return nil, pkgErrors.Validation(fmt.Sprintf("No column for %s, the header has: %s",
+				field, strings.Join(header, ", ")))
+		}
+	}
+
+	return columns, nil
+}
+
+// normalizeHeader lowercases a column header and joins its words with underscores
+func normalizeHeader(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(name, "-", " "))), "_")
+}
+
+// importCSV imports the rows read from a CSV file
+func (s *ImportService) importCSV(ctx context.Context, job *models.ImportJob, records []csvRecord, columns map[string]int) error {
+	job.TotalItems = len(records)
+	for i, record := range records {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("the import was interrupted: %w", err)
+		}
+
+		if record.err != nil {
+			job.SkippedItems++
+			job.Warn(fmt.Sprintf("line %d: %v", record.line, record.err))
+		} else if err := s.importCSVRow(ctx, job, fmt.Sprintf("line %d", record.line), record.fields, columns); err != nil {
+			job.FailedItems++
+			job.Warn(fmt.Sprintf("line %d: %v", record.line, err))
+		}
+
+		if (i+1)%importSaveInterval == 0 {
+			s.saveProgress(ctx, job)
+		}
+	}
+
+	return nil
+}
+
+// importCSVRow maps a row to a vocabulary entry and saves it with importVocabulary
+func (s *ImportService) importCSVRow(ctx context.Context, job *models.ImportJob, label string, record []string, columns map[string]int) error {
+	value := func(field string) string {
+		if i, ok := columns[field]; ok && i < len(record) {
+			return unescapeFormula(strings.TrimSpace(record[i]))
+		}
+		return ""
+	}
+
+	vocab := &models.Vocabulary{
+		Word:               value(ImportFieldWord),
+		Reading:            value(ImportFieldReading),
+		Meaning:            value(ImportFieldMeaning),
+		PartOfSpeech:       optionalString(value(ImportFieldPartOfSpeech)),
+		ExampleSentence:    optionalString(value(ImportFieldExampleSentence)),
+		ExampleTranslation: optionalString(value(ImportFieldExampleTranslation)),
+	}
+	// Words written in kana do not need a reading column
+	if vocab.Reading == "" && kana.IsAllKana(kana.NormalizeWidth(vocab.Word)) {
+		vocab.Reading = vocab.Word
+	}
+
+	if level := value(ImportFieldJLPTLevel); level != "" {
+		parsed, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(level), "N"))
+		if err != nil {
+			_, err := s.skipItem(job, label, vocab.Word, pkgErrors.Validation("Invalid JLPT level "+strconv.Quote(level)))
+			return err
+		}
+		vocab.JLPTLevel = parsed
+	}
+
+	_, err := s.importVocabulary(ctx, job, label, vocab)
+	return err
+}
+
+// unescapeFormula drops the apostrophe CSV exports put in front of cells
+// starting with =, +, -, @, a tab or a carriage return, so that an export
+// imports back unchanged
+func unescapeFormula(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
+
+// isBlankRecord reports whether every field of a record is empty
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
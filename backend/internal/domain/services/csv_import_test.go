@@ -0,0 +1,76 @@
+package services
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+// newTestCSVReader returns a reader of text positioned after its header row
+func newTestCSVReader(t *testing.T, text string) *csv.Reader {
+	t.Helper()
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	if _, err := reader.Read(); err != nil {
+		t.Fatalf("reading the header: %v", err)
+	}
+	return reader
+}
+
+func TestReadCSVRecords(t *testing.T) {
+	text := "word,meaning\n" +
+		"猫,cat\n" +
+		"\n" +
+		" , \n" +
+		"犬,\"dog\" x\n" +
+		"鳥,bird\n"
+
+	records, err := readCSVRecords(newTestCSVReader(t, text))
+	if err != nil {
+		t.Fatalf("readCSVRecords() error = %v", err)
+	}
+
+	want := []struct {
+		line   int
+		word   string
+		failed bool
+	}{
+		{2, "猫", false},
+		{5, "", true},
+		{6, "鳥", false},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("readCSVRecords() returned %d records, want %d", len(records), len(want))
+	}
+	for i, w := range want {
+		r := records[i]
+		if r.line != w.line || (r.err != nil) != w.failed {
+			t.Errorf("record %d = line %d, error %v, want line %d, failed %v", i, r.line, r.err, w.line, w.failed)
+		}
+		if !w.failed && r.fields[0] != w.word {
+			t.Errorf("record %d word = %q, want %q", i, r.fields[0], w.word)
+		}
+	}
+}
+
+func TestReadCSVRecordsLimit(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("word,meaning\n")
+	for i := 0; i < maxCSVImportRows; i++ {
+		b.WriteString("猫,cat\n")
+	}
+
+	records, err := readCSVRecords(newTestCSVReader(t, b.String()))
+	if err != nil || len(records) != maxCSVImportRows {
+		t.Fatalf("readCSVRecords() of %d rows = %d records, %v", maxCSVImportRows, len(records), err)
+	}
+
+	b.WriteString("犬,dog\n")
+	_, err = readCSVRecords(newTestCSVReader(t, b.String()))
+	appErr, ok := err.(*pkgErrors.AppError)
+	if !ok || appErr.Code != pkgErrors.ErrCodeValidation {
+		t.Errorf("readCSVRecords() of %d rows error = %v, want a validation error", maxCSVImportRows+1, err)
+	}
+}
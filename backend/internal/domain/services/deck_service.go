@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+const (
+	maxDeckNameLength        = 100
+	maxDeckDescriptionLength = 500
+	// maxItemsPerRequest limits how many vocabulary items are added to a deck or tag at once
+	maxItemsPerRequest = 500
+)
+
+// DeckService handles user-built vocabulary decks
+type DeckService struct {
+	deckRepo  repository.DeckRepository
+	vocabRepo repository.VocabularyRepository
+	logger    *utils.Logger
+}
+
+// NewDeckService creates a new deck service
+func NewDeckService(deckRepo repository.DeckRepository, vocabRepo repository.VocabularyRepository, logger *utils.Logger) *DeckService {
+	return &DeckService{
+		deckRepo:  deckRepo,
+		vocabRepo: vocabRepo,
+		logger:    logger,
+	}
+}
+
+// ListDecks retrieves all decks of a user
+func (s *DeckService) ListDecks(ctx context.Context, userID int) ([]models.Deck, error) {
+	decks, err := s.deckRepo.GetUserDecks(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get decks", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve decks", err)
+	}
+
+	return decks, nil
+}
+
+// CreateDeck creates an empty deck for a user
+func (s *DeckService) CreateDeck(ctx context.Context, userID int, name string, description *string) (*models.Deck, error) {
+	deck := &models.Deck{UserID: userID}
+	if err := setDeckDetails(deck, name, description); err != nil {
+		return nil, err
+	}
+
+	if err := s.deckRepo.CreateDeck(ctx, deck); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to create deck", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to create deck", err)
+	}
+
+	return deck, nil
+}
+
+// GetDeck retrieves one of the user's decks
+func (s *DeckService) GetDeck(ctx context.Context, userID, deckID int) (*models.Deck, error) {
+	deck, err := s.deckRepo.GetDeckByID(ctx, deckID)
+	if err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get deck", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve deck", err)
+	}
+
+	if deck.UserID != userID {
+		return nil, pkgErrors.Forbidden("Not authorized to access this deck")
+	}
+
+	return deck, nil
+}
+
+// UpdateDeck renames a deck and replaces its description
+func (s *DeckService) UpdateDeck(ctx context.Context, userID, deckID int, name string, description *string) (*models.Deck, error) {
+	deck, err := s.GetDeck(ctx, userID, deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setDeckDetails(deck, name, description); err != nil {
+		return nil, err
+	}
+
+	if err := s.deckRepo.UpdateDeck(ctx, deck); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to update deck", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to update deck", err)
+	}
+
+	return deck, nil
+}
+
+// DeleteDeck deletes a deck; the vocabulary items and their progress are kept
+func (s *DeckService) DeleteDeck(ctx context.Context, userID, deckID int) error {
+	if _, err := s.GetDeck(ctx, userID, deckID); err != nil {
+		return err
+	}
+
+	if err := s.deckRepo.DeleteDeck(ctx, deckID); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete deck", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to delete deck", err)
+	}
+
+	return nil
+}
+
+// AddItems adds vocabulary items to a deck and returns how many were added.
+// Unknown items and items already in the deck are skipped.
+func (s *DeckService) AddItems(ctx context.Context, userID, deckID int, vocabularyIDs []int) (int, error) {
+	if err := validateItemIDs(vocabularyIDs); err != nil {
+		return 0, err
+	}
+
+	if _, err := s.GetDeck(ctx, userID, deckID); err != nil {
+		return 0, err
+	}
+
+	added, err := s.deckRepo.AddItems(ctx, deckID, vocabularyIDs)
+	if err != nil {
+		s.logger.Error("Failed to add deck items", utils.WithContext("error", err.Error()))
+		return 0, pkgErrors.Internal("Failed to add items to deck", err)
+	}
+
+	return added, nil
+}
+
+// RemoveItem removes a vocabulary item from a deck
+func (s *DeckService) RemoveItem(ctx context.Context, userID, deckID, vocabularyID int) error {
+	if _, err := s.GetDeck(ctx, userID, deckID); err != nil {
+		return err
+	}
+
+	if err := s.deckRepo.RemoveItem(ctx, deckID, vocabularyID); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return err
+		}
+		s.logger.Error("Failed to remove deck item", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to remove item from deck", err)
+	}
+
+	return nil
+}
+
+// GetDueItems retrieves the items of a deck that are due for review
+func (s *DeckService) GetDueItems(ctx context.Context, userID, deckID, limit int) ([]models.VocabularyWithProgress, error) {
+	if _, err := s.GetDeck(ctx, userID, deckID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.vocabRepo.GetDueForReview(ctx, userID, &deckID, limit)
+	if err != nil {
+		s.logger.Error("Failed to get due deck items", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve due vocabulary", err)
+	}
+
+	return items, nil
+}
+
+// GetDeckStats summarizes the user's progress on a deck
+func (s *DeckService) GetDeckStats(ctx context.Context, userID, deckID int) (*models.DeckStats, error) {
+	if _, err := s.GetDeck(ctx, userID, deckID); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.deckRepo.GetDeckStats(ctx, userID, deckID)
+	if err != nil {
+		s.logger.Error("Failed to get deck stats", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve deck stats", err)
+	}
+
+	return stats, nil
+}
+
+// setDeckDetails validates and applies a deck's name and description
+func setDeckDetails(deck *models.Deck, name string, description *string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return pkgErrors.Validation("Deck name is required")
+	}
+	if utf8.RuneCountInString(name) > maxDeckNameLength {
+		return pkgErrors.Validation("Deck name must be at most 100 characters")
+	}
+
+	if description != nil {
+		trimmed := strings.TrimSpace(*description)
+		if utf8.RuneCountInString(trimmed) > maxDeckDescriptionLength {
+			return pkgErrors.Validation("Deck description must be at most 500 characters")
+		}
+		description = &trimmed
+		if trimmed == "" {
+			description = nil
+		}
+	}
+
+	deck.Name = name
+	deck.Description = description
+	return nil
+}
+
+// validateItemIDs checks a list of vocabulary IDs to add to a deck or tag
+func validateItemIDs(vocabularyIDs []int) error {
+	if len(vocabularyIDs) == 0 {
+		return pkgErrors.Validation("vocabulary_ids is required")
+	}
+	if len(vocabularyIDs) > maxItemsPerRequest {
+		return pkgErrors.Validation("At most 500 vocabulary items can be added at once")
+	}
+	return nil
+}
@@ -0,0 +1,490 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+)
+
+// DistractorKind is what the options of a multiple choice question show
+type DistractorKind string
+
+const (
+	// DistractorWord - options are words, e.g. for meaning→word questions
+	DistractorWord DistractorKind = "word"
+	// DistractorReading - options are kana readings
+	DistractorReading DistractorKind = "reading"
+	// DistractorMeaning - options are English meanings
+	DistractorMeaning DistractorKind = "meaning"
+)
+
+// Problems found in authored multiple choice questions
+const (
+	// QuestionIssueMissingAnswer - the correct answer is not one of the options
+	QuestionIssueMissingAnswer = "missing_answer"
+	// QuestionIssueDuplicate - the option repeats another option
+	QuestionIssueDuplicate = "duplicate"
+	// QuestionIssueAlsoCorrect - the option is a valid answer too, such as a synonym
+	QuestionIssueAlsoCorrect = "also_correct"
+	// QuestionIssueMismatched - the option is not in the script of the answer,
+	// which gives it away
+	QuestionIssueMismatched = "mismatched"
+)
+
+// Similarity weights used to rank distractors
+const (
+	similarPartOfSpeech = 2
+	similarReading      = 3
+	similarKanji        = 3
+	similarCategory     = 2
+)
+
+// lookAlikeKanji groups kanji that learners confuse with one another
+var lookAlikeKanji = [][]rune{
+	[]rune("日目白百自"), []rune("人入八"), []rune("土士上"), []rune("大太犬天"),
+	[]rune("木本休体"), []rune("千干午牛手"), []rune("右石左"), []rune("力刀万方"),
+	[]rune("待持特時寺"), []rune("買貝見員"), []rune("話語読"), []rune("間問聞門開閉"),
+	[]rune("名多夕外"), []rune("水氷永"), []rune("王玉主"), []rune("田由申電"),
+	[]rune("今会合全"), []rune("校交"), []rune("末未"),
+	[]rune("住注"), []rune("晴清"), []rune("計話"), []rune("送道"), []rune("雨両"),
+}
+
+// lookAlikes maps a kanji to the kanji that look like it
+var lookAlikes = func() map[rune][]rune {
+	m := make(map[rune][]rune)
+	for _, group := range lookAlikeKanji {
+		for _, r := range group {
+			for _, other := range group {
+				if other != r {
+					m[r] = append(m[r], other)
+				}
+			}
+		}
+	}
+	return m
+}()
+
+// interchangeableMarkers groups grammar markers that can often fill the same
+// blank: the topic は and the subject が, and に and へ marking a destination
+var interchangeableMarkers = [][]string{{"は", "が"}, {"に", "へ"}}
+
+// QuestionIssue is a problem with one option of an authored question
+type QuestionIssue struct {
+	Option  string // Letter of the option
+	Problem string // One of the QuestionIssue constants
+	Message string
+}
+
+// QuestionValidation is the outcome of validating an authored question
+type QuestionValidation struct {
+	Issues []QuestionIssue
+	// Suggestions are distractors that could replace the flagged options
+	Suggestions []string
+}
+
+// DistractorService picks the wrong options of multiple choice questions
+// so that they are not trivially wrong: words of the same part of speech,
+// readings one mora off, look-alike kanji and meanings of the same
+// category. It never picks a distractor that is also a valid answer.
+type DistractorService struct {
+	vocabRepo repository.VocabularyRepository
+	userRepo  repository.UserRepository
+	logger    *utils.Logger
+}
+
+// NewDistractorService creates a new distractor service
+func NewDistractorService(vocabRepo repository.VocabularyRepository, userRepo repository.UserRepository, logger *utils.Logger) *DistractorService {
+	return &DistractorService{
+		vocabRepo: vocabRepo,
+		userRepo:  userRepo,
+		logger:    logger,
+	}
+}
+
+// Distractors picks up to n distractors for target among the vocabulary of
+// its level
+func (s *DistractorService) Distractors(ctx context.Context, target *models.Vocabulary, kind DistractorKind, n int) ([]string, error) {
+	pool, err := s.vocabRepo.GetRandom(ctx, target.JLPTLevel, quizPoolSize)
+	if err != nil {
+		s.logger.Error("Failed to get vocabulary", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to pick distractors", err)
+	}
+
+	return s.Pick(target, pool, kind, n), nil
+}
+
+// Pick picks up to n distractors for target among candidates, the most
+// similar first. Reading distractors also include kana one mora off the
+// reading of target, such as がこう for がっこう.
+func (s *DistractorService) Pick(target *models.Vocabulary, candidates []models.Vocabulary, kind DistractorKind, n int) []string {
+	type scored struct {
+		option string
+		score  int
+	}
+
+	seen := map[string]bool{normalizeOption(distractorOption(target, kind)): true}
+	var ranked []scored
+	offer := func(option string, score int) {
+		key := normalizeOption(option)
+		if option == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		ranked = append(ranked, scored{option, score})
+	}
+
+	if kind == DistractorReading {
+		for _, slip := range kana.MoraSlips(target.Reading) {
+			offer(slip, similarReading)
+		}
+	}
+
+	for _, i := range rand.Perm(len(candidates)) {
+		candidate := &candidates[i]
+		if candidate.ID == target.ID || isAlsoCorrect(target, candidate, kind) {
+			continue
+		}
+		offer(distractorOption(candidate, kind), similarity(target, candidate))
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	distractors := make([]string, len(ranked))
+	for i, r := range ranked {
+		distractors[i] = r.option
+	}
+	return distractors
+}
+
+// PickMarkers picks up to n grammar markers among candidates as the wrong
+// options of a cloze question whose answer is marker, those closest in length
+// to the answer first and in random order otherwise. Markers that could fill
+// the blank too are never picked.
+func (s *DistractorService) PickMarkers(marker string, candidates []string, n int) []string {
+	seen := map[string]bool{normalizeOption(marker): true}
+	var distractors []string
+	for _, i := range rand.Perm(len(candidates)) {
+		candidate := candidates[i]
+		key := normalizeOption(candidate)
+		if candidate == "" || seen[key] || markersInterchangeable(marker, candidate) {
+			continue
+		}
+		seen[key] = true
+		distractors = append(distractors, candidate)
+	}
+
+	length := len([]rune(marker))
+	distance := func(s string) int {
+		d := len([]rune(s)) - length
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	sort.SliceStable(distractors, func(i, j int) bool {
+		return distance(distractors[i]) < distance(distractors[j])
+	})
+
+	if len(distractors) > n {
+		distractors = distractors[:n]
+	}
+	return distractors
+}
+
+// ValidateQuestion checks the options of an authored multiple choice
+// question for duplicates, options that are also correct and options that
+// give themselves away, and suggests replacements (admin)
+func (s *DistractorService) ValidateQuestion(ctx context.Context, userID int, question models.QuizQuestion) (*QuestionValidation, error) {
+	if err := requireAdmin(ctx, s.userRepo, s.logger, userID); err != nil {
+		return nil, err
+	}
+	if question.QuestionType != models.QuestionTypeMultipleChoice {
+		return nil, pkgErrors.Validation("Only multiple choice questions can be validated")
+	}
+
+	options := make(map[string]string)
+	var letters []string
+	for i, option := range []*string{question.OptionA, question.OptionB, question.OptionC, question.OptionD} {
+		if option != nil && strings.TrimSpace(*option) != "" {
+			options[optionLetters[i]] = strings.TrimSpace(*option)
+			letters = append(letters, optionLetters[i])
+		}
+	}
+
+	validation := &QuestionValidation{}
+	answerLetter := strings.ToUpper(strings.TrimSpace(question.CorrectAnswer))
+	answer, ok := options[answerLetter]
+	if !ok {
+		validation.Issues = append(validation.Issues, QuestionIssue{
+			Option:  answerLetter,
+			Problem: QuestionIssueMissingAnswer,
+			Message: fmt.Sprintf("The correct answer %q is not one of the options", question.CorrectAnswer),
+		})
+		return validation, nil
+	}
+
+	terms := make([]string, 0, len(options))
+	for _, option := range options {
+		terms = append(terms, option)
+	}
+	matches, err := s.vocabRepo.FindByTerms(ctx, terms, quizPoolSize)
+	if err != nil {
+		s.logger.Error("Failed to find vocabulary", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to validate question", err)
+	}
+
+	kind, targets := answerTargets(answer, matches)
+	firstLetter := map[string]string{normalizeOption(answer): answerLetter}
+	for _, letter := range letters {
+		if letter == answerLetter {
+			continue
+		}
+
+		option := options[letter]
+		if first, ok := firstLetter[normalizeOption(option)]; ok {
+			validation.Issues = append(validation.Issues, QuestionIssue{
+				Option:  letter,
+				Problem: QuestionIssueDuplicate,
+				Message: fmt.Sprintf("Option %s repeats option %s", letter, first),
+			})
+			continue
+		}
+		firstLetter[normalizeOption(option)] = letter
+
+		if isJapanese(option) != isJapanese(answer) {
+			validation.Issues = append(validation.Issues, QuestionIssue{
+				Option:  letter,
+				Problem: QuestionIssueMismatched,
+				Message: fmt.Sprintf("Option %s is not written like the answer, which gives it away", letter),
+			})
+			continue
+		}
+
+		if matched := correctOptionMatch(option, kind, targets, matches); matched != nil {
+			validation.Issues = append(validation.Issues, QuestionIssue{
+				Option:  letter,
+				Problem: QuestionIssueAlsoCorrect,
+				Message: fmt.Sprintf("Option %s is also correct: %s (%s) means %q", letter, matched.Word, matched.Reading, matched.Meaning),
+			})
+		}
+	}
+
+	if len(validation.Issues) > 0 && len(targets) > 0 {
+		distractors, err := s.Distractors(ctx, targets[0], kind, len(validation.Issues)+len(options))
+		if err != nil {
+			return nil, err
+		}
+		for _, distractor := range distractors {
+			if _, used := firstLetter[normalizeOption(distractor)]; !used && len(validation.Suggestions) < len(validation.Issues) {
+				validation.Suggestions = append(validation.Suggestions, distractor)
+			}
+		}
+	}
+
+	return validation, nil
+}
+
+// answerTargets returns what the options of a question show, judging by
+// its answer, and the vocabulary items the answer is right for
+func answerTargets(answer string, matches []models.Vocabulary) (DistractorKind, []*models.Vocabulary) {
+	var targets []*models.Vocabulary
+	if !isJapanese(answer) {
+		key := normalizeMeaning(answer)
+		for i := range matches {
+			for _, meaning := range SplitMeanings(matches[i].Meaning) {
+				if normalizeMeaning(meaning) == key {
+					targets = append(targets, &matches[i])
+					break
+				}
+			}
+		}
+		return DistractorMeaning, targets
+	}
+
+	kind := DistractorReading
+	for i := range matches {
+		switch normalizeOption(answer) {
+		case normalizeOption(matches[i].Word):
+			kind = DistractorWord
+			targets = append(targets, &matches[i])
+		case normalizeOption(matches[i].Reading):
+			targets = append(targets, &matches[i])
+		}
+	}
+	return kind, targets
+}
+
+// correctOptionMatch returns the vocabulary item that makes an option a
+// valid answer for one of targets, or nil
+func correctOptionMatch(option string, kind DistractorKind, targets []*models.Vocabulary, matches []models.Vocabulary) *models.Vocabulary {
+	key := normalizeOption(option)
+	for _, target := range targets {
+		if kind == DistractorMeaning {
+			for _, meaning := range SplitMeanings(target.Meaning) {
+				if normalizeMeaning(meaning) == normalizeMeaning(option) {
+					return target
+				}
+			}
+			continue
+		}
+
+		for i := range matches {
+			candidate := &matches[i]
+			if normalizeOption(distractorOption(candidate, kind)) == key && isAlsoCorrect(target, candidate, kind) {
+				return candidate
+			}
+		}
+	}
+	return nil
+}
+
+// isAlsoCorrect reports whether the option of candidate would be a valid
+// answer to a question about target
+func isAlsoCorrect(target, candidate *models.Vocabulary, kind DistractorKind) bool {
+	// Entries of the same word share its readings and meanings
+	if candidate.Word == target.Word {
+		return true
+	}
+	if kind == DistractorReading {
+		return normalizeOption(candidate.Reading) == normalizeOption(target.Reading)
+	}
+
+	meanings := make(map[string]bool)
+	for _, meaning := range SplitMeanings(target.Meaning) {
+		meanings[normalizeMeaning(meaning)] = true
+	}
+	return sharesMeaning(candidate, meanings)
+}
+
+// similarity scores how alike two vocabulary items are
+func similarity(target, candidate *models.Vocabulary) int {
+	score := 0
+	if partOfSpeechGroup(target.PartOfSpeech) == partOfSpeechGroup(candidate.PartOfSpeech) {
+		score += similarPartOfSpeech
+	}
+	if oneMoraApart(target.Reading, candidate.Reading) {
+		score += similarReading
+	}
+	if looksAlike(target.Word, candidate.Word) {
+		score += similarKanji
+	}
+	if sharesTag(target.Tags, candidate.Tags) {
+		score += similarCategory
+	}
+	return score
+}
+
+// distractorOption returns the text v shows as an option of the given kind
+func distractorOption(v *models.Vocabulary, kind DistractorKind) string {
+	switch kind {
+	case DistractorReading:
+		return v.Reading
+	case DistractorMeaning:
+		if meanings := SplitMeanings(v.Meaning); len(meanings) > 0 {
+			return meanings[0]
+		}
+		return ""
+	default:
+		return v.Word
+	}
+}
+
+// oneMoraApart reports whether two readings differ by exactly one mora
+// changed, added or removed
+func oneMoraApart(a, b string) bool {
+	x, y := kana.Morae(kana.ToHiragana(a)), kana.Morae(kana.ToHiragana(b))
+	if len(x) < len(y) {
+		x, y = y, x
+	}
+	if len(x)-len(y) > 1 || len(y) == 0 {
+		return false
+	}
+
+	i := 0
+	for i < len(y) && x[i] == y[i] {
+		i++
+	}
+	if i == len(y) {
+		return len(x) != len(y)
+	}
+	if len(x) == len(y) {
+		return strings.Join(x[i+1:], "") == strings.Join(y[i+1:], "")
+	}
+	return strings.Join(x[i+1:], "") == strings.Join(y[i:], "")
+}
+
+// looksAlike reports whether two different words share a kanji, or have
+// kanji that look alike
+func looksAlike(a, b string) bool {
+	if a == b || !kana.ContainsKanji(a) {
+		return false
+	}
+	for _, r := range a {
+		if !kana.IsKanji(r) {
+			continue
+		}
+		if strings.ContainsRune(b, r) {
+			return true
+		}
+		for _, other := range lookAlikes[r] {
+			if strings.ContainsRune(b, other) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sharesTag reports whether two tag lists have a tag in common. Tags such
+// as food or family are the semantic categories of the vocabulary.
+func sharesTag(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if strings.EqualFold(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// markersInterchangeable reports whether two grammar markers are among the
+// interchangeable markers
+func markersInterchangeable(a, b string) bool {
+	for _, group := range interchangeableMarkers {
+		if slices.Contains(group, a) && slices.Contains(group, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJapanese reports whether s contains kana or kanji
+func isJapanese(s string) bool {
+	for _, r := range s {
+		if kana.IsKana(r) || kana.IsKanji(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeOption folds case, width and script so that options spelled
+// alike compare equal
+func normalizeOption(s string) string {
+	return kana.ToHiragana(strings.ToLower(strings.TrimSpace(kana.NormalizeWidth(s))))
+}
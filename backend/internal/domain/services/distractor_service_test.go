@@ -0,0 +1,40 @@
+package services
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPickMarkers(t *testing.T) {
+	candidates := []string{"が", "へ", "を", "で", "は", "です", "ではありません", "を"}
+
+	tests := []struct {
+		name   string
+		marker string
+		n      int
+		want   []string // Picked in any order
+	}{
+		{"は never offers が", "は", 3, []string{"へ", "を", "で"}},
+		{"が never offers は", "が", 3, []string{"へ", "を", "で"}},
+		{"に never offers へ", "に", 4, []string{"が", "を", "で", "は"}},
+		{"closest in length first", "です", 1, []string{"が", "へ", "を", "で", "は"}},
+		{"fewer candidates than asked", "ではありません", 10, []string{"が", "へ", "を", "で", "は", "です"}},
+	}
+
+	s := &DistractorService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.PickMarkers(tt.marker, slices.Clone(candidates), tt.n)
+			if len(got) != min(tt.n, len(tt.want)) {
+				t.Fatalf("PickMarkers(%q) = %v, want %d of %v", tt.marker, got, min(tt.n, len(tt.want)), tt.want)
+			}
+			seen := make(map[string]bool)
+			for _, option := range got {
+				if !slices.Contains(tt.want, option) || seen[option] {
+					t.Errorf("PickMarkers(%q) = %v, want distinct options among %v", tt.marker, got, tt.want)
+				}
+				seen[option] = true
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+	"github.com/joaosantos/jlpt5/pkg/tatoeba"
+	"github.com/joaosantos/jlpt5/pkg/tokenizer"
+)
+
+const (
+	// DefaultMaxSentenceLength is the longest sentence, in characters, imported by default
+	DefaultMaxSentenceLength = 40
+	// vocabularyLoadPageSize is how many vocabulary items are loaded at a time for indexing
+	vocabularyLoadPageSize = 1000
+	// sentenceReindexPageSize is how many sentences are loaded at a time for re-indexing
+	sentenceReindexPageSize = 500
+)
+
+// ExampleService handles example sentences for vocabulary items
+type ExampleService struct {
+	exampleRepo  repository.ExampleSentenceRepository
+	vocabRepo    repository.VocabularyRepository
+	vocabService *VocabularyService
+	tokenizer    tokenizer.Tokenizer
+	logger       *utils.Logger
+}
+
+// NewExampleService creates a new example sentence service
+func NewExampleService(
+	exampleRepo repository.ExampleSentenceRepository,
+	vocabRepo repository.VocabularyRepository,
+	vocabService *VocabularyService,
+	tok tokenizer.Tokenizer,
+	logger *utils.Logger,
+) *ExampleService {
+	return &ExampleService{
+		exampleRepo:  exampleRepo,
+		vocabRepo:    vocabRepo,
+		vocabService: vocabService,
+		tokenizer:    tok,
+		logger:       logger,
+	}
+}
+
+// GetExamples retrieves example sentences containing a vocabulary item, the
+// easiest for an N5 learner first
+func (s *ExampleService) GetExamples(ctx context.Context, userID, vocabularyID, page, pageSize int) ([]models.ExampleSentence, int, error) {
+	if _, err := s.vocabService.getVisibleVocabulary(ctx, userID, vocabularyID); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	sentences, err := s.exampleRepo.GetForVocabulary(ctx, vocabularyID, pageSize, offset)
+	if err != nil {
+		s.logger.Error("Failed to get example sentences", utils.WithContext("error", err.Error()))
+		return nil, 0, pkgErrors.Internal("Failed to retrieve example sentences", err)
+	}
+
+	total, err := s.exampleRepo.CountForVocabulary(ctx, vocabularyID)
+	if err != nil {
+		s.logger.Error("Failed to count example sentences", utils.WithContext("error", err.Error()))
+		return nil, 0, pkgErrors.Internal("Failed to retrieve example sentences", err)
+	}
+
+	return sentences, total, nil
+}
+
+// SentenceImportResult summarizes an example sentence import
+type SentenceImportResult struct {
+	Read      int // Sentence pairs read
+	Saved     int // Sentences containing at least one vocabulary item
+	TooLong   int // Sentences over the length limit
+	Unmatched int // Sentences without any vocabulary item
+}
+
+// ImportSentences indexes sentence pairs against the public vocabulary by the
+// words the tokenizer finds in them, and saves the sentences that contain at
+// least one item. Sentences longer than maxLength characters are skipped.
+// Importing the same pairs again updates the sentences and their index, e.g.
+// after vocabulary was added.
+func (s *ExampleService) ImportSentences(ctx context.Context, pairs []tatoeba.Pair, maxLength int) (*SentenceImportResult, error) {
+	matcher, err := s.loadMatcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SentenceImportResult{}
+	for _, pair := range pairs {
+		result.Read++
+
+		japanese := strings.TrimSpace(pair.Japanese)
+		length := utf8.RuneCountInString(japanese)
+		if length > maxLength {
+			result.TooLong++
+			continue
+		}
+
+		ids, coverage := matcher.match(s.tokenizer.Tokenize(kana.NormalizeWidth(japanese)))
+		if len(ids) == 0 {
+			result.Unmatched++
+			continue
+		}
+
+		englishID := pair.EnglishID
+		sentence := &models.ExampleSentence{
+			TatoebaID:        pair.JapaneseID,
+			Japanese:         japanese,
+			English:          strings.TrimSpace(pair.English),
+			EnglishTatoebaID: &englishID,
+			CharCount:        length,
+			N5Coverage:       coverage,
+		}
+		if err := s.exampleRepo.SaveSentence(ctx, sentence, ids); err != nil {
+			return result, err
+		}
+		result.Saved++
+	}
+
+	return result, nil
+}
+
+// ReindexSentences indexes the imported sentences again against the public
+// vocabulary, e.g. after vocabulary was added or the matching changed.
+// Sentences that no longer contain any item are kept without an index.
+func (s *ExampleService) ReindexSentences(ctx context.Context) (*SentenceImportResult, error) {
+	matcher, err := s.loadMatcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SentenceImportResult{}
+	for afterID := 0; ; {
+		sentences, err := s.exampleRepo.GetSentences(ctx, afterID, sentenceReindexPageSize)
+		if err != nil {
+			return result, err
+		}
+
+		for i := range sentences {
+			sentence := &sentences[i]
+			result.Read++
+
+			ids, coverage := matcher.match(s.tokenizer.Tokenize(kana.NormalizeWidth(sentence.Japanese)))
+			if len(ids) == 0 {
+				result.Unmatched++
+			} else {
+				result.Saved++
+			}
+
+			sentence.N5Coverage = coverage
+			if err := s.exampleRepo.SaveSentence(ctx, sentence, ids); err != nil {
+				return result, err
+			}
+		}
+
+		if len(sentences) < sentenceReindexPageSize {
+			return result, nil
+		}
+		afterID = sentences[len(sentences)-1].ID
+	}
+}
+
+// loadMatcher indexes the public vocabulary for matching sentences
+func (s *ExampleService) loadMatcher(ctx context.Context) (*vocabularyMatcher, error) {
+	var vocabulary []models.Vocabulary
+	for offset := 0; ; offset += vocabularyLoadPageSize {
+		items, err := s.vocabRepo.GetAll(ctx, nil, vocabularyLoadPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		vocabulary = append(vocabulary, items...)
+		if len(items) < vocabularyLoadPageSize {
+			break
+		}
+	}
+
+	return newVocabularyMatcher(vocabulary), nil
+}
+
+// vocabularyMatcher finds the vocabulary items a tokenized sentence contains
+type vocabularyMatcher struct {
+	byWord    map[string][]matcherEntry
+	byReading map[string][]matcherEntry
+}
+
+type matcherEntry struct {
+	id  int
+	pos string // Part of speech group, see partOfSpeechGroup
+	n5  bool
+}
+
+// newVocabularyMatcher indexes vocabulary by spelling and by reading in hiragana
+func newVocabularyMatcher(items []models.Vocabulary) *vocabularyMatcher {
+	m := &vocabularyMatcher{
+		byWord:    make(map[string][]matcherEntry),
+		byReading: make(map[string][]matcherEntry),
+	}
+	for _, item := range items {
+		word := kana.NormalizeWidth(item.Word)
+		if word == "" {
+			continue
+		}
+
+		entry := matcherEntry{
+			id:  item.ID,
+			pos: partOfSpeechGroup(item.PartOfSpeech),
+			n5:  item.JLPTLevel == 5,
+		}
+		m.byWord[word] = append(m.byWord[word], entry)
+		if reading := kana.ToHiragana(kana.NormalizeWidth(item.Reading)); reading != "" {
+			m.byReading[reading] = append(m.byReading[reading], entry)
+		}
+	}
+	return m
+}
+
+// lookup returns the item a token is a form of. Words are found by their
+// dictionary form, so that 食べました is 食べる, and then by their surface.
+// Words written in kana are also found by reading when a single item of the
+// same or an unknown part of speech reads that way, so that ねこ is 猫 while かえる, which
+// could be 帰る or 変える, is left out. Particles, auxiliaries and dependent
+// words such as the いる of 食べている are never matched.
+func (m *vocabularyMatcher) lookup(token tokenizer.Token) (matcherEntry, bool) {
+	switch token.POS {
+	case tokenizer.POSSymbol, tokenizer.POSParticle, tokenizer.POSAuxiliaryVerb:
+		return matcherEntry{}, false
+	}
+	if slices.Contains(token.POSTags, "非自立") {
+		return matcherEntry{}, false
+	}
+
+	for _, word := range []string{token.BaseForm, token.Surface} {
+		if entries := m.byWord[word]; len(entries) > 0 {
+			return entries[0], true
+		}
+	}
+
+	if !kana.IsAllKana(token.BaseForm) {
+		return matcherEntry{}, false
+	}
+	var found []matcherEntry
+	for _, entry := range m.byReading[kana.ToHiragana(token.BaseForm)] {
+		if entry.pos == token.POS || entry.pos == "" {
+			found = append(found, entry)
+		}
+	}
+	if len(found) != 1 {
+		return matcherEntry{}, false
+	}
+	return found[0], true
+}
+
+// match returns the IDs of the items among the tokens of a sentence and the
+// share of the sentence an N5 learner can read: kanji and words of higher
+// levels count as unreadable unless they are part of an N5 word, while
+// punctuation is ignored
+func (m *vocabularyMatcher) match(tokens []tokenizer.Token) ([]int, float64) {
+	const (
+		unmatched = iota
+		higherLevel
+		n5
+	)
+
+	seen := make(map[int]bool)
+	var ids []int
+	counted, readable := 0, 0
+	for _, token := range tokens {
+		state := unmatched
+		if entry, ok := m.lookup(token); ok {
+			if !seen[entry.id] {
+				seen[entry.id] = true
+				ids = append(ids, entry.id)
+			}
+			state = higherLevel
+			if entry.n5 {
+				state = n5
+			}
+		}
+
+		for _, r := range token.Surface {
+			if unicode.IsPunct(r) || unicode.IsSpace(r) || unicode.IsSymbol(r) {
+				continue
+			}
+			counted++
+			if state == n5 || (state == unmatched && !unicode.Is(unicode.Han, r)) {
+				readable++
+			}
+		}
+	}
+
+	if counted == 0 {
+		return ids, 0
+	}
+	return ids, float64(readable) / float64(counted)
+}
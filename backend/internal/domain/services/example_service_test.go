@@ -0,0 +1,95 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/pkg/tokenizer"
+)
+
+func TestVocabularyMatcher(t *testing.T) {
+	pos := func(s string) *string { return &s }
+	matcher := newVocabularyMatcher([]models.Vocabulary{
+		{ID: 1, Word: "食べる", Reading: "たべる", PartOfSpeech: pos("ichidan verb"), JLPTLevel: 5},
+		{ID: 2, Word: "いる", Reading: "いる", PartOfSpeech: pos("ichidan verb"), JLPTLevel: 5},
+		{ID: 3, Word: "猫", Reading: "ねこ", PartOfSpeech: pos("noun"), JLPTLevel: 5},
+		{ID: 4, Word: "帰る", Reading: "かえる", PartOfSpeech: pos("godan verb"), JLPTLevel: 5},
+		{ID: 5, Word: "変える", Reading: "かえる", PartOfSpeech: pos("ichidan verb"), JLPTLevel: 4},
+		{ID: 6, Word: "部屋", Reading: "へや", PartOfSpeech: pos("noun"), JLPTLevel: 5},
+		{ID: 7, Word: "入る", Reading: "はいる", PartOfSpeech: pos("godan verb"), JLPTLevel: 5},
+		{ID: 8, Word: "会議", Reading: "かいぎ", PartOfSpeech: pos("noun"), JLPTLevel: 3},
+		{ID: 9, Word: "に", Reading: "に", PartOfSpeech: pos("particle"), JLPTLevel: 5},
+	})
+
+	verb := []string{"動詞", "自立"}
+	dependent := []string{"動詞", "非自立"}
+	noun := []string{"名詞", "一般"}
+	particle := []string{"助詞", "格助詞"}
+	token := func(surface, base, reading, pos string, tags []string) tokenizer.Token {
+		return tokenizer.Token{Surface: surface, BaseForm: base, Reading: reading, POS: pos, POSTags: tags}
+	}
+
+	tests := []struct {
+		name     string
+		tokens   []tokenizer.Token
+		ids      []int
+		coverage float64
+	}{
+		{
+			"inflected verb by its dictionary form",
+			[]tokenizer.Token{
+				token("猫", "猫", "ねこ", tokenizer.POSNoun, noun),
+				token("が", "が", "が", tokenizer.POSParticle, particle),
+				token("食べ", "食べる", "たべ", tokenizer.POSVerb, verb),
+				token("て", "て", "て", tokenizer.POSParticle, particle),
+				token("いる", "いる", "いる", tokenizer.POSVerb, dependent),
+				token("。", "。", "", tokenizer.POSSymbol, []string{"記号"}),
+			},
+			[]int{3, 1}, 1,
+		},
+		{
+			"kana inside a longer word does not match",
+			[]tokenizer.Token{
+				token("部屋", "部屋", "へや", tokenizer.POSNoun, noun),
+				token("に", "に", "に", tokenizer.POSParticle, particle),
+				token("はいり", "はいる", "はいり", tokenizer.POSVerb, verb),
+				token("ます", "ます", "ます", tokenizer.POSAuxiliaryVerb, []string{"助動詞"}),
+			},
+			[]int{6, 7}, 1,
+		},
+		{
+			"kana word by its reading",
+			[]tokenizer.Token{token("ねこ", "ねこ", "ねこ", tokenizer.POSNoun, noun)},
+			[]int{3}, 1,
+		},
+		{
+			"ambiguous reading is left out",
+			[]tokenizer.Token{token("かえる", "かえる", "かえる", tokenizer.POSVerb, verb)},
+			nil, 1,
+		},
+		{
+			"higher level words are not readable",
+			[]tokenizer.Token{
+				token("会議", "会議", "かいぎ", tokenizer.POSNoun, noun),
+				token("に", "に", "に", tokenizer.POSParticle, particle),
+				token("入る", "入る", "はいる", tokenizer.POSVerb, verb),
+			},
+			[]int{8, 7}, 3.0 / 5,
+		},
+		{
+			"unknown kanji are not readable",
+			[]tokenizer.Token{token("鬱", "鬱", "うつ", tokenizer.POSNoun, noun)},
+			nil, 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, coverage := matcher.match(tt.tokens)
+			if !reflect.DeepEqual(ids, tt.ids) || !closeTo(coverage, tt.coverage) {
+				t.Errorf("match() = %v, %v, want %v, %v", ids, coverage, tt.ids, tt.coverage)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	"github.com/joaosantos/jlpt5/pkg/anki"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+const (
+	// ankiNoteTypeID identifies the exported note type, so that Anki updates
+	// notes from earlier exports instead of duplicating the note type
+	ankiNoteTypeID = 1700000000000
+	// ankiDeckIDBase is added to deck IDs to get stable Anki deck IDs; the
+	// export of all vocabulary uses the base itself
+	ankiDeckIDBase = 1700000000000
+	// ankiExportDeckName names the export of all of the user's vocabulary
+	ankiExportDeckName = "JLPT5 Vocabulary"
+	// exportPageSize is how many items are fetched at a time for streamed exports
+	exportPageSize = 500
+)
+
+// ankiExportFields are the fields of the exported note type
+var ankiExportFields = []string{"Word", "Reading", "Meaning", "Example", "Example Translation"}
+
+// VocabularyCSVColumns are the columns of the vocabulary CSV export. The
+// columns other than tags are the field names the CSV import recognizes, so
+// an export can be imported again; tags are built-in tags, which private
+// entries do not have, and the import ignores them.
+var VocabularyCSVColumns = []string{
+	"word", "reading", "meaning", "part_of_speech", "jlpt_level",
+	"example_sentence", "example_translation", "tags",
+}
+
+const (
+	ankiFrontTemplate = `<div class="word">{{Word}}</div>`
+	ankiBackTemplate  = `{{FrontSide}}
+<hr id=answer>
+<div class="reading">{{Reading}}</div>
+<div>{{Meaning}}</div>
+{{#Example}}<div class="example">{{Example}}<br>{{Example Translation}}</div>{{/Example}}`
+	ankiCSS = `.card { font-family: sans-serif; font-size: 20px; text-align: center; }
+.word { font-size: 48px; }
+.reading { font-size: 24px; color: #666; }
+.example { margin-top: 1em; font-size: 18px; }`
+)
+
+// ExportService exports user vocabulary to other applications
+type ExportService struct {
+	vocabRepo   repository.VocabularyRepository
+	deckService *DeckService
+	logger      *utils.Logger
+}
+
+// NewExportService creates a new export service
+func NewExportService(vocabRepo repository.VocabularyRepository, deckService *DeckService, logger *utils.Logger) *ExportService {
+	return &ExportService{
+		vocabRepo:   vocabRepo,
+		deckService: deckService,
+		logger:      logger,
+	}
+}
+
+// AnkiExport is an Anki package ready to be written. Close releases its
+// temporary files.
+type AnkiExport struct {
+	FileName string
+	*anki.Archive
+}
+
+// ExportAnki builds an .apkg package of one of the user's decks or, without a
+// deck, of all vocabulary the user studies or owns. With includeProgress,
+// studied items keep their scheduling; otherwise every card is new.
+func (s *ExportService) ExportAnki(ctx context.Context, userID int, deckID *int, includeProgress bool) (*AnkiExport, error) {
+	export := anki.Export{
+		DeckID:       ankiDeckIDBase,
+		DeckName:     ankiExportDeckName,
+		NoteTypeID:   ankiNoteTypeID,
+		NoteTypeName: ankiExportDeckName,
+		Fields:       ankiExportFields,
+		Front:        ankiFrontTemplate,
+		Back:         ankiBackTemplate,
+		CSS:          ankiCSS,
+	}
+
+	if deckID != nil {
+		deck, err := s.deckService.GetDeck(ctx, userID, *deckID)
+		if err != nil {
+			return nil, err
+		}
+		export.DeckID = ankiDeckIDBase + int64(deck.ID)
+		export.DeckName = deck.Name
+	}
+
+	// Notes are written a page at a time as the collection is built
+	export.EachNote = func(add func(anki.ExportNote) error) error {
+		var after *repository.Cursor
+		for {
+			items, next, err := s.vocabRepo.GetUserVocabularyForExport(ctx, userID, deckID, after, exportPageSize)
+			if err != nil {
+				return fmt.Errorf("error getting vocabulary for export: %w", err)
+			}
+
+			for i := range items {
+				if err := add(toAnkiNote(&items[i], includeProgress)); err != nil {
+					return err
+				}
+			}
+
+			if next == nil {
+				return nil
+			}
+			after = next
+		}
+	}
+
+	archive, err := anki.NewArchive(ctx, export)
+	if err != nil {
+		s.logger.Error("Failed to build Anki package", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to export vocabulary", err)
+	}
+
+	return &AnkiExport{
+		FileName: exportFileName(export.DeckName) + ".apkg",
+		Archive:  archive,
+	}, nil
+}
+
+// EachVocabulary calls fn for every item of a filtered vocabulary list, in
+// list order, fetching the list a page at a time
+func (s *ExportService) EachVocabulary(ctx context.Context, userID int, filter repository.VocabularyFilter, fn func(*models.VocabularyWithProgress) error) error {
+	filter.After = nil
+	for {
+		items, next, err := s.vocabRepo.GetUserVocabularyList(ctx, userID, filter, exportPageSize, 0)
+		if err != nil {
+			s.logger.Error("Failed to get vocabulary for export", utils.WithContext("error", err.Error()))
+			return pkgErrors.Internal("Failed to export vocabulary", err)
+		}
+
+		for i := range items {
+			if err := fn(&items[i]); err != nil {
+				return err
+			}
+		}
+
+		if next == nil {
+			return nil
+		}
+		filter.After = next
+	}
+}
+
+// EachProgress calls fn for every item the user has reviewed with its
+// progress, optionally only for one of the user's decks, reading the items
+// as fn consumes them
+func (s *ExportService) EachProgress(ctx context.Context, userID int, deckID *int, fn func(*models.VocabularyWithProgress) error) error {
+	if deckID != nil {
+		if _, err := s.deckService.GetDeck(ctx, userID, *deckID); err != nil {
+			return err
+		}
+	}
+
+	// Errors of fn are returned as is, only those of the query are internal
+	var fnErr error
+	err := s.vocabRepo.EachReviewedVocabulary(ctx, userID, deckID, func(item *models.VocabularyWithProgress) error {
+		fnErr = fn(item)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		s.logger.Error("Failed to get progress for export", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to export progress", err)
+	}
+
+	return nil
+}
+
+// toAnkiNote converts a vocabulary item to an Anki note
+func toAnkiNote(item *models.VocabularyWithProgress, includeProgress bool) anki.ExportNote {
+	note := anki.ExportNote{
+		GUID: fmt.Sprintf("jlpt5-vocab-%d", item.ID),
+		Fields: []string{
+			item.Word,
+			item.Reading,
+			item.Meaning,
+			derefString(item.ExampleSentence),
+			derefString(item.ExampleTranslation),
+		},
+		Tags: []string{"jlpt5", fmt.Sprintf("jlpt-n%d", item.JLPTLevel)},
+	}
+
+	// Anki tags are separated by spaces
+	for _, tag := range item.Tags {
+		note.Tags = append(note.Tags, strings.Join(strings.Fields(tag), "_"))
+	}
+
+	// Progress rows created for personal notes carry no review history
+	if p := item.Progress; includeProgress && p != nil && p.TotalReviews > 0 {
+		note.Schedule = &anki.Schedule{
+			Due:      p.NextReviewDate,
+			Interval: p.Interval,
+			Factor:   int(p.EaseFactor * 1000),
+			Reps:     p.TotalReviews,
+			Lapses:   p.TotalReviews - p.CorrectReviews,
+		}
+	}
+
+	return note
+}
+
+// VocabularyCSVRow returns the cells of an item for the vocabulary CSV
+// export, in the order of VocabularyCSVColumns
+func VocabularyCSVRow(item *models.VocabularyWithProgress) []string {
+	return []string{
+		item.Word,
+		item.Reading,
+		item.Meaning,
+		derefString(item.PartOfSpeech),
+		strconv.Itoa(item.JLPTLevel),
+		derefString(item.ExampleSentence),
+		derefString(item.ExampleTranslation),
+		strings.Join(item.Tags, "; "),
+	}
+}
+
+// exportFileName turns a deck name into a file name without path separators
+// or other characters that are awkward in file names
+func exportFileName(name string) string {
+	fileName := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+
+	if strings.Trim(fileName, "_") == "" {
+		return "vocabulary"
+	}
+	return fileName
+}
+
+// derefString returns the value of an optional string, or "" when unset
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
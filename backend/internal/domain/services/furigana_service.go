@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/furigana"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+	"github.com/joaosantos/jlpt5/pkg/tokenizer"
+)
+
+// FuriganaService annotates Japanese text with the readings of its kanji
+type FuriganaService struct {
+	tokenizer tokenizer.Tokenizer
+	vocabRepo repository.VocabularyRepository
+	logger    *utils.Logger
+}
+
+// NewFuriganaService creates a new furigana service
+func NewFuriganaService(tok tokenizer.Tokenizer, vocabRepo repository.VocabularyRepository, logger *utils.Logger) *FuriganaService {
+	return &FuriganaService{
+		tokenizer: tok,
+		vocabRepo: vocabRepo,
+		logger:    logger,
+	}
+}
+
+// NewAnnotator creates an annotator for one user. With hideMastered, kanji
+// that appear in vocabulary the user has mastered get no furigana.
+func (s *FuriganaService) NewAnnotator(ctx context.Context, userID int, hideMastered bool) (*FuriganaAnnotator, error) {
+	a := &FuriganaAnnotator{tokenizer: s.tokenizer}
+	if !hideMastered {
+		return a, nil
+	}
+
+	words, err := s.vocabRepo.GetMasteredWords(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get mastered vocabulary", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to generate furigana", err)
+	}
+
+	a.mastered = make(map[rune]bool)
+	for _, word := range words {
+		for _, r := range word {
+			if kana.IsKanji(r) {
+				a.mastered[r] = true
+			}
+		}
+	}
+	return a, nil
+}
+
+// FuriganaAnnotator generates furigana for the texts of one response
+type FuriganaAnnotator struct {
+	tokenizer tokenizer.Tokenizer
+	mastered  map[rune]bool // Kanji whose furigana is hidden
+}
+
+// Word annotates a vocabulary word with its reading
+func (a *FuriganaAnnotator) Word(word, reading string) []furigana.Segment {
+	return a.hideMastered(furigana.Align(word, reading))
+}
+
+// Sentence annotates a sentence using the tokenizer's readings. Occurrences
+// of word take the given reading instead, which resolves words the tokenizer
+// may read differently, e.g. 今日 as こんにち instead of きょう. word may be empty.
+func (a *FuriganaAnnotator) Sentence(text, word, reading string) []furigana.Segment {
+	segments := furigana.Annotate(text, a.tokenizer.Tokenize(text), func(token tokenizer.Token) string {
+		if word != "" && token.Surface == word {
+			return reading
+		}
+		return ""
+	})
+	return a.hideMastered(segments)
+}
+
+// hideMastered drops the readings of segments whose kanji are all mastered
+func (a *FuriganaAnnotator) hideMastered(segments []furigana.Segment) []furigana.Segment {
+	if len(a.mastered) == 0 {
+		return segments
+	}
+	for i, s := range segments {
+		if s.Reading != "" && !strings.ContainsFunc(s.Text, func(r rune) bool {
+			return kana.IsKanji(r) && !a.mastered[r]
+		}) {
+			segments[i].Reading = ""
+		}
+	}
+	return segments
+}
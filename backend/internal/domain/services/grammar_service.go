@@ -24,23 +24,28 @@ func NewGrammarService(grammarRepo repository.GrammarRepository, logger *utils.L
 	}
 }
 
-// GetLessonsList retrieves grammar lessons with optional filtering
-func (s *GrammarService) GetLessonsList(ctx context.Context, userID int, jlptLevel *int, page, pageSize int) ([]models.GrammarLessonWithExamples, int, error) {
-	offset := (page - 1) * pageSize
+// GetLessonsList retrieves grammar lessons with optional filtering and sorting,
+// the cursor of the next page and, when withTotal is set, the total count
+func (s *GrammarService) GetLessonsList(ctx context.Context, userID int, filter repository.GrammarFilter, page, pageSize int, withTotal bool) ([]models.GrammarLessonWithExamples, *repository.Cursor, *int, error) {
+	offset := pageOffset(page, pageSize, filter.After)
 
-	lessons, err := s.grammarRepo.GetUserLessonsList(ctx, userID, jlptLevel, pageSize, offset)
+	lessons, next, err := s.grammarRepo.GetUserLessonsList(ctx, userID, filter, pageSize, offset)
 	if err != nil {
 		s.logger.Error("Failed to get grammar lessons", utils.WithContext("error", err.Error()))
-		return nil, 0, pkgErrors.Internal("Failed to retrieve grammar lessons", err)
+		return nil, nil, nil, pkgErrors.Internal("Failed to retrieve grammar lessons", err)
 	}
 
-	total, err := s.grammarRepo.CountLessons(ctx, jlptLevel)
+	if !withTotal {
+		return lessons, next, nil, nil
+	}
+
+	total, err := s.grammarRepo.CountUserLessonsList(ctx, userID, filter)
 	if err != nil {
 		s.logger.Error("Failed to count grammar lessons", utils.WithContext("error", err.Error()))
-		return nil, 0, pkgErrors.Internal("Failed to count grammar lessons", err)
+		return nil, nil, nil, pkgErrors.Internal("Failed to count grammar lessons", err)
 	}
 
-	return lessons, total, nil
+	return lessons, next, &total, nil
 }
 
 // GetLessonByID retrieves a specific grammar lesson with examples
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+const (
+	// importJobsListLimit caps how many jobs ListJobs returns
+	importJobsListLimit = 20
+	// importSaveInterval is how many items are processed between job progress saves
+	importSaveInterval = 100
+)
+
+// ImportService imports vocabulary from external sources and tracks each
+// import as a job whose status clients can poll
+type ImportService struct {
+	jobCtx       context.Context // Context of the imports running in the background
+	importRepo   repository.ImportJobRepository
+	vocabRepo    repository.VocabularyRepository
+	vocabService *VocabularyService
+	logger       *utils.Logger
+}
+
+// NewImportService creates a new import service. Imports started in the
+// background are cancelled when jobCtx is done, e.g. when the server shuts down.
+func NewImportService(
+	jobCtx context.Context,
+	importRepo repository.ImportJobRepository,
+	vocabRepo repository.VocabularyRepository,
+	vocabService *VocabularyService,
+	logger *utils.Logger,
+) *ImportService {
+	return &ImportService{
+		jobCtx:       jobCtx,
+		importRepo:   importRepo,
+		vocabRepo:    vocabRepo,
+		vocabService: vocabService,
+		logger:       logger,
+	}
+}
+
+// GetJob retrieves one of the user's import jobs
+func (s *ImportService) GetJob(ctx context.Context, userID, jobID int) (*models.ImportJob, error) {
+	job, err := s.importRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get import job", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve import job", err)
+	}
+
+	if job.UserID != userID {
+		return nil, pkgErrors.Forbidden("Not authorized to view this import job")
+	}
+
+	return job, nil
+}
+
+// ListJobs retrieves the user's most recent import jobs
+func (s *ImportService) ListJobs(ctx context.Context, userID int) ([]models.ImportJob, error) {
+	jobs, err := s.importRepo.GetUserJobs(ctx, userID, importJobsListLimit)
+	if err != nil {
+		s.logger.Error("Failed to get import jobs", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve import jobs", err)
+	}
+
+	return jobs, nil
+}
+
+// createJob records a pending import job
+func (s *ImportService) createJob(ctx context.Context, userID int, source, fileName string) (*models.ImportJob, error) {
+	job := &models.ImportJob{
+		UserID:   userID,
+		Source:   source,
+		FileName: fileName,
+		Status:   models.ImportStatusPending,
+	}
+
+	if err := s.importRepo.CreateJob(ctx, job); err != nil {
+		s.logger.Error("Failed to create import job", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to create import job", err)
+	}
+
+	return job, nil
+}
+
+// startJob runs an import in the background, on the context of background
+// imports, and returns a copy of the pending job: the import updates job as
+// it runs.
+func (s *ImportService) startJob(job *models.ImportJob, run func(context.Context, *models.ImportJob) error) *models.ImportJob {
+	pending := *job
+	go s.runJob(s.jobCtx, job, func(job *models.ImportJob) error {
+		return run(s.jobCtx, job)
+	})
+	return &pending
+}
+
+// runJob marks a job as running, runs the import and records its outcome.
+// The import reports progress through the job, which is saved periodically
+// by saveProgress. An import that panics fails the job, and the outcome is
+// saved even when ctx is cancelled.
+func (s *ImportService) runJob(ctx context.Context, job *models.ImportJob, run func(*models.ImportJob) error) {
+	now := time.Now()
+	job.Status = models.ImportStatusRunning
+	job.StartedAt = &now
+	s.saveProgress(ctx, job)
+
+	err := func() (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("the import stopped unexpectedly: %v", p)
+			}
+		}()
+		return run(job)
+	}()
+
+	finished := time.Now()
+	job.FinishedAt = &finished
+	if err != nil {
+		message := err.Error()
+		job.Status = models.ImportStatusFailed
+		job.Error = &message
+		s.logger.Error("Import failed", utils.WithContext(
+			"job_id", job.ID,
+			"source", job.Source,
+			"error", message,
+		))
+	} else {
+		job.Status = models.ImportStatusCompleted
+		s.logger.Info("Import completed", utils.WithContext(
+			"job_id", job.ID,
+			"source", job.Source,
+			"created", job.CreatedItems,
+			"matched", job.MatchedItems,
+			"skipped", job.SkippedItems,
+			"failed", job.FailedItems,
+		))
+	}
+
+	s.saveProgress(context.WithoutCancel(ctx), job)
+}
+
+// saveProgress saves a job's status and counters. Failures are logged only:
+// they must not abort the import itself.
+func (s *ImportService) saveProgress(ctx context.Context, job *models.ImportJob) {
+	if err := s.importRepo.UpdateJob(ctx, job); err != nil {
+		s.logger.Warn("Failed to save import job", utils.WithContext(
+			"job_id", job.ID,
+			"error", err.Error(),
+		))
+	}
+}
+
+// importVocabulary saves an imported entry. A public item with the same word
+// and reading is reused as is, the user's own private item is updated with
+// the imported fields and anything else becomes a new private item, so that
+// importing the same file twice changes nothing. Fields the import leaves
+// unset keep their stored values. Entries that fail validation are skipped
+// with a warning and nil is returned.
+func (s *ImportService) importVocabulary(ctx context.Context, job *models.ImportJob, label string, vocab *models.Vocabulary) (*models.Vocabulary, error) {
+	levelSet := vocab.JLPTLevel != 0
+	if err := normalizeVocabulary(vocab); err != nil {
+		return s.skipItem(job, label, vocab.Word, err)
+	}
+
+	existing, err := s.vocabRepo.FindByWordReading(ctx, job.UserID, vocab.Word, vocab.Reading)
+	if err != nil {
+		if appErr, ok := err.(*pkgErrors.AppError); !ok || appErr.Code != pkgErrors.ErrCodeNotFound {
+			return nil, err
+		}
+
+		created, err := s.vocabService.CreatePrivateVocabulary(ctx, job.UserID, vocab)
+		if err != nil {
+			return s.skipItem(job, label, vocab.Word, err)
+		}
+		job.CreatedItems++
+		return created, nil
+	}
+
+	if existing.Visibility != models.VisibilityPrivate || existing.OwnerID == nil || *existing.OwnerID != job.UserID {
+		job.MatchedItems++
+		return existing, nil
+	}
+
+	updated := *existing
+	updated.Meaning = vocab.Meaning
+	if levelSet {
+		updated.JLPTLevel = vocab.JLPTLevel
+	}
+	if vocab.PartOfSpeech != nil {
+		updated.PartOfSpeech = vocab.PartOfSpeech
+	}
+	if vocab.ExampleSentence != nil {
+		updated.ExampleSentence = vocab.ExampleSentence
+	}
+	if vocab.ExampleTranslation != nil {
+		updated.ExampleTranslation = vocab.ExampleTranslation
+	}
+
+	if sameVocabularyContent(existing, &updated) {
+		job.MatchedItems++
+		return existing, nil
+	}
+
+	saved, err := s.vocabService.UpdatePrivateVocabulary(ctx, job.UserID, &updated)
+	if err != nil {
+		return s.skipItem(job, label, vocab.Word, err)
+	}
+	job.UpdatedItems++
+	return saved, nil
+}
+
+// skipItem counts an entry that failed validation as skipped and returns
+// other errors, which count as failures
+func (s *ImportService) skipItem(job *models.ImportJob, label, word string, err error) (*models.Vocabulary, error) {
+	appErr, ok := err.(*pkgErrors.AppError)
+	if !ok || appErr.Code != pkgErrors.ErrCodeValidation {
+		return nil, err
+	}
+
+	if word != "" {
+		label = fmt.Sprintf("%s (%s)", label, word)
+	}
+	job.SkippedItems++
+	job.Warn(fmt.Sprintf("%s: %s", label, appErr.Message))
+	return nil, nil
+}
+
+// sameVocabularyContent reports whether two entries have the same imported fields
+func sameVocabularyContent(a, b *models.Vocabulary) bool {
+	return a.Meaning == b.Meaning &&
+		a.JLPTLevel == b.JLPTLevel &&
+		derefString(a.PartOfSpeech) == derefString(b.PartOfSpeech) &&
+		derefString(a.ExampleSentence) == derefString(b.ExampleSentence) &&
+		derefString(a.ExampleTranslation) == derefString(b.ExampleTranslation)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/numbers"
+)
+
+// MaxNumberDrillCount limits how many number questions are handed out at once
+const MaxNumberDrillCount = 50
+
+// NumberService generates questions on the readings of numbers, counters,
+// dates and clock times, which cannot be stored as vocabulary because of
+// their sound changes. It is also the source of numbers quizzes.
+type NumberService struct {
+	mu        sync.Mutex // Guards generator
+	generator *numbers.Generator
+	logger    *utils.Logger
+}
+
+// NewNumberService creates a new number service
+func NewNumberService(logger *utils.Logger) *NumberService {
+	return &NumberService{
+		generator: numbers.NewGenerator(rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))),
+		logger:    logger,
+	}
+}
+
+// ParseNumberKinds parses question kinds such as "counter,date"
+func ParseNumberKinds(value string) ([]numbers.Kind, error) {
+	var kinds []numbers.Kind
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		kind, err := numbers.ParseKind(part)
+		if err != nil {
+			return nil, pkgErrors.Validation(fmt.Sprintf("Unknown kind %q, expected number, counter, date or time", part))
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// GetDrills generates count questions, each of a random kind among kinds,
+// or among all kinds when kinds is empty
+func (s *NumberService) GetDrills(kinds []numbers.Kind, count int) ([]numbers.Question, error) {
+	if count < 1 || count > MaxNumberDrillCount {
+		return nil, pkgErrors.Validation(fmt.Sprintf("count must be between 1 and %d", MaxNumberDrillCount))
+	}
+	if len(kinds) == 0 {
+		kinds = numbers.Kinds
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	questions := make([]numbers.Question, count)
+	for i := range questions {
+		question, err := s.generator.Question(kinds[rand.IntN(len(kinds))])
+		if err != nil {
+			return nil, pkgErrors.Internal("Failed to generate question", err)
+		}
+		questions[i] = question
+	}
+
+	return questions, nil
+}
+
+// GenerateQuestions generates multiple choice reading questions with
+// distractors built from the typical sound change mistakes. Numbers are N5
+// content, so the level is not used.
+func (s *NumberService) GenerateQuestions(ctx context.Context, userID, jlptLevel, size int) ([]models.QuizQuestion, error) {
+	drills, err := s.GetDrills(nil, size)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	questions := make([]models.QuizQuestion, len(drills))
+	for i, drill := range drills {
+		text := fmt.Sprintf("How is %s read?", drill.Prompt)
+		if drill.Usage != "" {
+			text = fmt.Sprintf("How is %s (%s) read?", drill.Prompt, drill.Usage)
+		}
+
+		explanation := fmt.Sprintf("%s is read %s", drill.Prompt, strings.Join(drill.Answers, " or "))
+		questions[i] = multipleChoiceQuestion(text, drill.Answer(), s.generator.Distractors(drill, 3), explanation)
+	}
+
+	return questions, nil
+}
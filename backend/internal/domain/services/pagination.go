@@ -0,0 +1,12 @@
+package services
+
+import "github.com/joaosantos/jlpt5/internal/domain/repository"
+
+// pageOffset returns the row offset of a page. With a cursor the list continues
+// right after it and the page number is ignored.
+func pageOffset(page, pageSize int, after *repository.Cursor) int {
+	if after != nil || page < 1 {
+		return 0
+	}
+	return (page - 1) * pageSize
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+// Types of generated quizzes
+const (
+	// QuizTypeNumbers - readings of numbers, counters, dates and times
+	QuizTypeNumbers = "numbers"
+	// QuizTypeMeaningToWord - the word of an English meaning
+	QuizTypeMeaningToWord = "meaning_to_word"
+	// QuizTypeReading - the reading of a word written in kanji
+	QuizTypeReading = "reading"
+	// QuizTypeGrammarCloze - the grammar point blanked out of an example sentence
+	QuizTypeGrammarCloze = "grammar_cloze"
+)
+
+const (
+	// MaxGeneratedQuizSize limits how many questions a generated quiz has
+	MaxGeneratedQuizSize = 50
+	// generatedQuizPassingScore is the passing percentage of generated quizzes
+	generatedQuizPassingScore = 70
+)
+
+// optionLetters are the letters of multiple choice options, in order
+var optionLetters = []string{"A", "B", "C", "D"}
+
+// QuizSource generates the questions of on-demand quizzes
+type QuizSource interface {
+	// GenerateQuestions generates up to size questions of a JLPT level for a user
+	GenerateQuestions(ctx context.Context, userID, jlptLevel, size int) ([]models.QuizQuestion, error)
+}
+
+// GenerateQuiz generates a quiz of the given type for a user, saves it as a
+// quiz only that user can see and starts a session of it
+func (s *QuizService) GenerateQuiz(ctx context.Context, userID int, quizType string, jlptLevel, size int) (*QuizWithQuestions, *models.QuizSession, error) {
+	source, ok := s.sources[quizType]
+	if !ok {
+		return nil, nil, pkgErrors.Validation(fmt.Sprintf("Unknown quiz type %q, expected one of: %s",
+			quizType, strings.Join(s.sourceTypes(), ", ")))
+	}
+	if jlptLevel < 1 || jlptLevel > 5 {
+		return nil, nil, pkgErrors.Validation("JLPT level must be between 1 and 5")
+	}
+	if size < 1 || size > MaxGeneratedQuizSize {
+		return nil, nil, pkgErrors.Validation(fmt.Sprintf("Size must be between 1 and %d", MaxGeneratedQuizSize))
+	}
+
+	questions, err := source.GenerateQuestions(ctx, userID, jlptLevel, size)
+	if err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, nil, err
+		}
+		s.logger.Error("Failed to generate quiz questions", utils.WithContext("error", err.Error()))
+		return nil, nil, pkgErrors.Internal("Failed to generate quiz", err)
+	}
+	if len(questions) == 0 {
+		return nil, nil, pkgErrors.BadRequest("Not enough material to generate this quiz")
+	}
+
+	for i := range questions {
+		order := i + 1
+		questions[i].QuestionOrder = &order
+		if questions[i].Points == 0 {
+			questions[i].Points = 1
+		}
+	}
+
+	quiz := &models.Quiz{
+		Title:        fmt.Sprintf("N%d %s practice", jlptLevel, strings.ReplaceAll(quizType, "_", " ")),
+		QuizType:     &quizType,
+		JLPTLevel:    jlptLevel,
+		PassingScore: generatedQuizPassingScore,
+		OwnerID:      &userID,
+	}
+
+	if err := s.quizRepo.CreateQuiz(ctx, quiz, questions); err != nil {
+		s.logger.Error("Failed to create generated quiz", utils.WithContext("error", err.Error()))
+		return nil, nil, pkgErrors.Internal("Failed to create quiz", err)
+	}
+
+	return s.startSession(ctx, userID, quiz, questions)
+}
+
+// sourceTypes returns the generated quiz types, sorted
+func (s *QuizService) sourceTypes() []string {
+	types := make([]string, 0, len(s.sources))
+	for quizType := range s.sources {
+		types = append(types, quizType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// multipleChoiceQuestion builds a multiple choice question with the correct
+// answer and up to three distractors in random order
+func multipleChoiceQuestion(text, correct string, distractors []string, explanation string) models.QuizQuestion {
+	options := append([]string{correct}, distractors...)
+	if len(options) > len(optionLetters) {
+		options = options[:len(optionLetters)]
+	}
+	rand.Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
+
+	question := models.QuizQuestion{
+		QuestionType: models.QuestionTypeMultipleChoice,
+		QuestionText: text,
+		Points:       1,
+	}
+	if explanation != "" {
+		question.Explanation = &explanation
+	}
+
+	slots := []**string{&question.OptionA, &question.OptionB, &question.OptionC, &question.OptionD}
+	for i := range options {
+		option := options[i]
+		*slots[i] = &option
+		if option == correct {
+			question.CorrectAnswer = optionLetters[i]
+		}
+	}
+
+	return question
+}
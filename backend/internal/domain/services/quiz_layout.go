@@ -0,0 +1,149 @@
+package services
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+)
+
+// quizLayout is the order a session shows the questions of a quiz in, and
+// the options of each question in. It is derived from the seed stored with
+// the session, so every view of the session shows the same order.
+type quizLayout struct {
+	order   []int         // Indexes of the questions, in display order
+	options map[int][]int // By question ID, the stored option index of each displayed option
+}
+
+// newQuizLayout lays out the questions of a quiz, in the order the
+// repository returns them, for a session
+func newQuizLayout(quiz *models.Quiz, session *models.QuizSession, questions []models.QuizQuestion) *quizLayout {
+	layout := &quizLayout{
+		order:   make([]int, len(questions)),
+		options: make(map[int][]int),
+	}
+	for i := range layout.order {
+		layout.order[i] = i
+	}
+	if session.ShuffleSeed == nil {
+		return layout
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(*session.ShuffleSeed), uint64(session.ID)))
+	if quiz.ShuffleQuestions {
+		rng.Shuffle(len(layout.order), func(i, j int) {
+			layout.order[i], layout.order[j] = layout.order[j], layout.order[i]
+		})
+	}
+	if quiz.ShuffleOptions {
+		for _, q := range questions {
+			if q.QuestionType != models.QuestionTypeMultipleChoice {
+				continue
+			}
+			present := presentOptions(q)
+			rng.Shuffle(len(present), func(i, j int) {
+				present[i], present[j] = present[j], present[i]
+			})
+			layout.options[q.ID] = present
+		}
+	}
+
+	return layout
+}
+
+// arrange returns the questions as the session shows them: in display
+// order, with their options in display order and the correct answer
+// remapped to the letter of the displayed option
+func (l *quizLayout) arrange(questions []models.QuizQuestion) []models.QuizQuestion {
+	arranged := make([]models.QuizQuestion, len(questions))
+	for i, index := range l.order {
+		q := questions[index]
+		positions, ok := l.options[q.ID]
+		if !ok {
+			arranged[i] = q
+			continue
+		}
+
+		stored := []*string{q.OptionA, q.OptionB, q.OptionC, q.OptionD}
+		slots := []**string{&q.OptionA, &q.OptionB, &q.OptionC, &q.OptionD}
+		for j := range slots {
+			*slots[j] = nil
+		}
+		for j, position := range positions {
+			*slots[j] = stored[position]
+		}
+		q.CorrectAnswer = l.displayedAnswer(q.ID, q.CorrectAnswer)
+		arranged[i] = q
+	}
+	return arranged
+}
+
+// canonicalAnswer maps an answer given as the letter of a displayed option
+// to the letter of that option as stored. Other answers are returned as is.
+func (l *quizLayout) canonicalAnswer(questionID int, answer string) string {
+	positions, ok := l.options[questionID]
+	if !ok {
+		return answer
+	}
+
+	index := letterIndex(answer)
+	if index < 0 || index >= len(positions) {
+		return answer
+	}
+	return optionLetters[positions[index]]
+}
+
+// displayedAnswer maps the letter of a stored option to the letter the
+// session shows it under. Other answers are returned as is.
+func (l *quizLayout) displayedAnswer(questionID int, answer string) string {
+	positions, ok := l.options[questionID]
+	if !ok {
+		return answer
+	}
+
+	index := letterIndex(answer)
+	for displayed, position := range positions {
+		if position == index {
+			return optionLetters[displayed]
+		}
+	}
+	return answer
+}
+
+// displayedAnswers maps the stored answers of a session to the letters the
+// session shows
+func (l *quizLayout) displayedAnswers(answers []models.QuizAnswer) []models.QuizAnswer {
+	displayed := make([]models.QuizAnswer, len(answers))
+	for i, answer := range answers {
+		if answer.UserAnswer != nil {
+			userAnswer := l.displayedAnswer(answer.QuizQuestionID, *answer.UserAnswer)
+			answer.UserAnswer = &userAnswer
+		}
+		displayed[i] = answer
+	}
+	return displayed
+}
+
+// presentOptions returns the indexes of the options a question has
+func presentOptions(q models.QuizQuestion) []int {
+	var present []int
+	for i, option := range []*string{q.OptionA, q.OptionB, q.OptionC, q.OptionD} {
+		if option != nil {
+			present = append(present, i)
+		}
+	}
+	return present
+}
+
+// letterIndex returns the position of an option letter such as "b", or -1
+// when answer is not a letter
+func letterIndex(answer string) int {
+	letter := strings.ToUpper(strings.TrimSpace(kana.NormalizeWidth(answer)))
+	for i, l := range optionLetters {
+		if letter == l {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+)
+
+// layoutQuestions returns multiple choice questions with 4, 3 and 2 options,
+// one with a gap in its options, and a question of another type
+func layoutQuestions() []models.QuizQuestion {
+	option := func(s string) *string { return &s }
+	return []models.QuizQuestion{
+		{ID: 1, QuestionType: models.QuestionTypeMultipleChoice, CorrectAnswer: "C",
+			OptionA: option("いち"), OptionB: option("に"), OptionC: option("さん"), OptionD: option("よん")},
+		{ID: 2, QuestionType: models.QuestionTypeMultipleChoice, CorrectAnswer: "B",
+			OptionA: option("ねこ"), OptionB: option("いぬ"), OptionC: option("とり")},
+		{ID: 3, QuestionType: models.QuestionTypeMultipleChoice, CorrectAnswer: "A",
+			OptionA: option("はい"), OptionB: option("いいえ")},
+		{ID: 4, QuestionType: models.QuestionTypeMultipleChoice, CorrectAnswer: "D",
+			OptionA: option("あさ"), OptionB: option("ひる"), OptionD: option("よる")},
+		{ID: 5, QuestionType: models.QuestionTypeFillInBlank, CorrectAnswer: "たべる"},
+	}
+}
+
+func TestQuizLayoutRoundTrip(t *testing.T) {
+	quiz := &models.Quiz{ShuffleQuestions: true, ShuffleOptions: true}
+	questions := layoutQuestions()
+
+	for seed := int64(1); seed <= 20; seed++ {
+		session := &models.QuizSession{ID: int(seed) * 7, ShuffleSeed: &seed}
+		layout := newQuizLayout(quiz, session, questions)
+
+		for _, q := range questions {
+			for _, index := range presentOptions(q) {
+				letter := optionLetters[index]
+				displayed := layout.displayedAnswer(q.ID, letter)
+				if got := layout.canonicalAnswer(q.ID, displayed); got != letter {
+					t.Errorf("seed %d, question %d: canonicalAnswer(displayedAnswer(%q)) = %q via %q",
+						seed, q.ID, letter, got, displayed)
+				}
+			}
+		}
+	}
+}
+
+func TestQuizLayoutArrange(t *testing.T) {
+	quiz := &models.Quiz{ShuffleQuestions: true, ShuffleOptions: true}
+	questions := layoutQuestions()
+	byID := make(map[int]models.QuizQuestion)
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		session := &models.QuizSession{ID: 42, ShuffleSeed: &seed}
+		layout := newQuizLayout(quiz, session, questions)
+		arranged := layout.arrange(questions)
+
+		if len(arranged) != len(questions) {
+			t.Fatalf("seed %d: arranged %d questions, want %d", seed, len(arranged), len(questions))
+		}
+
+		seen := make(map[int]bool)
+		for _, q := range arranged {
+			if seen[q.ID] {
+				t.Errorf("seed %d: question %d shown twice", seed, q.ID)
+			}
+			seen[q.ID] = true
+
+			stored := byID[q.ID]
+			storedOptions := []*string{stored.OptionA, stored.OptionB, stored.OptionC, stored.OptionD}
+			shownOptions := []*string{q.OptionA, q.OptionB, q.OptionC, q.OptionD}
+
+			// Displayed options are packed from A, each the stored option it stands for
+			shown := 0
+			for i, option := range shownOptions {
+				if option == nil {
+					continue
+				}
+				shown++
+				canonical := letterIndex(layout.canonicalAnswer(q.ID, optionLetters[i]))
+				if canonical < 0 || storedOptions[canonical] == nil || *storedOptions[canonical] != *option {
+					t.Errorf("seed %d, question %d: option %s is %q, not the stored option it maps to",
+						seed, q.ID, optionLetters[i], *option)
+				}
+			}
+			if want := len(presentOptions(stored)); shown != want {
+				t.Errorf("seed %d, question %d: shows %d options, want %d", seed, q.ID, shown, want)
+			}
+			if shown > 0 && shownOptions[shown-1] == nil {
+				t.Errorf("seed %d, question %d: displayed options have a gap", seed, q.ID)
+			}
+
+			// The correct answer points at the same text as stored
+			if q.QuestionType != models.QuestionTypeMultipleChoice {
+				if q.CorrectAnswer != stored.CorrectAnswer {
+					t.Errorf("seed %d, question %d: answer %q changed to %q", seed, q.ID, stored.CorrectAnswer, q.CorrectAnswer)
+				}
+				continue
+			}
+			correct := *shownOptions[letterIndex(q.CorrectAnswer)]
+			if want := *storedOptions[letterIndex(stored.CorrectAnswer)]; correct != want {
+				t.Errorf("seed %d, question %d: correct answer shows %q, want %q", seed, q.ID, correct, want)
+			}
+		}
+	}
+}
+
+func TestQuizLayoutDeterministic(t *testing.T) {
+	quiz := &models.Quiz{ShuffleQuestions: true, ShuffleOptions: true}
+	questions := layoutQuestions()
+	seed := int64(12345)
+	session := &models.QuizSession{ID: 9, ShuffleSeed: &seed}
+
+	first := newQuizLayout(quiz, session, questions).arrange(questions)
+	second := newQuizLayout(quiz, session, questions).arrange(questions)
+	for i := range first {
+		if first[i].ID != second[i].ID || first[i].CorrectAnswer != second[i].CorrectAnswer {
+			t.Fatalf("the same seed gave different layouts at position %d", i)
+		}
+	}
+}
+
+func TestQuizLayoutWithoutSeed(t *testing.T) {
+	quiz := &models.Quiz{ShuffleQuestions: true, ShuffleOptions: true}
+	questions := layoutQuestions()
+	layout := newQuizLayout(quiz, &models.QuizSession{ID: 1}, questions)
+
+	for i, q := range layout.arrange(questions) {
+		if q.ID != questions[i].ID || q.CorrectAnswer != questions[i].CorrectAnswer {
+			t.Errorf("position %d: got question %d answer %q, want the stored order", i, q.ID, q.CorrectAnswer)
+		}
+	}
+	if got := layout.canonicalAnswer(1, "b"); got != "b" {
+		t.Errorf("canonicalAnswer without a layout = %q, want the answer as given", got)
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"math"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+)
+
+// SectionScore is the score of the questions of one section of a session
+type SectionScore struct {
+	Section     *models.QuizSection // nil for the questions outside any section
+	Score       float64
+	TotalPoints int
+	Percentage  float64
+}
+
+// sessionScore is the score of a session under the scoring policy of its quiz
+type sessionScore struct {
+	score       float64
+	totalPoints int
+	percentage  float64
+	sections    []SectionScore
+}
+
+// answerPoints returns the points an answer earns under the scoring policy
+// of a quiz: the points of the question when correct, the partial credit
+// share of them for a near miss and minus the penalty share of them when
+// wrong. Unanswered questions earn nothing.
+func answerPoints(quiz *models.Quiz, question models.QuizQuestion, answered bool, verdict AnswerVerdict) float64 {
+	if !answered {
+		return 0
+	}
+
+	points := float64(question.Points)
+	switch verdict {
+	case AnswerCorrect:
+		return points
+	case AnswerAlmost:
+		if quiz.PartialCredit > 0 {
+			return roundPoints(points * quiz.PartialCredit)
+		}
+	}
+	return roundPoints(-points * quiz.WrongAnswerPenalty)
+}
+
+// scoreSession totals the points awarded to the answers of a session. Every
+// question counts toward the total, answered or not, and negative marking
+// never takes a score below zero. When the quiz has sections, the
+// percentage is the mean of the percentages of its sections weighted by
+// their weight, the questions outside any section weighing 1.
+func scoreSession(sections []models.QuizSection, questions []models.QuizQuestion, answers []models.QuizAnswer) sessionScore {
+	awarded := make(map[int]float64, len(answers))
+	for _, answer := range answers {
+		if answer.PointsAwarded != nil {
+			awarded[answer.QuizQuestionID] = *answer.PointsAwarded
+		}
+	}
+
+	index := make(map[int]int, len(sections))
+	for i, section := range sections {
+		index[section.ID] = i
+	}
+
+	// The questions outside any section are scored last
+	groups := make([]SectionScore, len(sections)+1)
+	for i := range sections {
+		groups[i].Section = &sections[i]
+	}
+	unsectioned := len(sections)
+
+	var result sessionScore
+	for _, q := range questions {
+		group := unsectioned
+		if q.SectionID != nil {
+			if i, ok := index[*q.SectionID]; ok {
+				group = i
+			}
+		}
+
+		groups[group].Score += awarded[q.ID]
+		groups[group].TotalPoints += q.Points
+		result.score += awarded[q.ID]
+		result.totalPoints += q.Points
+	}
+
+	var weighted, weights float64
+	for i := range groups {
+		group := &groups[i]
+		group.Score = roundPoints(group.Score)
+		group.Percentage = percentageOf(group.Score, group.TotalPoints)
+
+		weight := 1.0
+		if group.Section != nil {
+			weight = group.Section.Weight
+		}
+		if group.TotalPoints > 0 {
+			weighted += weight * group.Percentage
+			weights += weight
+		}
+	}
+
+	result.score = roundPoints(math.Max(result.score, 0))
+	result.percentage = percentageOf(result.score, result.totalPoints)
+	if len(sections) > 0 {
+		result.percentage = 0
+		if weights > 0 {
+			result.percentage = weighted / weights
+		}
+		result.sections = groups
+		if groups[unsectioned].TotalPoints == 0 {
+			result.sections = groups[:unsectioned]
+		}
+	}
+
+	return result
+}
+
+// percentageOf returns score as a percentage of totalPoints, from 0 to 100
+func percentageOf(score float64, totalPoints int) float64 {
+	if totalPoints <= 0 || score <= 0 {
+		return 0
+	}
+	return score / float64(totalPoints) * 100
+}
+
+// roundPoints rounds points to the hundredths stored with answers and sessions
+func roundPoints(points float64) float64 {
+	return math.Round(points*100) / 100
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+)
+
+func TestAnswerPoints(t *testing.T) {
+	tests := []struct {
+		name          string
+		penalty       float64
+		partialCredit float64
+		points        int
+		answered      bool
+		verdict       AnswerVerdict
+		want          float64
+	}{
+		{"correct", 0, 0, 2, true, AnswerCorrect, 2},
+		{"correct with a policy", 0.25, 0.5, 2, true, AnswerCorrect, 2},
+		{"wrong without penalty", 0, 0, 2, true, AnswerIncorrect, 0},
+		{"wrong with penalty", 0.25, 0, 2, true, AnswerIncorrect, -0.5},
+		{"wrong penalty is rounded", 0.33, 0, 1, true, AnswerIncorrect, -0.33},
+		{"near miss with partial credit", 0, 0.5, 3, true, AnswerAlmost, 1.5},
+		{"near miss with partial credit and penalty", 0.25, 0.5, 3, true, AnswerAlmost, 1.5},
+		{"near miss without partial credit", 0, 0, 3, true, AnswerAlmost, 0},
+		{"near miss without partial credit is wrong", 0.25, 0, 1, true, AnswerAlmost, -0.25},
+		{"unanswered", 0, 0, 2, false, AnswerIncorrect, 0},
+		{"unanswered is never penalized", 1, 0.5, 2, false, AnswerIncorrect, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &models.Quiz{WrongAnswerPenalty: tt.penalty, PartialCredit: tt.partialCredit}
+			question := models.QuizQuestion{ID: 1, Points: tt.points}
+			if got := answerPoints(quiz, question, tt.answered, tt.verdict); got != tt.want {
+				t.Errorf("answerPoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreSession(t *testing.T) {
+	sectionID := func(id int) *int { return &id }
+	questions := []models.QuizQuestion{
+		{ID: 1, Points: 1},
+		{ID: 2, Points: 1},
+		{ID: 3, Points: 2},
+	}
+
+	tests := []struct {
+		name        string
+		questions   []models.QuizQuestion
+		awarded     map[int]float64 // Points awarded by question ID
+		score       float64
+		totalPoints int
+		percentage  float64
+	}{
+		{"empty submission", questions, nil, 0, 4, 0},
+		{"no questions", nil, nil, 0, 0, 0},
+		{"all correct", questions, map[int]float64{1: 1, 2: 1, 3: 2}, 4, 4, 100},
+		{"unanswered questions count toward the total", questions, map[int]float64{3: 2}, 2, 4, 50},
+		{"negative marking", questions, map[int]float64{1: 1, 2: -0.25, 3: 2}, 2.75, 4, 68.75},
+		{"negative marking stops at zero", questions, map[int]float64{1: -0.5, 2: -0.5, 3: 0}, 0, 4, 0},
+		{"partial credit", questions, map[int]float64{1: 0.5, 2: 1}, 1.5, 4, 37.5},
+		{"partial credit and negative marking", questions, map[int]float64{1: 0.5, 2: -0.25, 3: 1}, 1.25, 4, 31.25},
+		{
+			"unknown section counts as outside any section",
+			[]models.QuizQuestion{{ID: 1, Points: 1, SectionID: sectionID(99)}},
+			map[int]float64{1: 1}, 1, 1, 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := scoreSession(nil, tt.questions, awardedAnswers(tt.awarded))
+			if result.score != tt.score || result.totalPoints != tt.totalPoints || !closeTo(result.percentage, tt.percentage) {
+				t.Errorf("scoreSession() = score %v, total %d, %v%%, want score %v, total %d, %v%%",
+					result.score, result.totalPoints, result.percentage, tt.score, tt.totalPoints, tt.percentage)
+			}
+			if result.sections != nil {
+				t.Errorf("scoreSession() without sections returned sections %v", result.sections)
+			}
+		})
+	}
+}
+
+func TestScoreSessionSections(t *testing.T) {
+	sectionID := func(id int) *int { return &id }
+	sections := []models.QuizSection{
+		{ID: 10, Name: "Vocabulary", Weight: 2},
+		{ID: 20, Name: "Grammar", Weight: 1},
+		{ID: 30, Name: "Reading", Weight: 5}, // No questions, so it does not count
+	}
+
+	tests := []struct {
+		name       string
+		questions  []models.QuizQuestion
+		awarded    map[int]float64
+		score      float64
+		percentage float64
+		sections   []float64 // Percentage of each section, then of the questions outside any
+	}{
+		{
+			name: "weighted sections",
+			questions: []models.QuizQuestion{
+				{ID: 1, Points: 1, SectionID: sectionID(10)},
+				{ID: 2, Points: 1, SectionID: sectionID(10)},
+				{ID: 3, Points: 2, SectionID: sectionID(20)},
+			},
+			awarded:    map[int]float64{1: 1, 2: 1},
+			score:      2,
+			percentage: (2*100 + 1*0) / 3.0,
+			sections:   []float64{100, 0, 0},
+		},
+		{
+			name: "questions outside any section weigh 1",
+			questions: []models.QuizQuestion{
+				{ID: 1, Points: 1, SectionID: sectionID(10)},
+				{ID: 2, Points: 2, SectionID: sectionID(20)},
+				{ID: 3, Points: 4},
+			},
+			awarded:    map[int]float64{2: 1, 3: 4},
+			score:      5,
+			percentage: (2*0 + 1*50 + 1*100) / 4.0,
+			sections:   []float64{0, 50, 0, 100},
+		},
+		{
+			name: "negative section scores count as zero",
+			questions: []models.QuizQuestion{
+				{ID: 1, Points: 1, SectionID: sectionID(10)},
+				{ID: 2, Points: 1, SectionID: sectionID(20)},
+			},
+			awarded:    map[int]float64{1: -0.5, 2: 1},
+			score:      0.5,
+			percentage: (2*0 + 1*100) / 3.0,
+			sections:   []float64{0, 100, 0},
+		},
+		{
+			name: "empty submission",
+			questions: []models.QuizQuestion{
+				{ID: 1, Points: 1, SectionID: sectionID(10)},
+				{ID: 2, Points: 1, SectionID: sectionID(20)},
+			},
+			score:      0,
+			percentage: 0,
+			sections:   []float64{0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := scoreSession(sections, tt.questions, awardedAnswers(tt.awarded))
+			if result.score != tt.score || !closeTo(result.percentage, tt.percentage) {
+				t.Errorf("scoreSession() = score %v, %v%%, want score %v, %v%%",
+					result.score, result.percentage, tt.score, tt.percentage)
+			}
+
+			if len(result.sections) != len(tt.sections) {
+				t.Fatalf("scoreSession() returned %d sections, want %d", len(result.sections), len(tt.sections))
+			}
+			for i, want := range tt.sections {
+				if got := result.sections[i].Percentage; !closeTo(got, want) {
+					t.Errorf("section %d: %v%%, want %v%%", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// awardedAnswers builds the scored answers of a session
+func awardedAnswers(awarded map[int]float64) []models.QuizAnswer {
+	var answers []models.QuizAnswer
+	for questionID, points := range awarded {
+		answers = append(answers, models.QuizAnswer{QuizQuestionID: questionID, PointsAwarded: &points})
+	}
+	return answers
+}
+
+// closeTo reports whether two percentages are equal up to rounding
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"math/rand/v2"
 	"time"
 
 	"github.com/joaosantos/jlpt5/internal/domain/models"
@@ -10,17 +11,39 @@ import (
 	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
 )
 
+const (
+	// expiredSessionBatchSize limits how many expired sessions one sweep closes
+	expiredSessionBatchSize = 100
+	// staleQuizBatchSize limits how many stale generated quizzes one sweep deletes
+	staleQuizBatchSize = 100
+)
+
 // QuizService handles quiz business logic
 type QuizService struct {
-	quizRepo repository.QuizRepository
-	logger   *utils.Logger
+	quizRepo      repository.QuizRepository
+	answerChecker *AnswerChecker
+	sources       map[string]QuizSource // Generated quiz sources by quiz type
+	gracePeriod   time.Duration         // Time allowed past the deadline of timed quizzes
+	retention     time.Duration         // How long generated quizzes are kept
+	logger        *utils.Logger
 }
 
 // NewQuizService creates a new quiz service
-func NewQuizService(quizRepo repository.QuizRepository, logger *utils.Logger) *QuizService {
+func NewQuizService(
+	quizRepo repository.QuizRepository,
+	answerChecker *AnswerChecker,
+	sources map[string]QuizSource,
+	gracePeriod time.Duration,
+	retention time.Duration,
+	logger *utils.Logger,
+) *QuizService {
 	return &QuizService{
-		quizRepo: quizRepo,
-		logger:   logger,
+		quizRepo:      quizRepo,
+		answerChecker: answerChecker,
+		sources:       sources,
+		gracePeriod:   gracePeriod,
+		retention:     retention,
+		logger:        logger,
 	}
 }
 
@@ -36,6 +59,7 @@ type QuizSessionResult struct {
 	Quiz      models.Quiz
 	Questions []models.QuizQuestion
 	Answers   []models.QuizAnswer
+	Sections  []SectionScore // Empty when the quiz has no sections
 }
 
 // GetQuizzesList retrieves quizzes with optional filtering
@@ -57,15 +81,25 @@ func (s *QuizService) GetQuizzesList(ctx context.Context, jlptLevel *int, page,
 	return quizzes, total, nil
 }
 
-// GetQuizByID retrieves a quiz by ID
-func (s *QuizService) GetQuizByID(ctx context.Context, quizID int) (*models.Quiz, error) {
-	return s.quizRepo.GetQuizByID(ctx, quizID)
+// GetQuizByID retrieves a quiz by ID, reporting other users' generated
+// quizzes as not found
+func (s *QuizService) GetQuizByID(ctx context.Context, userID, quizID int) (*models.Quiz, error) {
+	quiz, err := s.quizRepo.GetQuizByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !quiz.IsVisibleTo(userID) {
+		return nil, pkgErrors.NotFound("Quiz not found")
+	}
+
+	return quiz, nil
 }
 
 // StartQuizSession starts a new quiz session
 func (s *QuizService) StartQuizSession(ctx context.Context, userID, quizID int) (*QuizWithQuestions, *models.QuizSession, error) {
 	// Verify quiz exists
-	quiz, err := s.quizRepo.GetQuizByID(ctx, quizID)
+	quiz, err := s.GetQuizByID(ctx, userID, quizID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -81,13 +115,26 @@ func (s *QuizService) StartQuizSession(ctx context.Context, userID, quizID int)
 		return nil, nil, pkgErrors.BadRequest("Quiz has no questions")
 	}
 
+	return s.startSession(ctx, userID, quiz, questions)
+}
+
+// startSession creates a session of a quiz for a user
+func (s *QuizService) startSession(ctx context.Context, userID int, quiz *models.Quiz, questions []models.QuizQuestion) (*QuizWithQuestions, *models.QuizSession, error) {
 	// Create quiz session
 	now := time.Now()
 	session := &models.QuizSession{
 		UserID:    userID,
-		QuizID:    quizID,
+		QuizID:    quiz.ID,
 		StartedAt: now,
 	}
+	if quiz.IsShuffled() {
+		seed := rand.Int64()
+		session.ShuffleSeed = &seed
+	}
+	if quiz.TimeLimitMinutes != nil && *quiz.TimeLimitMinutes > 0 {
+		deadline := now.Add(time.Duration(*quiz.TimeLimitMinutes) * time.Minute)
+		session.Deadline = &deadline
+	}
 
 	if err := s.quizRepo.CreateQuizSession(ctx, session); err != nil {
 		s.logger.Error("Failed to create quiz session", utils.WithContext("error", err.Error()))
@@ -96,133 +143,460 @@ func (s *QuizService) StartQuizSession(ctx context.Context, userID, quizID int)
 
 	quizWithQuestions := &QuizWithQuestions{
 		Quiz:      *quiz,
-		Questions: questions,
+		Questions: newQuizLayout(quiz, session, questions).arrange(questions),
 	}
 
-	s.logger.Info("Quiz session started", utils.WithContext("user_id", userID, "quiz_id", quizID, "session_id", session.ID))
+	s.logger.Info("Quiz session started", utils.WithContext("user_id", userID, "quiz_id", quiz.ID, "session_id", session.ID))
 	return quizWithQuestions, session, nil
 }
 
-// SubmitQuizAnswers submits answers for a quiz session and calculates the score
-func (s *QuizService) SubmitQuizAnswers(ctx context.Context, userID, sessionID int, answers map[int]string) (*QuizSessionResult, error) {
-	// Get session
+// sessionFunc works on a session locked for update, with its quiz and
+// questions, through a repository working within the transaction
+type sessionFunc func(repo repository.QuizRepository, session *models.QuizSession, quiz *models.Quiz, questions []models.QuizQuestion) error
+
+// lockedSession runs fn in a transaction holding a lock on a session of a
+// user, so that answers, submits and the sweeper never interleave on it
+func (s *QuizService) lockedSession(ctx context.Context, userID, sessionID int, fn sessionFunc) error {
 	session, err := s.quizRepo.GetQuizSession(ctx, sessionID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Verify session belongs to user
 	if session.UserID != userID {
-		return nil, pkgErrors.Forbidden("Not authorized to submit answers for this session")
+		return pkgErrors.Forbidden("Not authorized to answer this session")
 	}
 
-	// Verify session is not already completed
-	if session.CompletedAt != nil {
-		return nil, pkgErrors.BadRequest("Quiz session already completed")
-	}
-
-	// Get quiz and questions
 	quiz, err := s.quizRepo.GetQuizByID(ctx, session.QuizID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	questions, err := s.quizRepo.GetQuizQuestions(ctx, session.QuizID)
 	if err != nil {
 		s.logger.Error("Failed to get quiz questions", utils.WithContext("error", err.Error()))
-		return nil, pkgErrors.Internal("Failed to retrieve quiz questions", err)
+		return pkgErrors.Internal("Failed to retrieve quiz questions", err)
 	}
 
-	// Create a map of question ID to question for easy lookup
-	questionMap := make(map[int]models.QuizQuestion)
-	for _, q := range questions {
-		questionMap[q.ID] = q
+	err = s.quizRepo.WithLockedQuizSession(ctx, sessionID, func(repo repository.QuizRepository, locked *models.QuizSession) error {
+		return fn(repo, locked, quiz, questions)
+	})
+	return s.sessionError(err)
+}
+
+// sessionError passes application errors through and reports others, such
+// as a failure to lock the session, as internal errors
+func (s *QuizService) sessionError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if appErr, ok := err.(*pkgErrors.AppError); ok {
+		return appErr
 	}
+	s.logger.Error("Failed to lock quiz session", utils.WithContext("error", err.Error()))
+	return pkgErrors.Internal("Failed to update quiz session", err)
+}
 
-	// Score the answers
-	now := time.Now()
-	var totalPoints int
-	var earnedPoints int
-	var submittedAnswers []models.QuizAnswer
-
-	for questionID, userAnswer := range answers {
-		question, exists := questionMap[questionID]
-		if !exists {
-			s.logger.Warn("Answer submitted for non-existent question", utils.WithContext("question_id", questionID))
-			continue
+// sessionInProgress runs fn on a session of a user that is still in
+// progress, holding a lock on it. A session whose time is up is closed
+// instead and reported as a conflict. A session completed by a submit
+// carrying submitKey is passed to fn as well, so that a retried submit can
+// return its result.
+func (s *QuizService) sessionInProgress(ctx context.Context, userID, sessionID int, submitKey string, fn sessionFunc) error {
+	expired := false
+	err := s.lockedSession(ctx, userID, sessionID, func(repo repository.QuizRepository, session *models.QuizSession, quiz *models.Quiz, questions []models.QuizQuestion) error {
+		if session.CompletedAt != nil {
+			if session.WasSubmittedWith(submitKey) {
+				return fn(repo, session, quiz, questions)
+			}
+			return pkgErrors.BadRequest("Quiz session already completed")
 		}
 
-		isCorrect := userAnswer == question.CorrectAnswer
-		if isCorrect {
-			earnedPoints += question.Points
+		// Late answers close the session with the answers saved in time
+		if session.IsExpired(time.Now(), s.gracePeriod) {
+			expired = true
+			return s.closeExpiredSession(ctx, repo, quiz, session, questions)
 		}
-		totalPoints += question.Points
 
-		isCorrectPtr := &isCorrect
-		userAnswerPtr := &userAnswer
+		return fn(repo, session, quiz, questions)
+	})
+	if err != nil {
+		return err
+	}
+
+	if expired {
+		return pkgErrors.Conflict("Time limit exceeded, the quiz session was closed")
+	}
+	return nil
+}
+
+// saveAnswer checks an answer given as the session shows the question and
+// saves it, replacing any answer saved before
+func (s *QuizService) saveAnswer(ctx context.Context, repo repository.QuizRepository, session *models.QuizSession, layout *quizLayout, question models.QuizQuestion, userAnswer string, now time.Time) (*models.QuizAnswer, error) {
+	userAnswer = layout.canonicalAnswer(question.ID, userAnswer)
+	isCorrect := s.answerChecker.CheckQuizAnswer(userAnswer, question).IsCorrect()
+
+	answer := &models.QuizAnswer{
+		QuizSessionID:  session.ID,
+		QuizQuestionID: question.ID,
+		UserAnswer:     &userAnswer,
+		IsCorrect:      &isCorrect,
+		AnsweredAt:     now,
+	}
 
-		answer := models.QuizAnswer{
-			QuizSessionID:  sessionID,
-			QuizQuestionID: questionID,
-			UserAnswer:     userAnswerPtr,
-			IsCorrect:      isCorrectPtr,
-			AnsweredAt:     now,
+	if err := repo.SaveQuizAnswer(ctx, answer); err != nil {
+		s.logger.Error("Failed to save quiz answer", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to save quiz answer", err)
+	}
+
+	return answer, nil
+}
+
+// SaveQuizAnswer saves the answer to one question of a session in progress,
+// so that the attempt survives a reload. The answer is returned as the
+// session shows it, without revealing whether it is correct.
+func (s *QuizService) SaveQuizAnswer(ctx context.Context, userID, sessionID, questionID int, userAnswer string) (*models.QuizAnswer, error) {
+	var displayed models.QuizAnswer
+	err := s.sessionInProgress(ctx, userID, sessionID, "", func(repo repository.QuizRepository, session *models.QuizSession, quiz *models.Quiz, questions []models.QuizQuestion) error {
+		var question *models.QuizQuestion
+		for i := range questions {
+			if questions[i].ID == questionID {
+				question = &questions[i]
+				break
+			}
+		}
+		if question == nil {
+			return pkgErrors.NotFound("Question not found in this quiz")
 		}
 
-		if err := s.quizRepo.CreateQuizAnswer(ctx, &answer); err != nil {
-			s.logger.Error("Failed to create quiz answer", utils.WithContext("error", err.Error()))
-			return nil, pkgErrors.Internal("Failed to save quiz answer", err)
+		layout := newQuizLayout(quiz, session, questions)
+		answer, err := s.saveAnswer(ctx, repo, session, layout, *question, userAnswer, time.Now())
+		if err != nil {
+			return err
 		}
 
-		submittedAnswers = append(submittedAnswers, answer)
+		displayed = layout.displayedAnswers([]models.QuizAnswer{*answer})[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	displayed.IsCorrect = nil
+	return &displayed, nil
+}
+
+// ResumeQuizSession returns a session in progress as it was shown when it
+// started, with the answers saved so far as the session shows them
+func (s *QuizService) ResumeQuizSession(ctx context.Context, userID, sessionID int) (*QuizWithQuestions, *models.QuizSession, []models.QuizAnswer, error) {
+	var quizWithQuestions *QuizWithQuestions
+	var resumed *models.QuizSession
+	var answers []models.QuizAnswer
+	err := s.sessionInProgress(ctx, userID, sessionID, "", func(repo repository.QuizRepository, session *models.QuizSession, quiz *models.Quiz, questions []models.QuizQuestion) error {
+		saved, err := repo.GetSessionAnswers(ctx, sessionID)
+		if err != nil {
+			s.logger.Error("Failed to get session answers", utils.WithContext("error", err.Error()))
+			return pkgErrors.Internal("Failed to retrieve session answers", err)
+		}
+
+		layout := newQuizLayout(quiz, session, questions)
+		answers = layout.displayedAnswers(saved)
+		for i := range answers {
+			answers[i].IsCorrect = nil
+		}
+
+		quizWithQuestions = &QuizWithQuestions{
+			Quiz:      *quiz,
+			Questions: layout.arrange(questions),
+		}
+		resumed = session
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	// Calculate final score and percentage
-	var score int
-	var percentage float64
-	if totalPoints > 0 {
-		score = earnedPoints
-		percentage = (float64(earnedPoints) / float64(totalPoints)) * 100
+	return quizWithQuestions, resumed, answers, nil
+}
+
+// SubmitQuizAnswers saves the submitted answers, then completes the session
+// and scores every answer saved to it, in one transaction. A submit retried
+// with the idempotency key of the submit that completed the session returns
+// the result of that submit.
+func (s *QuizService) SubmitQuizAnswers(ctx context.Context, userID, sessionID int, answers map[int]string, submitKey string) (*QuizSessionResult, error) {
+	var result *QuizSessionResult
+	replayed := false
+	err := s.sessionInProgress(ctx, userID, sessionID, submitKey, func(repo repository.QuizRepository, session *models.QuizSession, quiz *models.Quiz, questions []models.QuizQuestion) error {
+		// Answers refer to the options as the session shows them
+		layout := newQuizLayout(quiz, session, questions)
+
+		now := time.Now()
+		replayed = session.CompletedAt != nil
+		if !replayed {
+			// Create a map of question ID to question for easy lookup
+			questionMap := make(map[int]models.QuizQuestion)
+			for _, q := range questions {
+				questionMap[q.ID] = q
+			}
+
+			for questionID, userAnswer := range answers {
+				question, exists := questionMap[questionID]
+				if !exists {
+					s.logger.Warn("Answer submitted for non-existent question", utils.WithContext("question_id", questionID))
+					continue
+				}
+
+				if _, err := s.saveAnswer(ctx, repo, session, layout, question, userAnswer, now); err != nil {
+					return err
+				}
+			}
+		}
+
+		saved, err := repo.GetSessionAnswers(ctx, sessionID)
+		if err != nil {
+			s.logger.Error("Failed to get session answers", utils.WithContext("error", err.Error()))
+			return pkgErrors.Internal("Failed to retrieve session answers", err)
+		}
+
+		if !replayed {
+			if submitKey != "" {
+				session.SubmitKey = &submitKey
+			}
+			saved, err = s.completeSession(ctx, repo, quiz, session, questions, saved, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err = s.sessionResult(ctx, repo, quiz, session, questions, saved)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Determine if passed
-	passed := percentage >= float64(quiz.PassingScore)
+	if replayed {
+		s.logger.Info("Quiz submit replayed", utils.WithContext("user_id", userID, "session_id", sessionID))
+		return result, nil
+	}
 
-	// Calculate time spent
-	timeSpent := int(now.Sub(session.StartedAt).Seconds())
+	s.logger.Info("Quiz session completed", utils.WithContext(
+		"user_id", userID,
+		"session_id", sessionID,
+		"score", *result.Session.Score,
+		"passed", *result.Session.Passed,
+	))
+
+	return result, nil
+}
+
+// completeSession scores the answers of a session under the scoring policy
+// of its quiz and marks it completed at now. Questions left unanswered are
+// recorded as such and count toward the total. Sessions completed after
+// their deadline count the time up to it. The scored answers are returned
+// in question order.
+func (s *QuizService) completeSession(ctx context.Context, repo repository.QuizRepository, quiz *models.Quiz, session *models.QuizSession, questions []models.QuizQuestion, answers []models.QuizAnswer, now time.Time) ([]models.QuizAnswer, error) {
+	sections, err := repo.GetQuizSections(ctx, quiz.ID)
+	if err != nil {
+		s.logger.Error("Failed to get quiz sections", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve quiz sections", err)
+	}
+
+	saved := make(map[int]models.QuizAnswer, len(answers))
+	for _, answer := range answers {
+		saved[answer.QuizQuestionID] = answer
+	}
+
+	scored := make([]models.QuizAnswer, 0, len(questions))
+	for _, q := range questions {
+		answer, ok := saved[q.ID]
+		if !ok {
+			answer = models.QuizAnswer{
+				QuizSessionID:  session.ID,
+				QuizQuestionID: q.ID,
+				AnsweredAt:     now,
+			}
+		}
+
+		verdict := AnswerIncorrect
+		if answer.IsAnswered() {
+			verdict = s.answerChecker.CheckQuizAnswer(*answer.UserAnswer, q).Verdict
+		}
+		isCorrect := verdict == AnswerCorrect
+		points := answerPoints(quiz, q, answer.IsAnswered(), verdict)
+		answer.IsCorrect = &isCorrect
+		answer.PointsAwarded = &points
+
+		if err := repo.SaveQuizAnswer(ctx, &answer); err != nil {
+			s.logger.Error("Failed to save quiz answer", utils.WithContext("error", err.Error()))
+			return nil, pkgErrors.Internal("Failed to save quiz answer", err)
+		}
+		scored = append(scored, answer)
+	}
+
+	result := scoreSession(sections, questions, scored)
+	passed := result.percentage >= float64(quiz.PassingScore)
+
+	end := now
+	if session.Deadline != nil && end.After(*session.Deadline) {
+		end = *session.Deadline
+	}
+	timeSpent := int(end.Sub(session.StartedAt).Seconds())
 
-	// Update session
 	session.CompletedAt = &now
-	session.Score = &score
-	session.TotalPoints = &totalPoints
-	percentagePtr := &percentage
-	session.Percentage = percentagePtr
+	session.Score = &result.score
+	session.TotalPoints = &result.totalPoints
+	session.Percentage = &result.percentage
 	session.Passed = &passed
 	session.TimeSpentSeconds = &timeSpent
 
-	if err := s.quizRepo.UpdateQuizSession(ctx, session); err != nil {
+	if err := repo.UpdateQuizSession(ctx, session); err != nil {
 		s.logger.Error("Failed to update quiz session", utils.WithContext("error", err.Error()))
 		return nil, pkgErrors.Internal("Failed to update quiz session", err)
 	}
 
-	result := &QuizSessionResult{
+	return scored, nil
+}
+
+// sessionResult builds the result of a completed session as the session
+// shows it, with the score of each section of the quiz
+func (s *QuizService) sessionResult(ctx context.Context, repo repository.QuizRepository, quiz *models.Quiz, session *models.QuizSession, questions []models.QuizQuestion, answers []models.QuizAnswer) (*QuizSessionResult, error) {
+	sections, err := repo.GetQuizSections(ctx, quiz.ID)
+	if err != nil {
+		s.logger.Error("Failed to get quiz sections", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve quiz sections", err)
+	}
+
+	layout := newQuizLayout(quiz, session, questions)
+	return &QuizSessionResult{
 		Session:   *session,
 		Quiz:      *quiz,
-		Questions: questions,
-		Answers:   submittedAnswers,
+		Questions: layout.arrange(questions),
+		Answers:   layout.displayedAnswers(answers),
+		Sections:  scoreSession(sections, questions, answers).sections,
+	}, nil
+}
+
+// closeExpiredSession completes a locked session whose time is up with the
+// answers saved before its deadline
+func (s *QuizService) closeExpiredSession(ctx context.Context, repo repository.QuizRepository, quiz *models.Quiz, session *models.QuizSession, questions []models.QuizQuestion) error {
+	answers, err := repo.GetSessionAnswers(ctx, session.ID)
+	if err != nil {
+		s.logger.Error("Failed to get session answers", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to retrieve session answers", err)
 	}
 
-	s.logger.Info("Quiz session completed", utils.WithContext(
-		"user_id", userID,
-		"session_id", sessionID,
-		"score", score,
-		"passed", passed,
+	if _, err := s.completeSession(ctx, repo, quiz, session, questions, answers, time.Now()); err != nil {
+		return err
+	}
+
+	s.logger.Info("Expired quiz session closed", utils.WithContext(
+		"user_id", session.UserID,
+		"session_id", session.ID,
+		"score", *session.Score,
 	))
+	return nil
+}
 
-	return result, nil
+// CloseExpiredSessions closes the sessions whose time limit and grace
+// period are over, and returns how many were closed
+func (s *QuizService) CloseExpiredSessions(ctx context.Context) (int, error) {
+	sessions, err := s.quizRepo.GetExpiredQuizSessions(ctx, time.Now().Add(-s.gracePeriod), expiredSessionBatchSize)
+	if err != nil {
+		s.logger.Error("Failed to get expired quiz sessions", utils.WithContext("error", err.Error()))
+		return 0, pkgErrors.Internal("Failed to retrieve expired quiz sessions", err)
+	}
+
+	type quizQuestions struct {
+		quiz      *models.Quiz
+		questions []models.QuizQuestion
+	}
+	quizzes := make(map[int]quizQuestions)
+
+	closed := 0
+	for i := range sessions {
+		session := &sessions[i]
+
+		loaded, ok := quizzes[session.QuizID]
+		if !ok {
+			quiz, err := s.quizRepo.GetQuizByID(ctx, session.QuizID)
+			if err != nil {
+				return closed, err
+			}
+			questions, err := s.quizRepo.GetQuizQuestions(ctx, session.QuizID)
+			if err != nil {
+				s.logger.Error("Failed to get quiz questions", utils.WithContext("error", err.Error()))
+				return closed, pkgErrors.Internal("Failed to retrieve quiz questions", err)
+			}
+			loaded = quizQuestions{quiz, questions}
+			quizzes[session.QuizID] = loaded
+		}
+
+		err := s.quizRepo.WithLockedQuizSession(ctx, session.ID, func(repo repository.QuizRepository, locked *models.QuizSession) error {
+			// The session may have been submitted since it was listed
+			if locked.CompletedAt != nil {
+				return nil
+			}
+			if err := s.closeExpiredSession(ctx, repo, loaded.quiz, locked, loaded.questions); err != nil {
+				return err
+			}
+			closed++
+			return nil
+		})
+		if err != nil {
+			return closed, s.sessionError(err)
+		}
+	}
+
+	return closed, nil
+}
+
+// DeleteStaleGeneratedQuizzes deletes the generated quizzes older than the
+// retention period that have no session in progress, and returns how many
+// were deleted
+func (s *QuizService) DeleteStaleGeneratedQuizzes(ctx context.Context) (int, error) {
+	deleted, err := s.quizRepo.DeleteStaleGeneratedQuizzes(ctx, time.Now().Add(-s.retention), staleQuizBatchSize)
+	if err != nil {
+		s.logger.Error("Failed to delete stale generated quizzes", utils.WithContext("error", err.Error()))
+		return 0, pkgErrors.Internal("Failed to delete stale generated quizzes", err)
+	}
+
+	if deleted > 0 {
+		s.logger.Info("Stale generated quizzes deleted", utils.WithContext("count", deleted))
+	}
+	return deleted, nil
+}
+
+// SweepExpiredSessions closes expired sessions and deletes stale generated
+// quizzes every interval until ctx is done
+func (s *QuizService) SweepExpiredSessions(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are logged where they happen; the next sweep retries
+			for {
+				closed, err := s.CloseExpiredSessions(ctx)
+				if err != nil || closed < expiredSessionBatchSize {
+					break
+				}
+			}
+			for {
+				deleted, err := s.DeleteStaleGeneratedQuizzes(ctx)
+				if err != nil || deleted < staleQuizBatchSize {
+					break
+				}
+			}
+		}
+	}
 }
 
-// GetQuizSessionResult retrieves the result of a completed quiz session
+// GetQuizSessionResult retrieves the result of a completed quiz session,
+// closing it first when its time is up
 func (s *QuizService) GetQuizSessionResult(ctx context.Context, userID, sessionID int) (*QuizSessionResult, error) {
 	// Get session
 	session, err := s.quizRepo.GetQuizSession(ctx, sessionID)
@@ -248,6 +622,23 @@ func (s *QuizService) GetQuizSessionResult(ctx context.Context, userID, sessionI
 		return nil, pkgErrors.Internal("Failed to retrieve quiz questions", err)
 	}
 
+	// Results would reveal the answers of a session still in progress
+	if session.CompletedAt == nil {
+		if !session.IsExpired(time.Now(), s.gracePeriod) {
+			return nil, pkgErrors.BadRequest("Quiz session is still in progress")
+		}
+		err := s.quizRepo.WithLockedQuizSession(ctx, sessionID, func(repo repository.QuizRepository, locked *models.QuizSession) error {
+			session = locked
+			if locked.CompletedAt != nil {
+				return nil
+			}
+			return s.closeExpiredSession(ctx, repo, quiz, locked, questions)
+		})
+		if err != nil {
+			return nil, s.sessionError(err)
+		}
+	}
+
 	// Get answers
 	answers, err := s.quizRepo.GetSessionAnswers(ctx, sessionID)
 	if err != nil {
@@ -255,25 +646,29 @@ func (s *QuizService) GetQuizSessionResult(ctx context.Context, userID, sessionI
 		return nil, pkgErrors.Internal("Failed to retrieve session answers", err)
 	}
 
-	result := &QuizSessionResult{
-		Session:   *session,
-		Quiz:      *quiz,
-		Questions: questions,
-		Answers:   answers,
-	}
-
-	return result, nil
+	return s.sessionResult(ctx, s.quizRepo, quiz, session, questions, answers)
 }
 
-// GetUserQuizHistory retrieves a user's quiz history
-func (s *QuizService) GetUserQuizHistory(ctx context.Context, userID, page, pageSize int) ([]models.QuizSession, error) {
-	offset := (page - 1) * pageSize
+// GetUserQuizHistory retrieves a user's quiz sessions, newest first, the cursor
+// of the next page and, when withTotal is set, the total count
+func (s *QuizService) GetUserQuizHistory(ctx context.Context, userID int, after *repository.Cursor, page, pageSize int, withTotal bool) ([]models.QuizSession, *repository.Cursor, *int, error) {
+	offset := pageOffset(page, pageSize, after)
 
-	sessions, err := s.quizRepo.GetUserQuizSessions(ctx, userID, pageSize, offset)
+	sessions, next, err := s.quizRepo.GetUserQuizSessions(ctx, userID, after, pageSize, offset)
 	if err != nil {
 		s.logger.Error("Failed to get user quiz sessions", utils.WithContext("error", err.Error()))
-		return nil, pkgErrors.Internal("Failed to retrieve quiz history", err)
+		return nil, nil, nil, pkgErrors.Internal("Failed to retrieve quiz history", err)
+	}
+
+	if !withTotal {
+		return sessions, next, nil, nil
+	}
+
+	total, err := s.quizRepo.CountUserQuizSessions(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count user quiz sessions", utils.WithContext("error", err.Error()))
+		return nil, nil, nil, pkgErrors.Internal("Failed to count quiz history", err)
 	}
 
-	return sessions, nil
+	return sessions, next, &total, nil
 }
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"unicode"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/pkg/conjugation"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+)
+
+const (
+	// quizPoolSize is how many vocabulary items are drawn to build the
+	// questions and distractors of a generated quiz
+	quizPoolSize = 400
+	// quizDistractorCount is the number of wrong options of a generated question
+	quizDistractorCount = 3
+	// clozeBlank replaces the grammar point in a cloze question
+	clozeBlank = "＿＿"
+)
+
+// VocabularyQuizSource generates meaning→word and reading quizzes from the
+// public vocabulary of a level
+type VocabularyQuizSource struct {
+	vocabRepo   repository.VocabularyRepository
+	distractors *DistractorService
+	quizType    string // QuizTypeMeaningToWord or QuizTypeReading
+}
+
+// NewVocabularyQuizSource creates a vocabulary quiz source of the given type
+func NewVocabularyQuizSource(vocabRepo repository.VocabularyRepository, distractors *DistractorService, quizType string) *VocabularyQuizSource {
+	return &VocabularyQuizSource{
+		vocabRepo:   vocabRepo,
+		distractors: distractors,
+		quizType:    quizType,
+	}
+}
+
+// GenerateQuestions asks for the word of a meaning, or the reading of a
+// word, with distractors picked among the vocabulary of the level
+func (s *VocabularyQuizSource) GenerateQuestions(ctx context.Context, userID, jlptLevel, size int) ([]models.QuizQuestion, error) {
+	pool, err := s.vocabRepo.GetRandom(ctx, jlptLevel, quizPoolSize)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := DistractorWord
+	if s.quizType == QuizTypeReading {
+		kind = DistractorReading
+	}
+
+	var questions []models.QuizQuestion
+	for i := range pool {
+		if len(questions) == size {
+			break
+		}
+
+		item := &pool[i]
+		var text string
+		switch s.quizType {
+		case QuizTypeReading:
+			// Words written in kana are their own reading
+			if kana.IsAllKana(item.Word) || item.Word == item.Reading {
+				continue
+			}
+			text = fmt.Sprintf("How is %s read?", item.Word)
+		default:
+			text = fmt.Sprintf("Which word means %q?", item.Meaning)
+		}
+
+		distractors := s.distractors.Pick(item, pool, kind, quizDistractorCount)
+		if len(distractors) < quizDistractorCount {
+			continue
+		}
+
+		explanation := fmt.Sprintf("%s (%s): %s", item.Word, item.Reading, item.Meaning)
+		questions = append(questions, multipleChoiceQuestion(text, distractorOption(item, kind), distractors, explanation))
+	}
+
+	return questions, nil
+}
+
+// sharesMeaning reports whether any meaning of v is among meanings
+func sharesMeaning(v *models.Vocabulary, meanings map[string]bool) bool {
+	for _, meaning := range SplitMeanings(v.Meaning) {
+		if meanings[normalizeMeaning(meaning)] {
+			return true
+		}
+	}
+	return false
+}
+
+// partOfSpeechGroup groups parts of speech for picking distractors, so that
+// verbs of every conjugation class are alike
+func partOfSpeechGroup(partOfSpeech *string) string {
+	if partOfSpeech == nil {
+		return ""
+	}
+	if class, ok := conjugation.ParseClass(*partOfSpeech); ok && class.IsVerb() {
+		return "verb"
+	}
+	return strings.ToLower(strings.TrimSpace(*partOfSpeech))
+}
+
+// GrammarQuizSource generates cloze quizzes from the examples of the
+// grammar lessons of a level
+type GrammarQuizSource struct {
+	grammarRepo repository.GrammarRepository
+	distractors *DistractorService
+}
+
+// NewGrammarQuizSource creates a grammar cloze quiz source
+func NewGrammarQuizSource(grammarRepo repository.GrammarRepository, distractors *DistractorService) *GrammarQuizSource {
+	return &GrammarQuizSource{
+		grammarRepo: grammarRepo,
+		distractors: distractors,
+	}
+}
+
+// clozeItem is an example sentence with the grammar point it illustrates
+type clozeItem struct {
+	lesson  *models.GrammarLessonWithExamples
+	example *models.GrammarExample
+	marker  string
+}
+
+// GenerateQuestions blanks the grammar point out of example sentences and
+// asks for it among the grammar points of other lessons of the level
+func (s *GrammarQuizSource) GenerateQuestions(ctx context.Context, userID, jlptLevel, size int) ([]models.QuizQuestion, error) {
+	lessons, err := s.grammarRepo.GetLessonsWithExamples(ctx, jlptLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	markers := make([][]string, len(lessons))
+	var items []clozeItem
+	for i := range lessons {
+		lesson := &lessons[i]
+		markers[i] = grammarMarkers(lesson.GrammarPoint)
+
+		for j := range lesson.Examples {
+			example := &lesson.Examples[j]
+			for _, marker := range markers[i] {
+				if strings.Contains(example.JapaneseSentence, marker) {
+					items = append(items, clozeItem{lesson: lesson, example: example, marker: marker})
+					break
+				}
+			}
+		}
+	}
+
+	rand.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+
+	var questions []models.QuizQuestion
+	for _, item := range items {
+		if len(questions) == size {
+			break
+		}
+
+		var candidates []string
+		for i := range lessons {
+			if lessons[i].ID == item.lesson.ID {
+				continue
+			}
+			candidates = append(candidates, markers[i]...)
+		}
+
+		distractors := s.distractors.PickMarkers(item.marker, candidates, quizDistractorCount)
+		if len(distractors) == 0 {
+			continue
+		}
+
+		sentence := strings.Replace(item.example.JapaneseSentence, item.marker, clozeBlank, 1)
+		text := fmt.Sprintf("Fill in the blank: %s (%s)", sentence, item.example.EnglishTranslation)
+		explanation := fmt.Sprintf("%s: %s", item.lesson.GrammarPoint, item.lesson.Title)
+		questions = append(questions, multipleChoiceQuestion(text, item.marker, distractors, explanation))
+	}
+
+	return questions, nil
+}
+
+// grammarMarkers extracts the Japanese of a grammar point written for
+// learners, such as "XはYです" (は, です) or "じゃありません / ではありません"
+func grammarMarkers(point string) []string {
+	point = parenthetical.ReplaceAllString(point, " ")
+
+	seen := make(map[string]bool)
+	var markers []string
+	for _, alternative := range strings.FieldsFunc(point, func(r rune) bool {
+		return r == '/' || r == '／' || r == '、' || r == ','
+	}) {
+		// Latin letters stand for the words the grammar point connects
+		for _, marker := range strings.FieldsFunc(alternative, func(r rune) bool {
+			return r <= unicode.MaxASCII || r == '〜' || r == '～' || unicode.IsSpace(r)
+		}) {
+			if !seen[marker] {
+				seen[marker] = true
+				markers = append(markers, marker)
+			}
+		}
+	}
+	return markers
+}
@@ -113,6 +113,19 @@ func (s *SpacedRepetitionService) GetQualityFromBoolean(isCorrect bool) ReviewQu
 	return ReviewQualityIncorrect // Default to quality 1 for incorrect
 }
 
+// GetQualityFromVerdict converts a checked answer verdict to ReviewQuality.
+// Near-misses count as incorrect but penalize the ease factor less.
+func (s *SpacedRepetitionService) GetQualityFromVerdict(verdict AnswerVerdict) ReviewQuality {
+	switch verdict {
+	case AnswerCorrect:
+		return ReviewQualityCorrectEasy
+	case AnswerAlmost:
+		return ReviewQualityIncorrectEasy
+	default:
+		return ReviewQualityIncorrect
+	}
+}
+
 // GetReviewStats calculates review statistics for display
 func (s *SpacedRepetitionService) GetReviewStats(progress *models.UserVocabularyProgress) map[string]interface{} {
 	var successRate float64
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+)
+
+const maxTagNameLength = 50
+
+// TagService handles user-defined vocabulary tags
+type TagService struct {
+	tagRepo repository.TagRepository
+	logger  *utils.Logger
+}
+
+// NewTagService creates a new tag service
+func NewTagService(tagRepo repository.TagRepository, logger *utils.Logger) *TagService {
+	return &TagService{
+		tagRepo: tagRepo,
+		logger:  logger,
+	}
+}
+
+// ListTags retrieves all tags of a user
+func (s *TagService) ListTags(ctx context.Context, userID int) ([]models.Tag, error) {
+	tags, err := s.tagRepo.GetUserTags(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get tags", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve tags", err)
+	}
+
+	return tags, nil
+}
+
+// CreateTag creates a tag for a user. Tag names cannot contain commas because
+// list filters take comma-separated tags.
+func (s *TagService) CreateTag(ctx context.Context, userID int, name string) (*models.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, pkgErrors.Validation("Tag name is required")
+	}
+	if utf8.RuneCountInString(name) > maxTagNameLength {
+		return nil, pkgErrors.Validation("Tag name must be at most 50 characters")
+	}
+	if strings.Contains(name, ",") {
+		return nil, pkgErrors.Validation("Tag name cannot contain commas")
+	}
+
+	tag := &models.Tag{UserID: userID, Name: name}
+	if err := s.tagRepo.CreateTag(ctx, tag); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to create tag", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to create tag", err)
+	}
+
+	return tag, nil
+}
+
+// GetTag retrieves one of the user's tags
+func (s *TagService) GetTag(ctx context.Context, userID, tagID int) (*models.Tag, error) {
+	tag, err := s.tagRepo.GetTagByID(ctx, tagID)
+	if err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get tag", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to retrieve tag", err)
+	}
+
+	if tag.UserID != userID {
+		return nil, pkgErrors.Forbidden("Not authorized to access this tag")
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes a tag and removes it from all items
+func (s *TagService) DeleteTag(ctx context.Context, userID, tagID int) error {
+	if _, err := s.GetTag(ctx, userID, tagID); err != nil {
+		return err
+	}
+
+	if err := s.tagRepo.DeleteTag(ctx, tagID); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete tag", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to delete tag", err)
+	}
+
+	return nil
+}
+
+// TagItems tags vocabulary items and returns how many were newly tagged.
+// Unknown items and items that already carry the tag are skipped.
+func (s *TagService) TagItems(ctx context.Context, userID, tagID int, vocabularyIDs []int) (int, error) {
+	if err := validateItemIDs(vocabularyIDs); err != nil {
+		return 0, err
+	}
+
+	if _, err := s.GetTag(ctx, userID, tagID); err != nil {
+		return 0, err
+	}
+
+	tagged, err := s.tagRepo.TagItems(ctx, tagID, vocabularyIDs)
+	if err != nil {
+		s.logger.Error("Failed to tag vocabulary", utils.WithContext("error", err.Error()))
+		return 0, pkgErrors.Internal("Failed to tag vocabulary", err)
+	}
+
+	return tagged, nil
+}
+
+// UntagItem removes a tag from a vocabulary item
+func (s *TagService) UntagItem(ctx context.Context, userID, tagID, vocabularyID int) error {
+	if _, err := s.GetTag(ctx, userID, tagID); err != nil {
+		return err
+	}
+
+	if err := s.tagRepo.UntagItem(ctx, tagID, vocabularyID); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return err
+		}
+		s.logger.Error("Failed to untag vocabulary", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to untag vocabulary", err)
+	}
+
+	return nil
+}
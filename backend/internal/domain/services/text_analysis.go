@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+	"github.com/joaosantos/jlpt5/pkg/tokenizer"
+)
+
+// How well the user knows a word of an analyzed text
+const (
+	WordKnown    = "known"    // Reviewed and currently remembered
+	WordLearning = "learning" // Reviewed but not yet remembered
+	WordUnknown  = "unknown"  // Never reviewed, or not in the vocabulary
+)
+
+// ReadableCoverage is the share of the words of a text a reader needs to
+// know to read it without constant lookups
+const ReadableCoverage = 0.95
+
+// TextAnalysis is the vocabulary of a text measured against the JLPT levels
+// and the user's progress. Counts are of word occurrences, so a word used
+// twice counts twice.
+type TextAnalysis struct {
+	Words         []AnalyzedWord // Distinct words in order of first appearance
+	TotalWords    int
+	LevelCounts   map[int]int // Occurrences per JLPT level; level 0 is words not in the vocabulary
+	KnownWords    int
+	LearningWords int
+	UnknownWords  int
+}
+
+// AnalyzedWord is a distinct word of an analyzed text
+type AnalyzedWord struct {
+	BaseForm   string
+	Reading    string
+	Vocabulary *models.Vocabulary // nil when the word is not in the vocabulary
+	State      string
+	Count      int
+}
+
+// KnownCoverage returns the share of word occurrences the user knows
+func (a *TextAnalysis) KnownCoverage() float64 {
+	if a.TotalWords == 0 {
+		return 0
+	}
+	return float64(a.KnownWords) / float64(a.TotalWords)
+}
+
+// AnalyzeText tokenizes a text and reports which of its words the user knows
+// and which JLPT levels they belong to. Particles, auxiliary verbs and affixes
+// only count when they are vocabulary items; punctuation and text without
+// kana or kanji never count.
+func (s *ToolsService) AnalyzeText(ctx context.Context, userID int, text string) (*TextAnalysis, error) {
+	tokens, err := s.Tokenize(ctx, userID, text)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &TextAnalysis{LevelCounts: make(map[int]int)}
+	byKey := make(map[string]int)
+	var vocabularyIDs []int
+	for _, token := range tokens {
+		if !isCountedWord(token) {
+			continue
+		}
+
+		key := "w:" + token.BaseForm
+		if token.Vocabulary != nil {
+			key = "v:" + strconv.Itoa(token.Vocabulary.ID)
+		}
+		i, ok := byKey[key]
+		if !ok {
+			i = len(analysis.Words)
+			byKey[key] = i
+			word := AnalyzedWord{BaseForm: token.BaseForm, Reading: token.Reading, Vocabulary: token.Vocabulary, State: WordUnknown}
+			if v := token.Vocabulary; v != nil {
+				word.BaseForm, word.Reading = v.Word, v.Reading
+				vocabularyIDs = append(vocabularyIDs, v.ID)
+			}
+			analysis.Words = append(analysis.Words, word)
+		}
+		analysis.Words[i].Count++
+	}
+
+	states := make(map[int]string)
+	if len(vocabularyIDs) > 0 {
+		progress, err := s.vocabRepo.GetUserProgressForVocabulary(ctx, userID, vocabularyIDs)
+		if err != nil {
+			s.logger.Error("Failed to get progress for text analysis", utils.WithContext("error", err.Error()))
+			return nil, pkgErrors.Internal("Failed to analyze text", err)
+		}
+		for _, p := range progress {
+			switch {
+			case p.Repetitions > 0:
+				states[p.VocabularyID] = WordKnown
+			case p.TotalReviews > 0:
+				states[p.VocabularyID] = WordLearning
+			}
+		}
+	}
+
+	for i := range analysis.Words {
+		word := &analysis.Words[i]
+		level := 0
+		if word.Vocabulary != nil {
+			level = word.Vocabulary.JLPTLevel
+			if state, ok := states[word.Vocabulary.ID]; ok {
+				word.State = state
+			}
+		}
+
+		analysis.TotalWords += word.Count
+		analysis.LevelCounts[level] += word.Count
+		switch word.State {
+		case WordKnown:
+			analysis.KnownWords += word.Count
+		case WordLearning:
+			analysis.LearningWords += word.Count
+		default:
+			analysis.UnknownWords += word.Count
+		}
+	}
+
+	return analysis, nil
+}
+
+// isCountedWord reports whether a token counts as a word of the text
+func isCountedWord(token TextToken) bool {
+	if token.POS == tokenizer.POSSymbol {
+		return false
+	}
+	if token.Vocabulary != nil {
+		return true
+	}
+
+	switch token.POS {
+	case tokenizer.POSParticle, tokenizer.POSAuxiliaryVerb, tokenizer.POSPrefix, tokenizer.POSSuffix:
+		return false
+	}
+	return strings.ContainsFunc(token.Surface, func(r rune) bool {
+		return kana.IsKana(r) || kana.IsKanji(r)
+	})
+}
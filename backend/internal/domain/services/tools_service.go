@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/utils"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+	"github.com/joaosantos/jlpt5/pkg/tokenizer"
+)
+
+// Conversion targets supported by ConvertKana
+const (
+	ConvertToHiragana = "hiragana"
+	ConvertToKatakana = "katakana"
+	ConvertToRomaji   = "romaji"
+)
+
+// maxToolTextLength limits the size of text accepted by the language tools
+const maxToolTextLength = 2000
+
+// ToolsService provides Japanese language helpers
+type ToolsService struct {
+	tokenizer tokenizer.Tokenizer
+	vocabRepo repository.VocabularyRepository
+	logger    *utils.Logger
+}
+
+// NewToolsService creates a new tools service
+func NewToolsService(tok tokenizer.Tokenizer, vocabRepo repository.VocabularyRepository, logger *utils.Logger) *ToolsService {
+	return &ToolsService{
+		tokenizer: tok,
+		vocabRepo: vocabRepo,
+		logger:    logger,
+	}
+}
+
+// ConvertKana converts text between romaji, hiragana and katakana.
+// system selects the romanization (hepburn or kunrei) and defaults to Hepburn.
+func (s *ToolsService) ConvertKana(text, to, system string) (string, error) {
+	text = kana.NormalizeWidth(strings.TrimSpace(text))
+	if text == "" {
+		return "", pkgErrors.BadRequest("text is required")
+	}
+	if utf8.RuneCountInString(text) > maxToolTextLength {
+		return "", pkgErrors.BadRequest("text is too long")
+	}
+
+	romajiSystem := kana.Hepburn
+	switch kana.System(system) {
+	case "", kana.Hepburn:
+	case kana.Kunrei:
+		romajiSystem = kana.Kunrei
+	default:
+		return "", pkgErrors.BadRequest("system must be 'hepburn' or 'kunrei'")
+	}
+
+	switch to {
+	case ConvertToHiragana:
+		return kana.ToHiragana(kana.FromRomaji(text)), nil
+	case ConvertToKatakana:
+		return kana.ToKatakana(kana.FromRomajiKatakana(text)), nil
+	case ConvertToRomaji:
+		return kana.ToRomaji(text, romajiSystem), nil
+	default:
+		return "", pkgErrors.BadRequest("to must be 'hiragana', 'katakana' or 'romaji'")
+	}
+}
+
+// TextToken is a word of a text, linked to the vocabulary item it is a form of
+type TextToken struct {
+	tokenizer.Token
+	Vocabulary *models.Vocabulary
+}
+
+// Tokenize splits text into words and links each word to the vocabulary item
+// visible to the user with the same dictionary form
+func (s *ToolsService) Tokenize(ctx context.Context, userID int, text string) ([]TextToken, error) {
+	text = kana.NormalizeWidth(strings.TrimSpace(text))
+	if text == "" {
+		return nil, pkgErrors.BadRequest("text is required")
+	}
+	if utf8.RuneCountInString(text) > maxToolTextLength {
+		return nil, pkgErrors.BadRequest("text is too long")
+	}
+
+	tokens := s.tokenizer.Tokenize(text)
+
+	var words []string
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		for _, word := range []string{token.BaseForm, token.Surface} {
+			if token.POS != tokenizer.POSSymbol && !seen[word] {
+				seen[word] = true
+				words = append(words, word)
+			}
+		}
+	}
+
+	byWord := make(map[string][]models.Vocabulary)
+	if len(words) > 0 {
+		items, err := s.vocabRepo.FindByWords(ctx, userID, words)
+		if err != nil {
+			s.logger.Error("Failed to find vocabulary for tokens", utils.WithContext("error", err.Error()))
+			return nil, pkgErrors.Internal("Failed to tokenize text", err)
+		}
+		for _, item := range items {
+			byWord[item.Word] = append(byWord[item.Word], item)
+		}
+	}
+
+	result := make([]TextToken, len(tokens))
+	for i, token := range tokens {
+		result[i] = TextToken{Token: token}
+		if token.POS != tokenizer.POSSymbol {
+			result[i].Vocabulary = matchVocabulary(token, byWord)
+		}
+	}
+
+	return result, nil
+}
+
+// matchVocabulary picks the vocabulary item a token is a form of. The
+// dictionary form is tried before the surface, and items read like the
+// token are preferred when a spelling has several readings (今日 as きょう
+// or こんにち).
+func matchVocabulary(token tokenizer.Token, byWord map[string][]models.Vocabulary) *models.Vocabulary {
+	for _, word := range []string{token.BaseForm, token.Surface} {
+		candidates := byWord[word]
+		if len(candidates) == 0 {
+			continue
+		}
+		if token.Surface == word && token.Reading != "" {
+			for i := range candidates {
+				if kana.ToHiragana(candidates[i].Reading) == token.Reading {
+					return &candidates[i]
+				}
+			}
+		}
+		return &candidates[0]
+	}
+	return nil
+}
@@ -2,55 +2,146 @@ package services
 
 import (
 	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/joaosantos/jlpt5/internal/domain/models"
 	"github.com/joaosantos/jlpt5/internal/domain/repository"
 	"github.com/joaosantos/jlpt5/internal/utils"
+	"github.com/joaosantos/jlpt5/pkg/conjugation"
 	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/joaosantos/jlpt5/pkg/kana"
+)
+
+// maxSearchQueryLength limits the length of vocabulary search queries
+const maxSearchQueryLength = 100
+
+// Limits of user-authored vocabulary fields, matching the column sizes
+const (
+	maxWordLength         = 100
+	maxPartOfSpeechLength = 50
+)
+
+// Limits of personal notes on vocabulary items
+const (
+	maxMnemonicLength        = 2000
+	maxPersonalExampleLength = 1000
+	maxImageURLLength        = 500
 )
 
 // VocabularyService handles vocabulary business logic
 type VocabularyService struct {
-	vocabRepo repository.VocabularyRepository
-	srService *SpacedRepetitionService
-	logger    *utils.Logger
+	vocabRepo     repository.VocabularyRepository
+	userRepo      repository.UserRepository
+	srService     *SpacedRepetitionService
+	answerChecker *AnswerChecker
+	logger        *utils.Logger
 }
 
 // NewVocabularyService creates a new vocabulary service
 func NewVocabularyService(
 	vocabRepo repository.VocabularyRepository,
+	userRepo repository.UserRepository,
 	srService *SpacedRepetitionService,
+	answerChecker *AnswerChecker,
 	logger *utils.Logger,
 ) *VocabularyService {
 	return &VocabularyService{
-		vocabRepo: vocabRepo,
-		srService: srService,
-		logger:    logger,
+		vocabRepo:     vocabRepo,
+		userRepo:      userRepo,
+		srService:     srService,
+		answerChecker: answerChecker,
+		logger:        logger,
 	}
 }
 
-// GetVocabularyList retrieves vocabulary items with optional filtering
-func (s *VocabularyService) GetVocabularyList(ctx context.Context, userID int, jlptLevel *int, page, pageSize int) ([]models.VocabularyWithProgress, int, error) {
-	offset := (page - 1) * pageSize
+// GetVocabularyList retrieves vocabulary items with optional filtering and sorting,
+// the cursor of the next page and, when withTotal is set, the total count
+func (s *VocabularyService) GetVocabularyList(ctx context.Context, userID int, filter repository.VocabularyFilter, page, pageSize int, withTotal bool) ([]models.VocabularyWithProgress, *repository.Cursor, *int, error) {
+	offset := pageOffset(page, pageSize, filter.After)
 
-	items, err := s.vocabRepo.GetUserVocabularyList(ctx, userID, jlptLevel, pageSize, offset)
+	items, next, err := s.vocabRepo.GetUserVocabularyList(ctx, userID, filter, pageSize, offset)
 	if err != nil {
 		s.logger.Error("Failed to get vocabulary list", utils.WithContext("error", err.Error()))
-		return nil, 0, pkgErrors.Internal("Failed to retrieve vocabulary", err)
+		return nil, nil, nil, pkgErrors.Internal("Failed to retrieve vocabulary", err)
 	}
 
-	total, err := s.vocabRepo.Count(ctx, jlptLevel)
+	if !withTotal {
+		return items, next, nil, nil
+	}
+
+	total, err := s.vocabRepo.CountUserVocabularyList(ctx, userID, filter)
 	if err != nil {
 		s.logger.Error("Failed to count vocabulary", utils.WithContext("error", err.Error()))
-		return nil, 0, pkgErrors.Internal("Failed to count vocabulary", err)
+		return nil, nil, nil, pkgErrors.Internal("Failed to count vocabulary", err)
+	}
+
+	return items, next, &total, nil
+}
+
+// SearchVocabulary searches vocabulary by word, reading, meaning or romaji.
+// Spellings shorter than three characters match words and readings by exact
+// spelling and prefix only, not anywhere inside them.
+func (s *VocabularyService) SearchVocabulary(ctx context.Context, userID int, query string, jlptLevel *int, page, pageSize int) ([]models.VocabularyWithProgress, int, error) {
+	query = strings.TrimSpace(kana.NormalizeWidth(query))
+	if query == "" {
+		return nil, 0, pkgErrors.BadRequest("Search query is required")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, 0, pkgErrors.BadRequest("Search query is too long")
+	}
+
+	search := repository.VocabularySearch{
+		Text:      query,
+		Terms:     searchTerms(query),
+		JLPTLevel: jlptLevel,
+	}
+	offset := (page - 1) * pageSize
+
+	items, err := s.vocabRepo.Search(ctx, userID, search, pageSize, offset)
+	if err != nil {
+		s.logger.Error("Failed to search vocabulary", utils.WithContext("error", err.Error()))
+		return nil, 0, pkgErrors.Internal("Failed to search vocabulary", err)
+	}
+
+	total, err := s.vocabRepo.CountSearch(ctx, userID, search)
+	if err != nil {
+		s.logger.Error("Failed to count vocabulary search results", utils.WithContext("error", err.Error()))
+		return nil, 0, pkgErrors.Internal("Failed to search vocabulary", err)
 	}
 
 	return items, total, nil
 }
 
+// searchTerms returns the Japanese spellings a query should match: the query
+// as typed plus its hiragana and katakana forms, converting romaji first. There
+// are at most repository.MaxSearchTerms of them.
+func searchTerms(query string) []string {
+	base := query
+	if kana.IsRomaji(query) {
+		converted := kana.FromRomaji(strings.ReplaceAll(query, " ", ""))
+		if !kana.IsAllKana(converted) {
+			// Not convertible (e.g. an English word): match it as typed only
+			return []string{query}
+		}
+		base = converted
+	}
+
+	terms := []string{query}
+	for _, term := range []string{base, kana.ToHiragana(base), kana.ToKatakana(base)} {
+		if !slices.Contains(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
 // GetVocabularyByID retrieves a specific vocabulary item
 func (s *VocabularyService) GetVocabularyByID(ctx context.Context, userID, vocabularyID int) (*models.VocabularyWithProgress, error) {
-	vocab, err := s.vocabRepo.GetByID(ctx, vocabularyID)
+	vocab, err := s.getVisibleVocabulary(ctx, userID, vocabularyID)
 	if err != nil {
 		return nil, err
 	}
@@ -73,9 +164,10 @@ func (s *VocabularyService) GetVocabularyByID(ctx context.Context, userID, vocab
 	return result, nil
 }
 
-// GetDueVocabulary retrieves vocabulary items due for review
-func (s *VocabularyService) GetDueVocabulary(ctx context.Context, userID int, limit int) ([]models.VocabularyWithProgress, error) {
-	items, err := s.vocabRepo.GetDueForReview(ctx, userID, limit)
+// GetDueVocabulary retrieves vocabulary items due for review, optionally only
+// those of one of the user's decks
+func (s *VocabularyService) GetDueVocabulary(ctx context.Context, userID int, deckID *int, limit int) ([]models.VocabularyWithProgress, error) {
+	items, err := s.vocabRepo.GetDueForReview(ctx, userID, deckID, limit)
 	if err != nil {
 		s.logger.Error("Failed to get due vocabulary", utils.WithContext("error", err.Error()))
 		return nil, pkgErrors.Internal("Failed to retrieve due vocabulary", err)
@@ -84,8 +176,113 @@ func (s *VocabularyService) GetDueVocabulary(ctx context.Context, userID int, li
 	return items, nil
 }
 
-// SubmitReview processes a review submission
-func (s *VocabularyService) SubmitReview(ctx context.Context, userID, vocabularyID int, isCorrect bool) (*models.UserVocabularyProgress, error) {
+// SubmitSelfGradedReview records a review the learner graded themselves, as
+// with flashcards, with an SM-2 quality from 0 to 5. The review log marks it
+// as self-graded.
+func (s *VocabularyService) SubmitSelfGradedReview(ctx context.Context, userID, vocabularyID, quality int) (*models.UserVocabularyProgress, error) {
+	if quality < int(ReviewQualityBlackout) || quality > int(ReviewQualityPerfect) {
+		return nil, pkgErrors.BadRequest("quality must be between 0 and 5")
+	}
+
+	if _, err := s.getVisibleVocabulary(ctx, userID, vocabularyID); err != nil {
+		return nil, err
+	}
+
+	return s.applyReview(ctx, userID, vocabularyID, ReviewQuality(quality), true)
+}
+
+// SubmitTypedReview checks a typed answer server-side and records the review.
+// answerType is either AnswerTypeReading or AnswerTypeMeaning. A near miss
+// only goes to the review log, so the learner can correct it: the progress is
+// then nil and the result withholds the expected answer. A correct retry
+// after a near miss counts as a hard pass.
+func (s *VocabularyService) SubmitTypedReview(ctx context.Context, userID, vocabularyID int, answer, answerType string) (*models.UserVocabularyProgress, *AnswerCheckResult, error) {
+	vocab, err := s.getVisibleVocabulary(ctx, userID, vocabularyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result AnswerCheckResult
+	var expected string
+	switch answerType {
+	case AnswerTypeReading:
+		result = s.answerChecker.CheckReading(answer, vocab)
+		expected = vocab.Reading
+	case AnswerTypeMeaning:
+		result = s.answerChecker.CheckMeaning(answer, vocab)
+		expected = vocab.Meaning
+	default:
+		return nil, nil, pkgErrors.BadRequest("answer_type must be 'reading' or 'meaning'")
+	}
+
+	if result.Verdict == AnswerAlmost {
+		result.Matched = ""
+		if err := s.recordNearMiss(ctx, userID, vocabularyID); err != nil {
+			return nil, nil, err
+		}
+		return nil, &result, nil
+	}
+
+	// Always tell the learner what was expected, even for a miss
+	if result.Matched == "" {
+		result.Matched = expected
+	}
+
+	quality := s.srService.GetQualityFromVerdict(result.Verdict)
+	if quality > ReviewQualityCorrectHard {
+		last, err := s.vocabRepo.GetLastReviewLog(ctx, userID, vocabularyID)
+		if err != nil {
+			if appErr, ok := err.(*pkgErrors.AppError); !ok || appErr.Code != pkgErrors.ErrCodeNotFound {
+				s.logger.Error("Failed to get last review", utils.WithContext("error", err.Error()))
+				return nil, nil, pkgErrors.Internal("Failed to submit review", err)
+			}
+		} else if last.NearMiss {
+			quality = ReviewQualityCorrectHard
+		}
+	}
+
+	progress, err := s.applyReview(ctx, userID, vocabularyID, quality, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return progress, &result, nil
+}
+
+// recordNearMiss logs a near-miss typed answer with the quality of a near
+// miss, leaving the schedule unchanged
+func (s *VocabularyService) recordNearMiss(ctx context.Context, userID, vocabularyID int) error {
+	progress, err := s.vocabRepo.GetUserProgress(ctx, userID, vocabularyID)
+	if err != nil {
+		appErr, ok := err.(*pkgErrors.AppError)
+		if !ok || appErr.Code != pkgErrors.ErrCodeNotFound {
+			s.logger.Error("Failed to get progress", utils.WithContext("error", err.Error()))
+			return pkgErrors.Internal("Failed to submit review", err)
+		}
+		// Not studied yet: log the schedule it would start with
+		progress = s.srService.InitializeProgress(userID, vocabularyID)
+	}
+
+	reviewLog := &models.VocabularyReviewLog{
+		UserID:           userID,
+		VocabularyID:     vocabularyID,
+		Quality:          int(s.srService.GetQualityFromVerdict(AnswerAlmost)),
+		NearMiss:         true,
+		PreviousInterval: progress.Interval,
+		Interval:         progress.Interval,
+		EaseFactor:       progress.EaseFactor,
+		ReviewedAt:       time.Now(),
+	}
+	if err := s.vocabRepo.CreateReviewLog(ctx, reviewLog); err != nil {
+		s.logger.Error("Failed to record near miss", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to submit review", err)
+	}
+
+	return nil
+}
+
+// applyReview updates (or creates) the user's progress with the given review quality
+func (s *VocabularyService) applyReview(ctx context.Context, userID, vocabularyID int, quality ReviewQuality, selfGraded bool) (*models.UserVocabularyProgress, error) {
 	// Get or create progress
 	progress, err := s.vocabRepo.GetUserProgress(ctx, userID, vocabularyID)
 	if err != nil {
@@ -107,7 +304,6 @@ func (s *VocabularyService) SubmitReview(ctx context.Context, userID, vocabulary
 	}
 
 	// Calculate new progress using SM-2 algorithm
-	quality := s.srService.GetQualityFromBoolean(isCorrect)
 	newProgress, err := s.srService.CalculateNextReview(progress, quality)
 	if err != nil {
 		s.logger.Error("Failed to calculate next review", utils.WithContext("error", err.Error()))
@@ -120,16 +316,56 @@ func (s *VocabularyService) SubmitReview(ctx context.Context, userID, vocabulary
 		return nil, pkgErrors.Internal("Failed to update progress", err)
 	}
 
+	// The progress is already saved, so failing to record the review is not fatal
+	reviewLog := &models.VocabularyReviewLog{
+		UserID:           userID,
+		VocabularyID:     vocabularyID,
+		Quality:          int(quality),
+		IsCorrect:        quality >= ReviewQualityCorrectHard,
+		SelfGraded:       selfGraded,
+		PreviousInterval: progress.Interval,
+		Interval:         newProgress.Interval,
+		EaseFactor:       newProgress.EaseFactor,
+		ReviewedAt:       *newProgress.LastReviewedAt,
+	}
+	if err := s.vocabRepo.CreateReviewLog(ctx, reviewLog); err != nil {
+		s.logger.Warn("Failed to record review", utils.WithContext("error", err.Error()))
+	}
+
 	s.logger.Info("Review submitted", utils.WithContext(
 		"user_id", userID,
 		"vocabulary_id", vocabularyID,
-		"is_correct", isCorrect,
+		"quality", int(quality),
 		"new_interval", newProgress.Interval,
 	))
 
 	return newProgress, nil
 }
 
+// GetReviewLog retrieves the user's reviews, newest first, optionally for one
+// vocabulary item, the cursor of the next page and, when withTotal is set, the total count
+func (s *VocabularyService) GetReviewLog(ctx context.Context, userID int, vocabularyID *int, after *repository.Cursor, page, pageSize int, withTotal bool) ([]models.VocabularyReviewLog, *repository.Cursor, *int, error) {
+	offset := pageOffset(page, pageSize, after)
+
+	logs, next, err := s.vocabRepo.GetUserReviewLog(ctx, userID, vocabularyID, after, pageSize, offset)
+	if err != nil {
+		s.logger.Error("Failed to get review log", utils.WithContext("error", err.Error()))
+		return nil, nil, nil, pkgErrors.Internal("Failed to retrieve review log", err)
+	}
+
+	if !withTotal {
+		return logs, next, nil, nil
+	}
+
+	total, err := s.vocabRepo.CountUserReviewLog(ctx, userID, vocabularyID)
+	if err != nil {
+		s.logger.Error("Failed to count review log", utils.WithContext("error", err.Error()))
+		return nil, nil, nil, pkgErrors.Internal("Failed to count review log", err)
+	}
+
+	return logs, next, &total, nil
+}
+
 // StartStudying initializes progress for a vocabulary item (marks it as "started")
 func (s *VocabularyService) StartStudying(ctx context.Context, userID, vocabularyID int) (*models.UserVocabularyProgress, error) {
 	// Check if progress already exists
@@ -168,3 +404,252 @@ func (s *VocabularyService) GetReviewStats(ctx context.Context, userID, vocabula
 
 	return s.srService.GetReviewStats(progress), nil
 }
+
+// SaveNotes stores a user's mnemonic, personal example and image URL for a
+// vocabulary item. Empty values clear a note. Saving notes on an item the user
+// has not studied yet starts studying it.
+func (s *VocabularyService) SaveNotes(ctx context.Context, userID, vocabularyID int, mnemonic, personalExample, imageURL *string) (*models.UserVocabularyProgress, error) {
+	if _, err := s.getVisibleVocabulary(ctx, userID, vocabularyID); err != nil {
+		return nil, err
+	}
+
+	mnemonic = trimNote(mnemonic)
+	personalExample = trimNote(personalExample)
+	imageURL = trimNote(imageURL)
+
+	if mnemonic != nil && utf8.RuneCountInString(*mnemonic) > maxMnemonicLength {
+		return nil, pkgErrors.Validation("Mnemonic must be at most 2000 characters")
+	}
+	if personalExample != nil && utf8.RuneCountInString(*personalExample) > maxPersonalExampleLength {
+		return nil, pkgErrors.Validation("Personal example must be at most 1000 characters")
+	}
+	if imageURL != nil {
+		if len(*imageURL) > maxImageURLLength {
+			return nil, pkgErrors.Validation("Image URL must be at most 500 characters")
+		}
+		u, err := url.Parse(*imageURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, pkgErrors.Validation("Image URL must be an http or https URL")
+		}
+	}
+
+	progress := s.srService.InitializeProgress(userID, vocabularyID)
+	progress.Mnemonic = mnemonic
+	progress.PersonalExample = personalExample
+	progress.ImageURL = imageURL
+
+	if err := s.vocabRepo.SaveUserNotes(ctx, progress); err != nil {
+		s.logger.Error("Failed to save vocabulary notes", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to save notes", err)
+	}
+
+	return progress, nil
+}
+
+// trimNote trims a note, treating blank notes as absent
+func trimNote(note *string) *string {
+	if note == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*note)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// CreatePrivateVocabulary adds a user-authored entry, visible only to its author
+// and scheduled like any other item once reviewed
+func (s *VocabularyService) CreatePrivateVocabulary(ctx context.Context, userID int, vocab *models.Vocabulary) (*models.Vocabulary, error) {
+	if err := normalizeVocabulary(vocab); err != nil {
+		return nil, err
+	}
+
+	vocab.ID = 0
+	vocab.OwnerID = &userID
+	vocab.Visibility = models.VisibilityPrivate
+	vocab.AudioURL = nil
+	vocab.Tags = nil
+
+	if err := s.vocabRepo.Create(ctx, vocab); err != nil {
+		s.logger.Error("Failed to create vocabulary", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to create vocabulary", err)
+	}
+
+	return vocab, nil
+}
+
+// UpdatePrivateVocabulary replaces the meaning, part of speech, JLPT level and
+// examples of one of the user's private entries, identified by vocab.ID, and
+// returns the updated entry. Word and reading are kept.
+func (s *VocabularyService) UpdatePrivateVocabulary(ctx context.Context, userID int, vocab *models.Vocabulary) (*models.Vocabulary, error) {
+	existing, err := s.getVisibleVocabulary(ctx, userID, vocab.ID)
+	if err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get vocabulary", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to update vocabulary", err)
+	}
+	if existing.Visibility != models.VisibilityPrivate {
+		return nil, pkgErrors.Forbidden("Only your own entries can be edited")
+	}
+
+	updated := *existing
+	updated.Meaning = vocab.Meaning
+	updated.PartOfSpeech = vocab.PartOfSpeech
+	updated.JLPTLevel = vocab.JLPTLevel
+	updated.ExampleSentence = vocab.ExampleSentence
+	updated.ExampleTranslation = vocab.ExampleTranslation
+	if err := normalizeVocabulary(&updated); err != nil {
+		return nil, err
+	}
+
+	if err := s.vocabRepo.UpdatePrivate(ctx, userID, &updated); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to update vocabulary", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to update vocabulary", err)
+	}
+
+	return &updated, nil
+}
+
+// normalizeVocabulary normalizes the width and spacing of a user-authored
+// entry and validates it. The JLPT level defaults to 5, and a plain verb or
+// adjective part of speech is replaced with its conjugation class so that
+// the entry can be drilled.
+func normalizeVocabulary(vocab *models.Vocabulary) error {
+	vocab.Word = strings.TrimSpace(kana.NormalizeWidth(vocab.Word))
+	vocab.Reading = strings.TrimSpace(kana.NormalizeWidth(vocab.Reading))
+	vocab.Meaning = strings.TrimSpace(vocab.Meaning)
+
+	if vocab.Word == "" || vocab.Reading == "" || vocab.Meaning == "" {
+		return pkgErrors.Validation("Word, reading, and meaning are required")
+	}
+	if utf8.RuneCountInString(vocab.Word) > maxWordLength || utf8.RuneCountInString(vocab.Reading) > maxWordLength {
+		return pkgErrors.Validation("Word and reading must be at most 100 characters")
+	}
+	if !kana.IsAllKana(vocab.Reading) {
+		return pkgErrors.Validation("Reading must be written in kana")
+	}
+	if vocab.PartOfSpeech != nil && utf8.RuneCountInString(*vocab.PartOfSpeech) > maxPartOfSpeechLength {
+		return pkgErrors.Validation("Part of speech must be at most 50 characters")
+	}
+	if vocab.PartOfSpeech != nil {
+		if class, ok := conjugation.InferClass(vocab.Word, vocab.Reading, *vocab.PartOfSpeech); ok {
+			partOfSpeech := string(class)
+			vocab.PartOfSpeech = &partOfSpeech
+		}
+	}
+	if vocab.JLPTLevel == 0 {
+		vocab.JLPTLevel = 5
+	}
+	if vocab.JLPTLevel < 1 || vocab.JLPTLevel > 5 {
+		return pkgErrors.Validation("JLPT level must be between 1 and 5")
+	}
+
+	return nil
+}
+
+// DeletePrivateVocabulary deletes one of the user's private entries with its progress
+func (s *VocabularyService) DeletePrivateVocabulary(ctx context.Context, userID, vocabularyID int) error {
+	if err := s.vocabRepo.DeletePrivate(ctx, userID, vocabularyID); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete vocabulary", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to delete vocabulary", err)
+	}
+
+	return nil
+}
+
+// SetSuggestion flags or unflags one of the user's private entries for inclusion
+// in the public vocabulary
+func (s *VocabularyService) SetSuggestion(ctx context.Context, userID, vocabularyID int, suggested bool) error {
+	if err := s.vocabRepo.SetSuggested(ctx, userID, vocabularyID, suggested); err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return err
+		}
+		s.logger.Error("Failed to update vocabulary suggestion", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to update suggestion", err)
+	}
+
+	return nil
+}
+
+// GetSuggestions retrieves private entries suggested for inclusion. Admins only.
+func (s *VocabularyService) GetSuggestions(ctx context.Context, userID, page, pageSize int) ([]models.Vocabulary, int, error) {
+	if err := requireAdmin(ctx, s.userRepo, s.logger, userID); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	items, err := s.vocabRepo.GetSuggested(ctx, pageSize, offset)
+	if err != nil {
+		s.logger.Error("Failed to get vocabulary suggestions", utils.WithContext("error", err.Error()))
+		return nil, 0, pkgErrors.Internal("Failed to retrieve suggestions", err)
+	}
+
+	total, err := s.vocabRepo.CountSuggested(ctx)
+	if err != nil {
+		s.logger.Error("Failed to count vocabulary suggestions", utils.WithContext("error", err.Error()))
+		return nil, 0, pkgErrors.Internal("Failed to count suggestions", err)
+	}
+
+	return items, total, nil
+}
+
+// PromoteVocabulary makes a private entry part of the public vocabulary. Admins only.
+func (s *VocabularyService) PromoteVocabulary(ctx context.Context, userID, vocabularyID int) (*models.Vocabulary, error) {
+	if err := requireAdmin(ctx, s.userRepo, s.logger, userID); err != nil {
+		return nil, err
+	}
+
+	vocab, err := s.vocabRepo.Promote(ctx, vocabularyID)
+	if err != nil {
+		if _, ok := err.(*pkgErrors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to promote vocabulary", utils.WithContext("error", err.Error()))
+		return nil, pkgErrors.Internal("Failed to promote vocabulary", err)
+	}
+
+	s.logger.Info("Vocabulary promoted to public", utils.WithContext("vocabulary_id", vocabularyID))
+	return vocab, nil
+}
+
+// getVisibleVocabulary retrieves a vocabulary item, reporting other users'
+// private entries as not found
+func (s *VocabularyService) getVisibleVocabulary(ctx context.Context, userID, vocabularyID int) (*models.Vocabulary, error) {
+	vocab, err := s.vocabRepo.GetByID(ctx, vocabularyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !vocab.IsVisibleTo(userID) {
+		return nil, pkgErrors.NotFound("Vocabulary not found")
+	}
+
+	return vocab, nil
+}
+
+// requireAdmin returns a Forbidden error unless the user is an administrator
+func requireAdmin(ctx context.Context, userRepo repository.UserRepository, logger *utils.Logger, userID int) error {
+	user, err := userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if appErr, ok := err.(*pkgErrors.AppError); ok && appErr.Code == pkgErrors.ErrCodeNotFound {
+			return pkgErrors.Forbidden("Admin access required")
+		}
+		logger.Error("Failed to get user", utils.WithContext("error", err.Error()))
+		return pkgErrors.Internal("Failed to check permissions", err)
+	}
+
+	if !user.IsAdmin {
+		return pkgErrors.Forbidden("Admin access required")
+	}
+
+	return nil
+}
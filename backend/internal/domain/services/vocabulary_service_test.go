@@ -0,0 +1,37 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+)
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"ねこ", []string{"ねこ", "ネコ"}},
+		{"ネコ", []string{"ネコ", "ねこ"}},
+		{"猫", []string{"猫"}},
+		{"neko", []string{"neko", "ねこ", "ネコ"}},
+		{"NEKO", []string{"NEKO", "ねこ", "ネコ"}},
+		{"kon'ya", []string{"kon'ya", "こんや", "コンヤ"}},
+		{"cat", []string{"cat"}},
+		{"日本ご", []string{"日本ご", "日本ゴ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := searchTerms(tt.query)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("searchTerms(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+			if len(got) > repository.MaxSearchTerms {
+				t.Errorf("searchTerms(%q) returned %d terms, more than MaxSearchTerms (%d)",
+					tt.query, len(got), repository.MaxSearchTerms)
+			}
+		})
+	}
+}
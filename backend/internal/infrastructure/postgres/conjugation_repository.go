@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type conjugationRepository struct {
+	db *database.DB
+}
+
+func NewConjugationRepository(db *database.DB) repository.ConjugationRepository {
+	return &conjugationRepository{db: db}
+}
+
+// conjugationProgressColumns lists the columns scanned by scanConjugationProgress
+const conjugationProgressColumns = `
+	c.id, c.user_id, c.vocabulary_id, c.form, c.ease_factor, c.interval, c.repetitions,
+	c.next_review_date, c.last_reviewed_at, c.total_reviews, c.correct_reviews,
+	c.created_at, c.updated_at`
+
+func conjugationProgressFields(p *models.ConjugationProgress) []interface{} {
+	return []interface{}{
+		&p.ID, &p.UserID, &p.VocabularyID, &p.Form, &p.EaseFactor, &p.Interval, &p.Repetitions,
+		&p.NextReviewDate, &p.LastReviewedAt, &p.TotalReviews, &p.CorrectReviews,
+		&p.CreatedAt, &p.UpdatedAt,
+	}
+}
+
+func vocabularyFields(v *models.Vocabulary) []interface{} {
+	return []interface{}{
+		&v.ID, &v.Word, &v.Reading, &v.Meaning, &v.PartOfSpeech, &v.JLPTLevel,
+		&v.ExampleSentence, &v.ExampleTranslation, &v.AudioURL, pq.Array(&v.Tags),
+		&v.OwnerID, &v.Visibility, &v.SuggestedForInclusion, &v.CreatedAt, &v.UpdatedAt,
+	}
+}
+
+func (r *conjugationRepository) GetDueDrills(ctx context.Context, userID int, forms []string, limit int) ([]models.ConjugationDrill, error) {
+	query := `
+		SELECT v.id, v.word, v.reading, v.meaning, v.part_of_speech, v.jlpt_level,
+		       v.example_sentence, v.example_translation, v.audio_url, v.tags,
+		       v.owner_id, v.visibility, v.suggested_for_inclusion, v.created_at, v.updated_at,
+		       ` + conjugationProgressColumns + `
+		FROM user_conjugation_progress c
+		JOIN vocabulary v ON v.id = c.vocabulary_id
+		WHERE c.user_id = $1
+		  AND c.next_review_date <= CURRENT_TIMESTAMP
+		  AND (v.visibility = 'public' OR v.owner_id = $1)
+		  AND ($2::text[] IS NULL OR c.form = ANY($2))
+		ORDER BY c.next_review_date, c.id
+		LIMIT $3
+	`
+
+	var formsParam interface{}
+	if len(forms) > 0 {
+		formsParam = pq.Array(forms)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, userID, formsParam, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying due drills: %w", err)
+	}
+	defer rows.Close()
+
+	var drills []models.ConjugationDrill
+	for rows.Next() {
+		var d models.ConjugationDrill
+		p := &models.ConjugationProgress{}
+		fields := append(vocabularyFields(&d.Vocabulary), conjugationProgressFields(p)...)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, fmt.Errorf("error scanning drill: %w", err)
+		}
+		d.Form = p.Form
+		d.Progress = p
+		drills = append(drills, d)
+	}
+
+	return drills, rows.Err()
+}
+
+func (r *conjugationRepository) GetLearnedVocabulary(ctx context.Context, userID int, classes []string, limit int) ([]models.Vocabulary, error) {
+	query := `
+		SELECT v.id, v.word, v.reading, v.meaning, v.part_of_speech, v.jlpt_level,
+		       v.example_sentence, v.example_translation, v.audio_url, v.tags,
+		       v.owner_id, v.visibility, v.suggested_for_inclusion, v.created_at, v.updated_at
+		FROM user_vocabulary_progress p
+		JOIN vocabulary v ON v.id = p.vocabulary_id
+		WHERE p.user_id = $1
+		  AND p.total_reviews > 0
+		  AND (v.visibility = 'public' OR v.owner_id = $1)
+		  AND v.part_of_speech = ANY($2::text[])
+		ORDER BY p.last_reviewed_at DESC NULLS LAST, v.id
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(classes), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying learned vocabulary: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.Vocabulary
+	for rows.Next() {
+		var v models.Vocabulary
+		if err := rows.Scan(vocabularyFields(&v)...); err != nil {
+			return nil, fmt.Errorf("error scanning vocabulary: %w", err)
+		}
+		items = append(items, v)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *conjugationRepository) GetProgressForVocabulary(ctx context.Context, userID int, vocabularyIDs []int) ([]models.ConjugationProgress, error) {
+	query := `
+		SELECT ` + conjugationProgressColumns + `
+		FROM user_conjugation_progress c
+		WHERE c.user_id = $1 AND c.vocabulary_id = ANY($2::int[])
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(vocabularyIDs))
+	if err != nil {
+		return nil, fmt.Errorf("error querying conjugation progress: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.ConjugationProgress
+	for rows.Next() {
+		var p models.ConjugationProgress
+		if err := rows.Scan(conjugationProgressFields(&p)...); err != nil {
+			return nil, fmt.Errorf("error scanning conjugation progress: %w", err)
+		}
+		items = append(items, p)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *conjugationRepository) GetProgress(ctx context.Context, userID, vocabularyID int, form string) (*models.ConjugationProgress, error) {
+	query := `
+		SELECT ` + conjugationProgressColumns + `
+		FROM user_conjugation_progress c
+		WHERE c.user_id = $1 AND c.vocabulary_id = $2 AND c.form = $3
+	`
+
+	p := &models.ConjugationProgress{}
+	err := r.db.QueryRowContext(ctx, query, userID, vocabularyID, form).Scan(conjugationProgressFields(p)...)
+
+	if err == sql.ErrNoRows {
+		return nil, pkgErrors.NotFound("Progress not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting conjugation progress: %w", err)
+	}
+
+	return p, nil
+}
+
+func (r *conjugationRepository) SaveProgress(ctx context.Context, p *models.ConjugationProgress) error {
+	query := `
+		INSERT INTO user_conjugation_progress
+		(user_id, vocabulary_id, form, ease_factor, interval, repetitions, next_review_date,
+		 last_reviewed_at, total_reviews, correct_reviews)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (user_id, vocabulary_id, form) DO UPDATE
+		SET ease_factor = EXCLUDED.ease_factor,
+		    interval = EXCLUDED.interval,
+		    repetitions = EXCLUDED.repetitions,
+		    next_review_date = EXCLUDED.next_review_date,
+		    last_reviewed_at = EXCLUDED.last_reviewed_at,
+		    total_reviews = EXCLUDED.total_reviews,
+		    correct_reviews = EXCLUDED.correct_reviews,
+		    updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		p.UserID, p.VocabularyID, p.Form, p.EaseFactor, p.Interval, p.Repetitions, p.NextReviewDate,
+		p.LastReviewedAt, p.TotalReviews, p.CorrectReviews,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("error saving conjugation progress: %w", err)
+	}
+
+	return nil
+}
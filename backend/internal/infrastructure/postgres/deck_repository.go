@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type deckRepository struct {
+	db *database.DB
+}
+
+func NewDeckRepository(db *database.DB) repository.DeckRepository {
+	return &deckRepository{db: db}
+}
+
+func (r *deckRepository) CreateDeck(ctx context.Context, deck *models.Deck) error {
+	query := `
+		INSERT INTO decks (user_id, name, description)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, deck.UserID, deck.Name, deck.Description).
+		Scan(&deck.ID, &deck.CreatedAt, &deck.UpdatedAt)
+
+	if isUniqueViolation(err) {
+		return pkgErrors.Conflict("A deck with this name already exists")
+	}
+	if err != nil {
+		return fmt.Errorf("error creating deck: %w", err)
+	}
+
+	return nil
+}
+
+func (r *deckRepository) GetDeckByID(ctx context.Context, deckID int) (*models.Deck, error) {
+	query := `
+		SELECT d.id, d.user_id, d.name, d.description,
+		       (SELECT COUNT(*) FROM deck_items di WHERE di.deck_id = d.id),
+		       d.created_at, d.updated_at
+		FROM decks d
+		WHERE d.id = $1
+	`
+
+	d := &models.Deck{}
+	err := r.db.QueryRowContext(ctx, query, deckID).Scan(
+		&d.ID, &d.UserID, &d.Name, &d.Description, &d.ItemCount, &d.CreatedAt, &d.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, pkgErrors.NotFound("Deck not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting deck: %w", err)
+	}
+
+	return d, nil
+}
+
+func (r *deckRepository) GetUserDecks(ctx context.Context, userID int) ([]models.Deck, error) {
+	query := `
+		SELECT d.id, d.user_id, d.name, d.description, COUNT(di.vocabulary_id),
+		       d.created_at, d.updated_at
+		FROM decks d
+		LEFT JOIN deck_items di ON di.deck_id = d.id
+		WHERE d.user_id = $1
+		GROUP BY d.id
+		ORDER BY d.name, d.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying decks: %w", err)
+	}
+	defer rows.Close()
+
+	var decks []models.Deck
+	for rows.Next() {
+		var d models.Deck
+		err := rows.Scan(&d.ID, &d.UserID, &d.Name, &d.Description, &d.ItemCount, &d.CreatedAt, &d.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning deck: %w", err)
+		}
+		decks = append(decks, d)
+	}
+
+	return decks, rows.Err()
+}
+
+func (r *deckRepository) UpdateDeck(ctx context.Context, deck *models.Deck) error {
+	query := `
+		UPDATE decks
+		SET name = $1, description = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, deck.Name, deck.Description, deck.ID).Scan(&deck.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return pkgErrors.NotFound("Deck not found")
+	}
+	if isUniqueViolation(err) {
+		return pkgErrors.Conflict("A deck with this name already exists")
+	}
+	if err != nil {
+		return fmt.Errorf("error updating deck: %w", err)
+	}
+
+	return nil
+}
+
+func (r *deckRepository) DeleteDeck(ctx context.Context, deckID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM decks WHERE id = $1`, deckID)
+	if err != nil {
+		return fmt.Errorf("error deleting deck: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return pkgErrors.NotFound("Deck not found")
+	}
+
+	return nil
+}
+
+func (r *deckRepository) AddItems(ctx context.Context, deckID int, vocabularyIDs []int) (int, error) {
+	query := `
+		INSERT INTO deck_items (deck_id, vocabulary_id)
+		SELECT $1, v.id
+		FROM vocabulary v
+		WHERE v.id = ANY($2::int[])
+		  AND (v.visibility = 'public' OR v.owner_id = (SELECT user_id FROM decks WHERE id = $1))
+		ON CONFLICT (deck_id, vocabulary_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, deckID, pq.Array(vocabularyIDs))
+	if err != nil {
+		return 0, fmt.Errorf("error adding deck items: %w", err)
+	}
+
+	added, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	return int(added), nil
+}
+
+func (r *deckRepository) RemoveItem(ctx context.Context, deckID, vocabularyID int) error {
+	query := `DELETE FROM deck_items WHERE deck_id = $1 AND vocabulary_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, deckID, vocabularyID)
+	if err != nil {
+		return fmt.Errorf("error removing deck item: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return pkgErrors.NotFound("Vocabulary item is not in this deck")
+	}
+
+	return nil
+}
+
+func (r *deckRepository) GetDeckStats(ctx context.Context, userID, deckID int) (*models.DeckStats, error) {
+	// Learning states follow the definitions of the vocabulary list filter
+	query := `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE p.id IS NULL),
+		       COUNT(*) FILTER (WHERE p.next_review_date > CURRENT_TIMESTAMP AND p.interval < $3),
+		       COUNT(*) FILTER (WHERE p.next_review_date <= CURRENT_TIMESTAMP),
+		       COUNT(*) FILTER (WHERE p.next_review_date > CURRENT_TIMESTAMP AND p.interval >= $3),
+		       COALESCE(SUM(p.total_reviews), 0), COALESCE(SUM(p.correct_reviews), 0)
+		FROM deck_items di
+		LEFT JOIN user_vocabulary_progress p ON p.vocabulary_id = di.vocabulary_id AND p.user_id = $2
+		WHERE di.deck_id = $1
+	`
+
+	stats := &models.DeckStats{DeckID: deckID}
+	err := r.db.QueryRowContext(ctx, query, deckID, userID, repository.MasteredIntervalDays).Scan(
+		&stats.TotalItems, &stats.NewItems, &stats.LearningItems, &stats.DueItems,
+		&stats.MasteredItems, &stats.TotalReviews, &stats.CorrectReviews,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting deck stats: %w", err)
+	}
+
+	return stats, nil
+}
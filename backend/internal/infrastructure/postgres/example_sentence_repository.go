@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
+	"github.com/lib/pq"
+)
+
+type exampleSentenceRepository struct {
+	db *database.DB
+}
+
+func NewExampleSentenceRepository(db *database.DB) repository.ExampleSentenceRepository {
+	return &exampleSentenceRepository{db: db}
+}
+
+func (r *exampleSentenceRepository) SaveSentence(ctx context.Context, sentence *models.ExampleSentence, vocabularyIDs []int) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO example_sentences (tatoeba_id, japanese, english, english_tatoeba_id, char_count, n5_coverage)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (tatoeba_id) DO UPDATE
+			SET japanese = EXCLUDED.japanese, english = EXCLUDED.english,
+			    english_tatoeba_id = EXCLUDED.english_tatoeba_id, char_count = EXCLUDED.char_count,
+			    n5_coverage = EXCLUDED.n5_coverage, updated_at = CURRENT_TIMESTAMP
+			RETURNING id, created_at, updated_at
+		`
+
+		err := tx.QueryRowContext(ctx, query,
+			sentence.TatoebaID, sentence.Japanese, sentence.English, sentence.EnglishTatoebaID,
+			sentence.CharCount, sentence.N5Coverage,
+		).Scan(&sentence.ID, &sentence.CreatedAt, &sentence.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("error saving example sentence: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM vocabulary_example_sentences WHERE sentence_id = $1`, sentence.ID)
+		if err != nil {
+			return fmt.Errorf("error clearing example sentence vocabulary: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO vocabulary_example_sentences (vocabulary_id, sentence_id)
+			SELECT v.id, $1 FROM vocabulary v WHERE v.id = ANY($2::int[])
+			ON CONFLICT DO NOTHING
+		`, sentence.ID, pq.Array(vocabularyIDs))
+		if err != nil {
+			return fmt.Errorf("error indexing example sentence: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *exampleSentenceRepository) GetSentences(ctx context.Context, afterID, limit int) ([]models.ExampleSentence, error) {
+	query := `
+		SELECT id, tatoeba_id, japanese, english, english_tatoeba_id,
+		       char_count, n5_coverage, created_at, updated_at
+		FROM example_sentences
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying example sentences: %w", err)
+	}
+	defer rows.Close()
+
+	return scanExampleSentences(rows)
+}
+
+func (r *exampleSentenceRepository) GetForVocabulary(ctx context.Context, vocabularyID, limit, offset int) ([]models.ExampleSentence, error) {
+	query := `
+		SELECT s.id, s.tatoeba_id, s.japanese, s.english, s.english_tatoeba_id,
+		       s.char_count, s.n5_coverage, s.created_at, s.updated_at
+		FROM example_sentences s
+		INNER JOIN vocabulary_example_sentences vs ON vs.sentence_id = s.id
+		WHERE vs.vocabulary_id = $1
+		ORDER BY s.n5_coverage DESC, s.char_count, s.id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, vocabularyID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error querying example sentences: %w", err)
+	}
+	defer rows.Close()
+
+	return scanExampleSentences(rows)
+}
+
+func (r *exampleSentenceRepository) CountForVocabulary(ctx context.Context, vocabularyID int) (int, error) {
+	query := `SELECT COUNT(*) FROM vocabulary_example_sentences WHERE vocabulary_id = $1`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, vocabularyID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting example sentences: %w", err)
+	}
+
+	return count, nil
+}
+
+// scanExampleSentences scans rows of example sentence columns
+func scanExampleSentences(rows *sql.Rows) ([]models.ExampleSentence, error) {
+	var sentences []models.ExampleSentence
+	for rows.Next() {
+		var s models.ExampleSentence
+		err := rows.Scan(
+			&s.ID, &s.TatoebaID, &s.Japanese, &s.English, &s.EnglishTatoebaID,
+			&s.CharCount, &s.N5Coverage, &s.CreatedAt, &s.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning example sentence: %w", err)
+		}
+		sentences = append(sentences, s)
+	}
+
+	return sentences, rows.Err()
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/lib/pq"
+)
+
+// sortColumn is a whitelisted sort expression and the Postgres type its
+// cursor key is cast back to
+type sortColumn struct {
+	Expr string
+	Type string
+}
+
+// sortColumns maps the sort fields a list accepts to SQL expressions
+type sortColumns map[string]sortColumn
+
+// resolve returns the column for a sort field, falling back to defaultField so
+// that the requested field never reaches the query text
+func (c sortColumns) resolve(field, defaultField string) sortColumn {
+	if col, ok := c[field]; ok {
+		return col
+	}
+	return c[defaultField]
+}
+
+// orderBy builds an ORDER BY clause from a whitelist of sort columns.
+// idColumn breaks ties to keep pagination stable.
+func orderBy(sort repository.SortOrder, columns sortColumns, defaultField, idColumn string) string {
+	expr := columns.resolve(sort.Field, defaultField).Expr
+
+	direction := "ASC"
+	if sort.Descending {
+		direction = "DESC"
+	}
+
+	if expr == idColumn {
+		return idColumn + " " + direction
+	}
+	return expr + " " + direction + " NULLS LAST, " + idColumn + " " + direction
+}
+
+// sortKeyColumn returns the select expression for the sort key a cursor is built from
+func sortKeyColumn(sort repository.SortOrder, columns sortColumns, defaultField string) string {
+	return "(" + columns.resolve(sort.Field, defaultField).Expr + ")::text"
+}
+
+// keyset returns the condition and arguments selecting the rows that follow the
+// cursor in the order produced by orderBy, or "" when there is no cursor.
+// Placeholders are numbered from next, the first unused parameter index.
+func keyset(cursor *repository.Cursor, sort repository.SortOrder, columns sortColumns, defaultField, idColumn string, next int) (string, []interface{}) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	col := columns.resolve(sort.Field, defaultField)
+	expr := "(" + col.Expr + ")"
+	cmp := ">"
+	if sort.Descending {
+		cmp = "<"
+	}
+
+	if col.Expr == idColumn {
+		return fmt.Sprintf(" AND %s %s $%d", idColumn, cmp, next), []interface{}{cursor.ID}
+	}
+
+	// NULL keys sort last in both directions
+	if cursor.Key == nil {
+		return fmt.Sprintf(" AND %s IS NULL AND %s %s $%d", expr, idColumn, cmp, next),
+			[]interface{}{cursor.ID}
+	}
+
+	condition := fmt.Sprintf(
+		" AND (%[1]s %[2]s $%[3]d::%[4]s OR (%[1]s = $%[3]d::%[4]s AND %[5]s %[2]s $%[6]d) OR %[1]s IS NULL)",
+		expr, cmp, next, col.Type, idColumn, next+1,
+	)
+	return condition, []interface{}{*cursor.Key, cursor.ID}
+}
+
+// sortKeys collects the sort key column of every scanned row
+type sortKeys []sql.NullString
+
+// Scan implements sql.Scanner by appending the value to the list
+func (k *sortKeys) Scan(src interface{}) error {
+	var key sql.NullString
+	if err := key.Scan(src); err != nil {
+		return err
+	}
+	*k = append(*k, key)
+	return nil
+}
+
+// cursor returns the cursor pointing after row i, whose ID is id
+func (k sortKeys) cursor(sort repository.SortOrder, i, id int) *repository.Cursor {
+	c := &repository.Cursor{Sort: sort.String(), ID: id}
+	if k[i].Valid {
+		c.Key = &k[i].String
+	}
+	return c
+}
+
+// optionalTags returns tags as a Postgres array, or NULL when no tag filter is set
+func optionalTags(tags []string) interface{} {
+	if len(tags) == 0 {
+		return nil
+	}
+	return pq.Array(tags)
+}
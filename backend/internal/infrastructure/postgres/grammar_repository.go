@@ -9,6 +9,7 @@ import (
 	"github.com/joaosantos/jlpt5/internal/domain/repository"
 	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
 	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/lib/pq"
 )
 
 type grammarRepository struct {
@@ -21,7 +22,7 @@ func NewGrammarRepository(db *database.DB) repository.GrammarRepository {
 
 func (r *grammarRepository) GetAllLessons(ctx context.Context, jlptLevel *int, limit, offset int) ([]models.GrammarLesson, error) {
 	query := `
-		SELECT id, title, grammar_point, explanation, usage_notes, jlpt_level, lesson_order, created_at, updated_at
+		SELECT id, title, grammar_point, explanation, usage_notes, jlpt_level, lesson_order, tags, created_at, updated_at
 		FROM grammar_lessons
 		WHERE ($1::int IS NULL OR jlpt_level = $1)
 		ORDER BY lesson_order, id
@@ -38,7 +39,7 @@ func (r *grammarRepository) GetAllLessons(ctx context.Context, jlptLevel *int, l
 	for rows.Next() {
 		var l models.GrammarLesson
 		err := rows.Scan(&l.ID, &l.Title, &l.GrammarPoint, &l.Explanation, &l.UsageNotes,
-			&l.JLPTLevel, &l.LessonOrder, &l.CreatedAt, &l.UpdatedAt)
+			&l.JLPTLevel, &l.LessonOrder, pq.Array(&l.Tags), &l.CreatedAt, &l.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning grammar lesson: %w", err)
 		}
@@ -51,7 +52,7 @@ func (r *grammarRepository) GetAllLessons(ctx context.Context, jlptLevel *int, l
 func (r *grammarRepository) GetLessonByID(ctx context.Context, lessonID int) (*models.GrammarLessonWithExamples, error) {
 	// Get lesson
 	lessonQuery := `
-		SELECT id, title, grammar_point, explanation, usage_notes, jlpt_level, lesson_order, created_at, updated_at
+		SELECT id, title, grammar_point, explanation, usage_notes, jlpt_level, lesson_order, tags, created_at, updated_at
 		FROM grammar_lessons
 		WHERE id = $1
 	`
@@ -59,7 +60,7 @@ func (r *grammarRepository) GetLessonByID(ctx context.Context, lessonID int) (*m
 	lesson := &models.GrammarLessonWithExamples{}
 	err := r.db.QueryRowContext(ctx, lessonQuery, lessonID).Scan(
 		&lesson.ID, &lesson.Title, &lesson.GrammarPoint, &lesson.Explanation, &lesson.UsageNotes,
-		&lesson.JLPTLevel, &lesson.LessonOrder, &lesson.CreatedAt, &lesson.UpdatedAt,
+		&lesson.JLPTLevel, &lesson.LessonOrder, pq.Array(&lesson.Tags), &lesson.CreatedAt, &lesson.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -98,6 +99,68 @@ func (r *grammarRepository) GetLessonByID(ctx context.Context, lessonID int) (*m
 	return lesson, rows.Err()
 }
 
+func (r *grammarRepository) GetLessonsWithExamples(ctx context.Context, jlptLevel int) ([]models.GrammarLessonWithExamples, error) {
+	lessonsQuery := `
+		SELECT id, title, grammar_point, explanation, usage_notes, jlpt_level, lesson_order, tags, created_at, updated_at
+		FROM grammar_lessons
+		WHERE jlpt_level = $1
+		ORDER BY lesson_order, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, lessonsQuery, jlptLevel)
+	if err != nil {
+		return nil, fmt.Errorf("error querying grammar lessons: %w", err)
+	}
+	defer rows.Close()
+
+	var lessons []models.GrammarLessonWithExamples
+	index := make(map[int]int)
+	for rows.Next() {
+		var l models.GrammarLessonWithExamples
+		err := rows.Scan(&l.ID, &l.Title, &l.GrammarPoint, &l.Explanation, &l.UsageNotes,
+			&l.JLPTLevel, &l.LessonOrder, pq.Array(&l.Tags), &l.CreatedAt, &l.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning grammar lesson: %w", err)
+		}
+		index[l.ID] = len(lessons)
+		lessons = append(lessons, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(lessons) == 0 {
+		return nil, nil
+	}
+
+	examplesQuery := `
+		SELECT e.id, e.grammar_lesson_id, e.japanese_sentence, e.english_translation, e.notes, e.example_order, e.created_at
+		FROM grammar_examples e
+		JOIN grammar_lessons l ON l.id = e.grammar_lesson_id
+		WHERE l.jlpt_level = $1
+		ORDER BY e.example_order, e.id
+	`
+
+	exampleRows, err := r.db.QueryContext(ctx, examplesQuery, jlptLevel)
+	if err != nil {
+		return nil, fmt.Errorf("error querying grammar examples: %w", err)
+	}
+	defer exampleRows.Close()
+
+	for exampleRows.Next() {
+		var e models.GrammarExample
+		err := exampleRows.Scan(&e.ID, &e.GrammarLessonID, &e.JapaneseSentence, &e.EnglishTranslation,
+			&e.Notes, &e.ExampleOrder, &e.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning grammar example: %w", err)
+		}
+		if i, ok := index[e.GrammarLessonID]; ok {
+			lessons[i].Examples = append(lessons[i].Examples, e)
+		}
+	}
+
+	return lessons, exampleRows.Err()
+}
+
 func (r *grammarRepository) GetUserProgress(ctx context.Context, userID, lessonID int) (*models.UserGrammarProgress, error) {
 	query := `
 		SELECT id, user_id, grammar_lesson_id, completed, completed_at, notes, created_at, updated_at
@@ -166,26 +229,51 @@ func (r *grammarRepository) UpdateUserProgress(ctx context.Context, progress *mo
 	return nil
 }
 
-func (r *grammarRepository) GetUserLessonsList(ctx context.Context, userID int, jlptLevel *int, limit, offset int) ([]models.GrammarLessonWithExamples, error) {
+// grammarListCondition filters the lesson list joined to the user's progress (p):
+// $2 JLPT level, $3 tags and $4 learning state, where completed lessons are mastered
+const grammarListCondition = `
+	($2::int IS NULL OR gl.jlpt_level = $2)
+	AND ($3::text[] IS NULL OR gl.tags @> $3)
+	AND ($4::text IS NULL
+	     OR ($4 = 'new' AND p.id IS NULL)
+	     OR ($4 = 'learning' AND p.id IS NOT NULL AND NOT COALESCE(p.completed, FALSE))
+	     OR ($4 = 'mastered' AND COALESCE(p.completed, FALSE)))
+`
+
+// grammarSortColumns is the whitelist of grammar lesson list sort fields
+var grammarSortColumns = sortColumns{
+	repository.SortByID:          {Expr: "gl.id", Type: "int"},
+	repository.SortByTitle:       {Expr: "gl.title", Type: "text"},
+	repository.SortByLessonOrder: {Expr: "gl.lesson_order", Type: "int"},
+}
+
+func (r *grammarRepository) GetUserLessonsList(ctx context.Context, userID int, filter repository.GrammarFilter, limit, offset int) ([]models.GrammarLessonWithExamples, *repository.Cursor, error) {
+	after, afterArgs := keyset(filter.After, filter.Sort, grammarSortColumns, repository.SortByLessonOrder, "gl.id", 7)
+
 	query := `
 		SELECT gl.id, gl.title, gl.grammar_point, gl.explanation, gl.usage_notes,
-		       gl.jlpt_level, gl.lesson_order, gl.created_at, gl.updated_at,
+		       gl.jlpt_level, gl.lesson_order, gl.tags, gl.created_at, gl.updated_at,
 		       p.id, p.user_id, p.grammar_lesson_id, p.completed, p.completed_at,
-		       p.notes, p.created_at, p.updated_at
+		       p.notes, p.created_at, p.updated_at,
+		       ` + sortKeyColumn(filter.Sort, grammarSortColumns, repository.SortByLessonOrder) + `
 		FROM grammar_lessons gl
 		LEFT JOIN user_grammar_progress p ON gl.id = p.grammar_lesson_id AND p.user_id = $1
-		WHERE ($2::int IS NULL OR gl.jlpt_level = $2)
-		ORDER BY gl.lesson_order, gl.id
-		LIMIT $3 OFFSET $4
+		WHERE ` + grammarListCondition + after + `
+		ORDER BY ` + orderBy(filter.Sort, grammarSortColumns, repository.SortByLessonOrder, "gl.id") + `
+		LIMIT $5 OFFSET $6
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, jlptLevel, limit, offset)
+	// Fetch one extra row to know whether there is a next page
+	args := append(grammarListArgs(userID, filter), limit+1, offset)
+	args = append(args, afterArgs...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error querying user lessons list: %w", err)
+		return nil, nil, fmt.Errorf("error querying user lessons list: %w", err)
 	}
 	defer rows.Close()
 
 	var lessons []models.GrammarLessonWithExamples
+	var keys sortKeys
 	for rows.Next() {
 		var lesson models.GrammarLessonWithExamples
 		var progressID sql.NullInt64
@@ -197,12 +285,18 @@ func (r *grammarRepository) GetUserLessonsList(ctx context.Context, userID int,
 
 		err := rows.Scan(
 			&lesson.ID, &lesson.Title, &lesson.GrammarPoint, &lesson.Explanation, &lesson.UsageNotes,
-			&lesson.JLPTLevel, &lesson.LessonOrder, &lesson.CreatedAt, &lesson.UpdatedAt,
+			&lesson.JLPTLevel, &lesson.LessonOrder, pq.Array(&lesson.Tags), &lesson.CreatedAt, &lesson.UpdatedAt,
 			&progressID, &progressUserID, &progressLessonID, &completed, &completedAt,
-			&notes, &progressCreatedAt, &progressUpdatedAt,
+			&notes, &progressCreatedAt, &progressUpdatedAt, &keys,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("error scanning user lesson: %w", err)
+			return nil, nil, fmt.Errorf("error scanning user lesson: %w", err)
+		}
+
+		// The extra row only tells there is a next page, so skip loading its examples
+		if len(lessons) == limit {
+			lessons = append(lessons, lesson)
+			break
 		}
 
 		// Populate progress if it exists
@@ -233,7 +327,7 @@ func (r *grammarRepository) GetUserLessonsList(ctx context.Context, userID int,
 
 		exampleRows, err := r.db.QueryContext(ctx, examplesQuery, lesson.ID)
 		if err != nil {
-			return nil, fmt.Errorf("error querying grammar examples: %w", err)
+			return nil, nil, fmt.Errorf("error querying grammar examples: %w", err)
 		}
 
 		var examples []models.GrammarExample
@@ -243,7 +337,7 @@ func (r *grammarRepository) GetUserLessonsList(ctx context.Context, userID int,
 				&e.Notes, &e.ExampleOrder, &e.CreatedAt)
 			if err != nil {
 				exampleRows.Close()
-				return nil, fmt.Errorf("error scanning grammar example: %w", err)
+				return nil, nil, fmt.Errorf("error scanning grammar example: %w", err)
 			}
 			examples = append(examples, e)
 		}
@@ -252,8 +346,17 @@ func (r *grammarRepository) GetUserLessonsList(ctx context.Context, userID int,
 		lesson.Examples = examples
 		lessons = append(lessons, lesson)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
 
-	return lessons, rows.Err()
+	var next *repository.Cursor
+	if len(lessons) > limit {
+		lessons = lessons[:limit]
+		next = keys.cursor(filter.Sort, limit-1, lessons[limit-1].ID)
+	}
+
+	return lessons, next, nil
 }
 
 func (r *grammarRepository) CountLessons(ctx context.Context, jlptLevel *int) (int, error) {
@@ -271,3 +374,24 @@ func (r *grammarRepository) CountLessons(ctx context.Context, jlptLevel *int) (i
 
 	return count, nil
 }
+
+func (r *grammarRepository) CountUserLessonsList(ctx context.Context, userID int, filter repository.GrammarFilter) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM grammar_lessons gl
+		LEFT JOIN user_grammar_progress p ON gl.id = p.grammar_lesson_id AND p.user_id = $1
+		WHERE ` + grammarListCondition
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, grammarListArgs(userID, filter)...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting user lessons list: %w", err)
+	}
+
+	return count, nil
+}
+
+// grammarListArgs builds the $1-$4 arguments used by grammarListCondition
+func grammarListArgs(userID int, filter repository.GrammarFilter) []interface{} {
+	return []interface{}{userID, filter.JLPTLevel, optionalTags(filter.Tags), filter.LearningState}
+}
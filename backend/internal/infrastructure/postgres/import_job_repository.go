@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type importJobRepository struct {
+	db *database.DB
+}
+
+func NewImportJobRepository(db *database.DB) repository.ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) CreateJob(ctx context.Context, job *models.ImportJob) error {
+	query := `
+		INSERT INTO import_jobs (user_id, source, file_name, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, job.UserID, job.Source, job.FileName, job.Status).
+		Scan(&job.ID, &job.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("error creating import job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *importJobRepository) GetJobByID(ctx context.Context, jobID int) (*models.ImportJob, error) {
+	query := `
+		SELECT id, user_id, source, file_name, status, total_items, created_items, matched_items,
+		       updated_items, skipped_items, failed_items, progress_imported, reviews_imported, warnings, error,
+		       created_at, started_at, finished_at
+		FROM import_jobs
+		WHERE id = $1
+	`
+
+	job, err := scanImportJob(r.db.QueryRowContext(ctx, query, jobID))
+	if err == sql.ErrNoRows {
+		return nil, pkgErrors.NotFound("Import job not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting import job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *importJobRepository) GetUserJobs(ctx context.Context, userID, limit int) ([]models.ImportJob, error) {
+	query := `
+		SELECT id, user_id, source, file_name, status, total_items, created_items, matched_items,
+		       updated_items, skipped_items, failed_items, progress_imported, reviews_imported, warnings, error,
+		       created_at, started_at, finished_at
+		FROM import_jobs
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying import jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []models.ImportJob
+	for rows.Next() {
+		job, err := scanImportJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning import job: %w", err)
+		}
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, rows.Err()
+}
+
+func (r *importJobRepository) UpdateJob(ctx context.Context, job *models.ImportJob) error {
+	query := `
+		UPDATE import_jobs
+		SET status = $1, total_items = $2, created_items = $3, matched_items = $4, updated_items = $5,
+		    skipped_items = $6, failed_items = $7, progress_imported = $8, reviews_imported = $9,
+		    warnings = $10, error = $11, started_at = $12, finished_at = $13
+		WHERE id = $14
+	`
+
+	warnings := job.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		job.Status, job.TotalItems, job.CreatedItems, job.MatchedItems, job.UpdatedItems,
+		job.SkippedItems, job.FailedItems, job.ProgressImported, job.ReviewsImported,
+		pq.Array(warnings), job.Error, job.StartedAt, job.FinishedAt, job.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating import job: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return pkgErrors.NotFound("Import job not found")
+	}
+
+	return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanImportJob(row rowScanner) (*models.ImportJob, error) {
+	job := &models.ImportJob{}
+	err := row.Scan(
+		&job.ID, &job.UserID, &job.Source, &job.FileName, &job.Status, &job.TotalItems,
+		&job.CreatedItems, &job.MatchedItems, &job.UpdatedItems, &job.SkippedItems, &job.FailedItems,
+		&job.ProgressImported, &job.ReviewsImported, pq.Array(&job.Warnings), &job.Error,
+		&job.CreatedAt, &job.StartedAt, &job.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
@@ -0,0 +1,9 @@
+-- Drop vocabulary search indexes
+DROP INDEX IF EXISTS idx_vocabulary_meaning_fts;
+DROP INDEX IF EXISTS idx_vocabulary_reading_trgm;
+DROP INDEX IF EXISTS idx_vocabulary_word_trgm;
+
+-- The pg_trgm extension is left installed as other database objects may depend on it
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '006_add_vocabulary_search_indexes';
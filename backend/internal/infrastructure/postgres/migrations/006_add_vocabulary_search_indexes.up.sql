@@ -0,0 +1,13 @@
+-- Enable trigram matching for substring search on Japanese text
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Create trigram indexes for word and reading search
+CREATE INDEX IF NOT EXISTS idx_vocabulary_word_trgm ON vocabulary USING GIN (word gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_vocabulary_reading_trgm ON vocabulary USING GIN (reading gin_trgm_ops);
+
+-- Create full-text index for English meaning search
+CREATE INDEX IF NOT EXISTS idx_vocabulary_meaning_fts ON vocabulary USING GIN (to_tsvector('english', meaning));
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('006_add_vocabulary_search_indexes')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,11 @@
+-- Drop list filter indexes
+DROP INDEX IF EXISTS idx_vocabulary_reading;
+DROP INDEX IF EXISTS idx_grammar_tags;
+DROP INDEX IF EXISTS idx_vocabulary_tags;
+
+-- Drop tags columns
+ALTER TABLE grammar_lessons DROP COLUMN IF EXISTS tags;
+ALTER TABLE vocabulary DROP COLUMN IF EXISTS tags;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '007_add_tags_and_list_filter_indexes';
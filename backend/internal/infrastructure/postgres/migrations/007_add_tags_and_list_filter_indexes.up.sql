@@ -0,0 +1,14 @@
+-- Add curated tags to vocabulary and grammar lessons
+ALTER TABLE vocabulary ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE grammar_lessons ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Create indexes for tag filters
+CREATE INDEX IF NOT EXISTS idx_vocabulary_tags ON vocabulary USING GIN (tags);
+CREATE INDEX IF NOT EXISTS idx_grammar_tags ON grammar_lessons USING GIN (tags);
+
+-- Create index for sorting vocabulary by reading
+CREATE INDEX IF NOT EXISTS idx_vocabulary_reading ON vocabulary(reading);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('007_add_tags_and_list_filter_indexes')
+ON CONFLICT (version) DO NOTHING;
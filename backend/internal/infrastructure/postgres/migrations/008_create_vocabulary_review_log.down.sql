@@ -0,0 +1,12 @@
+-- Drop quiz history index
+DROP INDEX IF EXISTS idx_quiz_sessions_user_started;
+
+-- Drop indexes for vocabulary review log
+DROP INDEX IF EXISTS idx_vrl_user_vocabulary;
+DROP INDEX IF EXISTS idx_vrl_user_reviewed;
+
+-- Drop vocabulary review log table
+DROP TABLE IF EXISTS vocabulary_review_log;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '008_create_vocabulary_review_log';
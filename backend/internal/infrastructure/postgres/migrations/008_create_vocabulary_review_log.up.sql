@@ -0,0 +1,23 @@
+-- Create vocabulary review log table (one row per review)
+CREATE TABLE IF NOT EXISTS vocabulary_review_log (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vocabulary_id INTEGER NOT NULL REFERENCES vocabulary(id) ON DELETE CASCADE,
+    quality INTEGER NOT NULL,               -- SM-2 review quality (0-5)
+    is_correct BOOLEAN NOT NULL,
+    previous_interval INTEGER NOT NULL,     -- Days until review before this one
+    interval INTEGER NOT NULL,              -- Days until review after this one
+    ease_factor DECIMAL(3,2) NOT NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for review log listings (keyset pagination)
+CREATE INDEX idx_vrl_user_reviewed ON vocabulary_review_log(user_id, reviewed_at DESC, id DESC);
+CREATE INDEX idx_vrl_user_vocabulary ON vocabulary_review_log(user_id, vocabulary_id, reviewed_at DESC, id DESC);
+
+-- Create index for quiz history listings (keyset pagination)
+CREATE INDEX IF NOT EXISTS idx_quiz_sessions_user_started ON quiz_sessions(user_id, started_at DESC, id DESC);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('008_create_vocabulary_review_log')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,18 @@
+-- Drop vocabulary tags table
+DROP INDEX IF EXISTS idx_vocabulary_tags_vocabulary_id;
+DROP TABLE IF EXISTS vocabulary_tags;
+
+-- Drop tags table
+DROP INDEX IF EXISTS idx_tags_user_id;
+DROP TABLE IF EXISTS tags;
+
+-- Drop deck items table
+DROP INDEX IF EXISTS idx_deck_items_vocabulary_id;
+DROP TABLE IF EXISTS deck_items;
+
+-- Drop decks table
+DROP INDEX IF EXISTS idx_decks_user_id;
+DROP TABLE IF EXISTS decks;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '009_create_decks_and_tags';
@@ -0,0 +1,51 @@
+-- Create decks table (user-built collections of vocabulary)
+CREATE TABLE IF NOT EXISTS decks (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_user_deck_name UNIQUE(user_id, name)
+);
+
+-- Create indexes for decks
+CREATE INDEX idx_decks_user_id ON decks(user_id);
+
+-- Create deck items table
+CREATE TABLE IF NOT EXISTS deck_items (
+    deck_id INTEGER NOT NULL REFERENCES decks(id) ON DELETE CASCADE,
+    vocabulary_id INTEGER NOT NULL REFERENCES vocabulary(id) ON DELETE CASCADE,
+    added_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (deck_id, vocabulary_id)
+);
+
+-- Create indexes for deck items
+CREATE INDEX idx_deck_items_vocabulary_id ON deck_items(vocabulary_id);
+
+-- Create tags table (user-defined, unlike the curated vocabulary.tags column)
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_user_tag_name UNIQUE(user_id, name)
+);
+
+-- Create indexes for tags
+CREATE INDEX idx_tags_user_id ON tags(user_id);
+
+-- Create vocabulary tags table
+CREATE TABLE IF NOT EXISTS vocabulary_tags (
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    vocabulary_id INTEGER NOT NULL REFERENCES vocabulary(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tag_id, vocabulary_id)
+);
+
+-- Create indexes for vocabulary tags
+CREATE INDEX idx_vocabulary_tags_vocabulary_id ON vocabulary_tags(vocabulary_id);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('009_create_decks_and_tags')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,14 @@
+-- Drop admin flag from users
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
+
+-- Drop user-authored vocabulary, then the ownership columns
+DELETE FROM vocabulary WHERE visibility = 'private';
+DROP INDEX IF EXISTS idx_vocabulary_suggested;
+DROP INDEX IF EXISTS idx_vocabulary_owner_id;
+ALTER TABLE vocabulary
+    DROP COLUMN IF EXISTS suggested_for_inclusion,
+    DROP COLUMN IF EXISTS visibility,
+    DROP COLUMN IF EXISTS owner_id;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '010_add_vocabulary_ownership';
@@ -0,0 +1,18 @@
+-- Add ownership and visibility to vocabulary (user-authored private entries)
+ALTER TABLE vocabulary
+    ADD COLUMN IF NOT EXISTS owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(10) NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'private')),
+    ADD COLUMN IF NOT EXISTS suggested_for_inclusion BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create indexes for vocabulary ownership
+CREATE INDEX IF NOT EXISTS idx_vocabulary_owner_id ON vocabulary(owner_id) WHERE owner_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_vocabulary_suggested ON vocabulary(id)
+    WHERE suggested_for_inclusion AND visibility = 'private';
+
+-- Add admin flag to users
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('010_add_vocabulary_ownership')
+ON CONFLICT (version) DO NOTHING;
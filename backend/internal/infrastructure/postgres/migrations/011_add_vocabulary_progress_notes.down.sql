@@ -0,0 +1,8 @@
+-- Drop personal notes from user vocabulary progress
+ALTER TABLE user_vocabulary_progress
+    DROP COLUMN IF EXISTS image_url,
+    DROP COLUMN IF EXISTS personal_example,
+    DROP COLUMN IF EXISTS mnemonic;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '011_add_vocabulary_progress_notes';
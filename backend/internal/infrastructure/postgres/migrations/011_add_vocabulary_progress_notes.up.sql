@@ -0,0 +1,9 @@
+-- Add personal notes to user vocabulary progress
+ALTER TABLE user_vocabulary_progress
+    ADD COLUMN IF NOT EXISTS mnemonic TEXT,
+    ADD COLUMN IF NOT EXISTS personal_example TEXT,
+    ADD COLUMN IF NOT EXISTS image_url VARCHAR(500);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('011_add_vocabulary_progress_notes')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,9 @@
+-- Drop vocabulary lookup index
+DROP INDEX IF EXISTS idx_vocabulary_word_reading;
+
+-- Drop import jobs table
+DROP INDEX IF EXISTS idx_import_jobs_user_created;
+DROP TABLE IF EXISTS import_jobs;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '012_create_import_jobs';
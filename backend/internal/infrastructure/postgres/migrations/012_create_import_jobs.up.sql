@@ -0,0 +1,31 @@
+-- Create import jobs table (vocabulary imports from Anki and other sources)
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    total_items INTEGER NOT NULL DEFAULT 0,
+    created_items INTEGER NOT NULL DEFAULT 0,
+    matched_items INTEGER NOT NULL DEFAULT 0,
+    skipped_items INTEGER NOT NULL DEFAULT 0,
+    failed_items INTEGER NOT NULL DEFAULT 0,
+    progress_imported INTEGER NOT NULL DEFAULT 0,
+    reviews_imported INTEGER NOT NULL DEFAULT 0,
+    warnings TEXT[] NOT NULL DEFAULT '{}',
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for import jobs
+CREATE INDEX idx_import_jobs_user_created ON import_jobs(user_id, created_at DESC);
+
+-- Create index to find existing vocabulary when importing
+CREATE INDEX IF NOT EXISTS idx_vocabulary_word_reading ON vocabulary(word, reading);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('012_create_import_jobs')
+ON CONFLICT (version) DO NOTHING;
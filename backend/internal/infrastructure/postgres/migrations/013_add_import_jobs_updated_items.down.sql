@@ -0,0 +1,5 @@
+-- Remove updated items count from import jobs
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS updated_items;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '013_add_import_jobs_updated_items';
@@ -0,0 +1,6 @@
+-- Add count of existing private items updated by an import
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS updated_items INTEGER NOT NULL DEFAULT 0;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('013_add_import_jobs_updated_items')
+ON CONFLICT (version) DO NOTHING;
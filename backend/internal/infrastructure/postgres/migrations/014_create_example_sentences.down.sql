@@ -0,0 +1,7 @@
+-- Drop example sentence tables
+DROP TABLE IF EXISTS vocabulary_example_sentences;
+DROP INDEX IF EXISTS idx_example_sentences_ranking;
+DROP TABLE IF EXISTS example_sentences;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '014_create_example_sentences';
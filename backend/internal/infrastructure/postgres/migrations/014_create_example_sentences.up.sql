@@ -0,0 +1,27 @@
+-- Create example sentences table (Japanese-English pairs imported from Tatoeba)
+CREATE TABLE IF NOT EXISTS example_sentences (
+    id SERIAL PRIMARY KEY,
+    tatoeba_id INTEGER NOT NULL UNIQUE,
+    japanese TEXT NOT NULL,
+    english TEXT NOT NULL,
+    english_tatoeba_id INTEGER,
+    char_count INTEGER NOT NULL,
+    n5_coverage REAL NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create table indexing the vocabulary each sentence contains
+CREATE TABLE IF NOT EXISTS vocabulary_example_sentences (
+    vocabulary_id INTEGER NOT NULL REFERENCES vocabulary(id) ON DELETE CASCADE,
+    sentence_id INTEGER NOT NULL REFERENCES example_sentences(id) ON DELETE CASCADE,
+    PRIMARY KEY (vocabulary_id, sentence_id)
+);
+
+-- Create indexes for example sentences
+CREATE INDEX idx_vocabulary_example_sentences_sentence_id ON vocabulary_example_sentences(sentence_id);
+CREATE INDEX idx_example_sentences_ranking ON example_sentences(n5_coverage DESC, char_count);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('014_create_example_sentences')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,7 @@
+-- Merge the verb conjugation classes back into a generic verb. Adjectives
+-- keep their class, as i-adjective and na-adjective were valid before.
+UPDATE vocabulary SET part_of_speech = 'verb'
+WHERE part_of_speech IN ('godan verb', 'ichidan verb', 'irregular verb');
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '015_refine_part_of_speech';
@@ -0,0 +1,39 @@
+-- Split the generic verb and adjective parts of speech into conjugation classes
+
+-- する and its compounds, 来る, 行く, ある and the honorific -aru verbs
+UPDATE vocabulary SET part_of_speech = 'irregular verb'
+WHERE LOWER(part_of_speech) = 'verb'
+  AND (word LIKE '%する'
+       OR (word LIKE '%来る' AND reading LIKE '%くる')
+       OR word = 'くる'
+       OR word LIKE '%行く'
+       OR word IN ('いく', 'ある', '有る', '在る')
+       OR word IN ('いらっしゃる', 'くださる', '下さる', 'おっしゃる', '仰る', 'なさる', '為さる', 'ござる', '御座る'));
+
+-- Verbs in -iru/-eru are ichidan, except for the godan verbs that look alike.
+-- Kana spellings shared with a common ichidan verb, such as ねる (寝る) and
+-- しめる (閉める), stay ichidan.
+UPDATE vocabulary SET part_of_speech = 'ichidan verb'
+WHERE LOWER(part_of_speech) = 'verb'
+  AND reading ~ '[いきぎしじちぢにひびぴみりえけげせぜてでねへべぺめれ]る$'
+  AND word NOT IN ('帰る', '入る', 'はいる', '要る', '切る', '知る', 'しる', '走る', 'はしる', '減る', 'へる',
+                   '限る', '喋る', 'しゃべる', '滑る', 'すべる', '握る', 'にぎる', '参る', 'まいる', '散る', 'ちる',
+                   '照る', '蹴る', 'ける', '焦る', 'あせる', '湿る', '茂る', 'しげる', '練る',
+                   '混じる', 'まじる', '覆る', '遮る', 'さえぎる', '陥る', '罵る', '甦る', '蘇る', 'よみがえる', '捻る', 'ひねる');
+
+-- Remaining verbs are godan
+UPDATE vocabulary SET part_of_speech = 'godan verb'
+WHERE LOWER(part_of_speech) = 'verb';
+
+-- Adjectives ending in い are い-adjectives, except for a few な-adjectives
+UPDATE vocabulary SET part_of_speech = CASE
+    WHEN word LIKE '%い'
+         AND word NOT IN ('きれい', '綺麗', '奇麗', '嫌い', 'きらい', '大嫌い', 'だいきらい', 'ていねい', 'しつれい')
+    THEN 'i-adjective'
+    ELSE 'na-adjective'
+END
+WHERE LOWER(part_of_speech) = 'adjective';
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('015_refine_part_of_speech')
+ON CONFLICT (version) DO NOTHING;
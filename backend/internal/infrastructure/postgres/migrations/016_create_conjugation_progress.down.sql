@@ -0,0 +1,5 @@
+-- Drop conjugation drill progress table
+DROP TABLE IF EXISTS user_conjugation_progress;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '016_create_conjugation_progress';
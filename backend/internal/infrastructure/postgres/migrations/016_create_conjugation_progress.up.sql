@@ -0,0 +1,25 @@
+-- Create conjugation drill progress table (one SM-2 schedule per word and form)
+CREATE TABLE IF NOT EXISTS user_conjugation_progress (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vocabulary_id INTEGER NOT NULL REFERENCES vocabulary(id) ON DELETE CASCADE,
+    form VARCHAR(30) NOT NULL,
+    ease_factor DECIMAL(3,2) DEFAULT 2.5,
+    interval INTEGER DEFAULT 1,
+    repetitions INTEGER DEFAULT 0,
+    next_review_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_reviewed_at TIMESTAMP WITH TIME ZONE,
+    total_reviews INTEGER DEFAULT 0,
+    correct_reviews INTEGER DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_user_conjugation UNIQUE(user_id, vocabulary_id, form)
+);
+
+-- Create indexes for conjugation progress
+CREATE INDEX idx_ucp_vocabulary_id ON user_conjugation_progress(vocabulary_id);
+CREATE INDEX idx_ucp_next_review ON user_conjugation_progress(user_id, next_review_date);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('016_create_conjugation_progress')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,7 @@
+-- Drop generated quizzes, then the owner column
+DELETE FROM quizzes WHERE owner_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_quizzes_owner_id;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS owner_id;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '017_add_quiz_owner';
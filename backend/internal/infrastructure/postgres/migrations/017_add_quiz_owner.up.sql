@@ -0,0 +1,10 @@
+-- Add the owner of generated quizzes (authored quizzes have none)
+ALTER TABLE quizzes
+    ADD COLUMN IF NOT EXISTS owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+
+-- Create index for generated quizzes
+CREATE INDEX IF NOT EXISTS idx_quizzes_owner_id ON quizzes(owner_id) WHERE owner_id IS NOT NULL;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('017_add_quiz_owner')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,7 @@
+-- Drop the shuffle seed of sessions and the shuffle settings of quizzes
+ALTER TABLE quiz_sessions DROP COLUMN IF EXISTS shuffle_seed;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS shuffle_options;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS shuffle_questions;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '018_add_quiz_shuffle';
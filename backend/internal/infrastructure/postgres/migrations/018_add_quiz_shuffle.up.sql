@@ -0,0 +1,12 @@
+-- Add per-quiz settings to shuffle the questions and their options
+ALTER TABLE quizzes
+    ADD COLUMN IF NOT EXISTS shuffle_questions BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS shuffle_options BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Add the seed of the order a session shows (null when nothing is shuffled)
+ALTER TABLE quiz_sessions
+    ADD COLUMN IF NOT EXISTS shuffle_seed BIGINT;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('018_add_quiz_shuffle')
+ON CONFLICT (version) DO NOTHING;
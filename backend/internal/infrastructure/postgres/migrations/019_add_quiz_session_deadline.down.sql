@@ -0,0 +1,6 @@
+-- Drop the deadline of quiz sessions
+DROP INDEX IF EXISTS idx_quiz_sessions_open_deadline;
+ALTER TABLE quiz_sessions DROP COLUMN IF EXISTS deadline;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '019_add_quiz_session_deadline';
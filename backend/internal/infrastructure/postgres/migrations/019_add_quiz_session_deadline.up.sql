@@ -0,0 +1,20 @@
+-- Add the deadline of sessions of timed quizzes
+ALTER TABLE quiz_sessions
+    ADD COLUMN IF NOT EXISTS deadline TIMESTAMP WITH TIME ZONE;
+
+-- Set the deadline of sessions of timed quizzes still in progress
+UPDATE quiz_sessions s
+SET deadline = s.started_at + q.time_limit_minutes * INTERVAL '1 minute'
+FROM quizzes q
+WHERE q.id = s.quiz_id
+  AND s.completed_at IS NULL
+  AND s.deadline IS NULL
+  AND q.time_limit_minutes IS NOT NULL;
+
+-- Create index for finding expired sessions
+CREATE INDEX IF NOT EXISTS idx_quiz_sessions_open_deadline ON quiz_sessions(deadline)
+    WHERE completed_at IS NULL AND deadline IS NOT NULL;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('019_add_quiz_session_deadline')
+ON CONFLICT (version) DO NOTHING;
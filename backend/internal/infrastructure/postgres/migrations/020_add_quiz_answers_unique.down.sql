@@ -0,0 +1,5 @@
+-- Drop the unique index on quiz answers
+DROP INDEX IF EXISTS idx_quiz_answers_session_question;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '020_add_quiz_answers_unique';
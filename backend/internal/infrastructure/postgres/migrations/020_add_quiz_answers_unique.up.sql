@@ -0,0 +1,14 @@
+-- Keep only the latest answer to each question of a session
+DELETE FROM quiz_answers a
+USING quiz_answers b
+WHERE a.quiz_session_id = b.quiz_session_id
+  AND a.quiz_question_id = b.quiz_question_id
+  AND a.id < b.id;
+
+-- Create unique index so that answers saved during a session replace each other
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quiz_answers_session_question
+    ON quiz_answers(quiz_session_id, quiz_question_id);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('020_add_quiz_answers_unique')
+ON CONFLICT (version) DO NOTHING;
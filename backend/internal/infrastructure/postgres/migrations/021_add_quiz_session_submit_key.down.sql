@@ -0,0 +1,5 @@
+-- Drop the submit idempotency key of quiz sessions
+ALTER TABLE quiz_sessions DROP COLUMN IF EXISTS submit_key;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '021_add_quiz_session_submit_key';
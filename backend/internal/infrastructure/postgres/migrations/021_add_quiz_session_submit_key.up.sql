@@ -0,0 +1,7 @@
+-- Add the idempotency key of the submit that completed a quiz session
+ALTER TABLE quiz_sessions
+    ADD COLUMN IF NOT EXISTS submit_key VARCHAR(255);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('021_add_quiz_session_submit_key')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,19 @@
+-- Drop the unanswered rows and the points of quiz answers
+DELETE FROM quiz_answers WHERE user_answer IS NULL;
+ALTER TABLE quiz_answers DROP COLUMN IF EXISTS points_awarded;
+
+-- Restore integer scores
+ALTER TABLE quiz_sessions ALTER COLUMN score TYPE INTEGER USING ROUND(score);
+
+-- Drop the sections of quiz questions
+DROP INDEX IF EXISTS idx_quiz_questions_section_id;
+ALTER TABLE quiz_questions DROP COLUMN IF EXISTS section_id;
+DROP TABLE IF EXISTS quiz_sections;
+
+-- Drop the scoring policy of quizzes
+ALTER TABLE quizzes
+    DROP COLUMN IF EXISTS partial_credit,
+    DROP COLUMN IF EXISTS wrong_answer_penalty;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '022_add_quiz_scoring_policy';
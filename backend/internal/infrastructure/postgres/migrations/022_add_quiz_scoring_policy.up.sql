@@ -0,0 +1,42 @@
+-- Add the scoring policy of quizzes
+ALTER TABLE quizzes
+    ADD COLUMN IF NOT EXISTS wrong_answer_penalty DECIMAL(4,2) NOT NULL DEFAULT 0
+        CHECK (wrong_answer_penalty >= 0 AND wrong_answer_penalty <= 1),
+    ADD COLUMN IF NOT EXISTS partial_credit DECIMAL(4,2) NOT NULL DEFAULT 0
+        CHECK (partial_credit >= 0 AND partial_credit <= 1);
+
+-- Create quiz sections table
+CREATE TABLE IF NOT EXISTS quiz_sections (
+    id SERIAL PRIMARY KEY,
+    quiz_id INTEGER NOT NULL REFERENCES quizzes(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    weight DECIMAL(6,2) NOT NULL DEFAULT 1 CHECK (weight > 0),
+    section_order INTEGER,
+    UNIQUE (quiz_id, name)
+);
+
+-- Add the section of quiz questions
+ALTER TABLE quiz_questions
+    ADD COLUMN IF NOT EXISTS section_id INTEGER REFERENCES quiz_sections(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_quiz_questions_section_id ON quiz_questions(section_id);
+
+-- Scores may be fractional under partial credit and negative marking
+ALTER TABLE quiz_sessions ALTER COLUMN score TYPE DECIMAL(8,2);
+
+-- Add the points each answer earned
+ALTER TABLE quiz_answers
+    ADD COLUMN IF NOT EXISTS points_awarded DECIMAL(8,2);
+
+-- Sessions completed before this migration keep the score, total, percentage
+-- and pass mark they were given, which counted the answered questions only.
+-- Their answers get the points that score gave them, all or nothing.
+UPDATE quiz_answers a
+SET points_awarded = CASE WHEN a.is_correct THEN q.points ELSE 0 END
+FROM quiz_questions q
+WHERE q.id = a.quiz_question_id
+  AND a.points_awarded IS NULL;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('022_add_quiz_scoring_policy')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,5 @@
+-- Drop the self-graded flag of reviews
+ALTER TABLE vocabulary_review_log DROP COLUMN IF EXISTS self_graded;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '023_add_review_log_self_graded';
@@ -0,0 +1,7 @@
+-- Mark the reviews graded by the learner rather than checked by the server
+ALTER TABLE vocabulary_review_log
+    ADD COLUMN IF NOT EXISTS self_graded BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('023_add_review_log_self_graded')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,6 @@
+-- Drop vocabulary prefix search indexes
+DROP INDEX IF EXISTS idx_vocabulary_reading_pattern;
+DROP INDEX IF EXISTS idx_vocabulary_word_pattern;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '024_add_vocabulary_prefix_indexes';
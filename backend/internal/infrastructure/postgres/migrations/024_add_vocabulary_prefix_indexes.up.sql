@@ -0,0 +1,10 @@
+-- Serve prefix searches on word and reading. The trigram indexes of migration
+-- 006 need three characters to match a pattern, so they cannot serve one and
+-- two character queries, which only match by exact spelling and prefix.
+-- text_pattern_ops lets LIKE 'prefix%' use a btree whatever the collation.
+CREATE INDEX IF NOT EXISTS idx_vocabulary_word_pattern ON vocabulary (word text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_vocabulary_reading_pattern ON vocabulary (reading text_pattern_ops);
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('024_add_vocabulary_prefix_indexes')
+ON CONFLICT (version) DO NOTHING;
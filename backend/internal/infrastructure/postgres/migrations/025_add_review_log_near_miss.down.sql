@@ -0,0 +1,6 @@
+-- Drop the near misses, which did not change the schedule, then their flag
+DELETE FROM vocabulary_review_log WHERE near_miss;
+ALTER TABLE vocabulary_review_log DROP COLUMN IF EXISTS near_miss;
+
+-- Remove migration record
+DELETE FROM schema_migrations WHERE version = '025_add_review_log_near_miss';
@@ -0,0 +1,8 @@
+-- Mark the near-miss typed answers, which are logged without changing the
+-- schedule and cap the quality of the retry that follows
+ALTER TABLE vocabulary_review_log
+    ADD COLUMN IF NOT EXISTS near_miss BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version) VALUES ('025_add_review_log_near_miss')
+ON CONFLICT (version) DO NOTHING;
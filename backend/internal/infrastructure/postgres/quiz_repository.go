@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/joaosantos/jlpt5/internal/domain/models"
 	"github.com/joaosantos/jlpt5/internal/domain/repository"
@@ -11,24 +12,33 @@ import (
 	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
 )
 
+// querier runs queries on the database or within a transaction
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
 type quizRepository struct {
 	db *database.DB
+	q  querier // db, or the transaction holding the lock on a session
 }
 
 func NewQuizRepository(db *database.DB) repository.QuizRepository {
-	return &quizRepository{db: db}
+	return &quizRepository{db: db, q: db}
 }
 
 func (r *quizRepository) GetAllQuizzes(ctx context.Context, jlptLevel *int, limit, offset int) ([]models.Quiz, error) {
 	query := `
-		SELECT id, title, description, jlpt_level, quiz_type, passing_score, created_at, updated_at
+		SELECT id, title, description, jlpt_level, quiz_type, time_limit_minutes, passing_score, owner_id,
+		       shuffle_questions, shuffle_options, wrong_answer_penalty, partial_credit, created_at, updated_at
 		FROM quizzes
-		WHERE ($1::int IS NULL OR jlpt_level = $1)
+		WHERE owner_id IS NULL AND ($1::int IS NULL OR jlpt_level = $1)
 		ORDER BY id
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, jlptLevel, limit, offset)
+	rows, err := r.q.QueryContext(ctx, query, jlptLevel, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("error querying quizzes: %w", err)
 	}
@@ -37,8 +47,9 @@ func (r *quizRepository) GetAllQuizzes(ctx context.Context, jlptLevel *int, limi
 	var quizzes []models.Quiz
 	for rows.Next() {
 		var q models.Quiz
-		err := rows.Scan(&q.ID, &q.Title, &q.Description, &q.JLPTLevel, &q.QuizType,
-			&q.PassingScore, &q.CreatedAt, &q.UpdatedAt)
+		err := rows.Scan(&q.ID, &q.Title, &q.Description, &q.JLPTLevel, &q.QuizType, &q.TimeLimitMinutes,
+			&q.PassingScore, &q.OwnerID, &q.ShuffleQuestions, &q.ShuffleOptions, &q.WrongAnswerPenalty,
+			&q.PartialCredit, &q.CreatedAt, &q.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning quiz: %w", err)
 		}
@@ -50,15 +61,17 @@ func (r *quizRepository) GetAllQuizzes(ctx context.Context, jlptLevel *int, limi
 
 func (r *quizRepository) GetQuizByID(ctx context.Context, quizID int) (*models.Quiz, error) {
 	query := `
-		SELECT id, title, description, jlpt_level, quiz_type, passing_score, created_at, updated_at
+		SELECT id, title, description, jlpt_level, quiz_type, time_limit_minutes, passing_score, owner_id,
+		       shuffle_questions, shuffle_options, wrong_answer_penalty, partial_credit, created_at, updated_at
 		FROM quizzes
 		WHERE id = $1
 	`
 
 	quiz := &models.Quiz{}
-	err := r.db.QueryRowContext(ctx, query, quizID).Scan(
-		&quiz.ID, &quiz.Title, &quiz.Description, &quiz.JLPTLevel, &quiz.QuizType,
-		&quiz.PassingScore, &quiz.CreatedAt, &quiz.UpdatedAt,
+	err := r.q.QueryRowContext(ctx, query, quizID).Scan(
+		&quiz.ID, &quiz.Title, &quiz.Description, &quiz.JLPTLevel, &quiz.QuizType, &quiz.TimeLimitMinutes,
+		&quiz.PassingScore, &quiz.OwnerID, &quiz.ShuffleQuestions, &quiz.ShuffleOptions,
+		&quiz.WrongAnswerPenalty, &quiz.PartialCredit, &quiz.CreatedAt, &quiz.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -74,13 +87,13 @@ func (r *quizRepository) GetQuizByID(ctx context.Context, quizID int) (*models.Q
 func (r *quizRepository) GetQuizQuestions(ctx context.Context, quizID int) ([]models.QuizQuestion, error) {
 	query := `
 		SELECT id, quiz_id, question_text, question_type, correct_answer, option_a,
-		       option_b, option_c, option_d, explanation, points, question_order, created_at
+		       option_b, option_c, option_d, explanation, points, question_order, section_id, created_at
 		FROM quiz_questions
 		WHERE quiz_id = $1
 		ORDER BY question_order, id
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, quizID)
+	rows, err := r.q.QueryContext(ctx, query, quizID)
 	if err != nil {
 		return nil, fmt.Errorf("error querying quiz questions: %w", err)
 	}
@@ -90,7 +103,8 @@ func (r *quizRepository) GetQuizQuestions(ctx context.Context, quizID int) ([]mo
 	for rows.Next() {
 		var q models.QuizQuestion
 		err := rows.Scan(&q.ID, &q.QuizID, &q.QuestionText, &q.QuestionType, &q.CorrectAnswer,
-			&q.OptionA, &q.OptionB, &q.OptionC, &q.OptionD, &q.Explanation, &q.Points, &q.QuestionOrder, &q.CreatedAt)
+			&q.OptionA, &q.OptionB, &q.OptionC, &q.OptionD, &q.Explanation, &q.Points, &q.QuestionOrder,
+			&q.SectionID, &q.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning quiz question: %w", err)
 		}
@@ -100,15 +114,83 @@ func (r *quizRepository) GetQuizQuestions(ctx context.Context, quizID int) ([]mo
 	return questions, rows.Err()
 }
 
+func (r *quizRepository) GetQuizSections(ctx context.Context, quizID int) ([]models.QuizSection, error) {
+	query := `
+		SELECT id, quiz_id, name, weight, section_order
+		FROM quiz_sections
+		WHERE quiz_id = $1
+		ORDER BY section_order, id
+	`
+
+	rows, err := r.q.QueryContext(ctx, query, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying quiz sections: %w", err)
+	}
+	defer rows.Close()
+
+	var sections []models.QuizSection
+	for rows.Next() {
+		var section models.QuizSection
+		err := rows.Scan(&section.ID, &section.QuizID, &section.Name, &section.Weight, &section.SectionOrder)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning quiz section: %w", err)
+		}
+		sections = append(sections, section)
+	}
+
+	return sections, rows.Err()
+}
+
+func (r *quizRepository) CreateQuiz(ctx context.Context, quiz *models.Quiz, questions []models.QuizQuestion) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO quizzes (title, description, quiz_type, jlpt_level, time_limit_minutes, passing_score, owner_id,
+			                     shuffle_questions, shuffle_options, wrong_answer_penalty, partial_credit)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING id, created_at, updated_at
+		`
+
+		err := tx.QueryRowContext(ctx, query,
+			quiz.Title, quiz.Description, quiz.QuizType, quiz.JLPTLevel, quiz.TimeLimitMinutes,
+			quiz.PassingScore, quiz.OwnerID, quiz.ShuffleQuestions, quiz.ShuffleOptions,
+			quiz.WrongAnswerPenalty, quiz.PartialCredit,
+		).Scan(&quiz.ID, &quiz.CreatedAt, &quiz.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("error creating quiz: %w", err)
+		}
+
+		query = `
+			INSERT INTO quiz_questions (quiz_id, question_type, question_text, correct_answer, option_a,
+			                            option_b, option_c, option_d, explanation, points, question_order, section_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING id, created_at
+		`
+
+		for i := range questions {
+			q := &questions[i]
+			q.QuizID = quiz.ID
+			err := tx.QueryRowContext(ctx, query,
+				q.QuizID, q.QuestionType, q.QuestionText, q.CorrectAnswer, q.OptionA,
+				q.OptionB, q.OptionC, q.OptionD, q.Explanation, q.Points, q.QuestionOrder, q.SectionID,
+			).Scan(&q.ID, &q.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("error creating quiz question: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
 func (r *quizRepository) CreateQuizSession(ctx context.Context, session *models.QuizSession) error {
 	query := `
-		INSERT INTO quiz_sessions (user_id, quiz_id, started_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO quiz_sessions (user_id, quiz_id, started_at, shuffle_seed, deadline)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id
 	`
 
-	err := r.db.QueryRowContext(ctx, query,
-		session.UserID, session.QuizID, session.StartedAt,
+	err := r.q.QueryRowContext(ctx, query,
+		session.UserID, session.QuizID, session.StartedAt, session.ShuffleSeed, session.Deadline,
 	).Scan(&session.ID)
 
 	if err != nil {
@@ -122,13 +204,13 @@ func (r *quizRepository) UpdateQuizSession(ctx context.Context, session *models.
 	query := `
 		UPDATE quiz_sessions
 		SET completed_at = $1, score = $2, total_points = $3,
-		    percentage = $4, passed = $5, time_spent_seconds = $6
-		WHERE id = $7
+		    percentage = $4, passed = $5, time_spent_seconds = $6, submit_key = $7
+		WHERE id = $8
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := r.q.ExecContext(ctx, query,
 		session.CompletedAt, session.Score, session.TotalPoints,
-		session.Percentage, session.Passed, session.TimeSpentSeconds, session.ID,
+		session.Percentage, session.Passed, session.TimeSpentSeconds, session.SubmitKey, session.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("error updating quiz session: %w", err)
@@ -149,16 +231,17 @@ func (r *quizRepository) UpdateQuizSession(ctx context.Context, session *models.
 func (r *quizRepository) GetQuizSession(ctx context.Context, sessionID int) (*models.QuizSession, error) {
 	query := `
 		SELECT id, user_id, quiz_id, started_at, completed_at, score,
-		       total_points, percentage, passed, time_spent_seconds
+		       total_points, percentage, passed, time_spent_seconds, shuffle_seed, deadline, submit_key
 		FROM quiz_sessions
 		WHERE id = $1
 	`
 
 	session := &models.QuizSession{}
-	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(
+	err := r.q.QueryRowContext(ctx, query, sessionID).Scan(
 		&session.ID, &session.UserID, &session.QuizID, &session.StartedAt,
 		&session.CompletedAt, &session.Score, &session.TotalPoints,
-		&session.Percentage, &session.Passed, &session.TimeSpentSeconds,
+		&session.Percentage, &session.Passed, &session.TimeSpentSeconds, &session.ShuffleSeed,
+		&session.Deadline, &session.SubmitKey,
 	)
 
 	if err == sql.ErrNoRows {
@@ -171,19 +254,48 @@ func (r *quizRepository) GetQuizSession(ctx context.Context, sessionID int) (*mo
 	return session, nil
 }
 
-func (r *quizRepository) GetUserQuizSessions(ctx context.Context, userID int, limit, offset int) ([]models.QuizSession, error) {
+func (r *quizRepository) WithLockedQuizSession(ctx context.Context, sessionID int, fn func(repo repository.QuizRepository, session *models.QuizSession) error) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		query := `
+			SELECT id, user_id, quiz_id, started_at, completed_at, score,
+			       total_points, percentage, passed, time_spent_seconds, shuffle_seed, deadline, submit_key
+			FROM quiz_sessions
+			WHERE id = $1
+			FOR UPDATE
+		`
+
+		session := &models.QuizSession{}
+		err := tx.QueryRowContext(ctx, query, sessionID).Scan(
+			&session.ID, &session.UserID, &session.QuizID, &session.StartedAt,
+			&session.CompletedAt, &session.Score, &session.TotalPoints,
+			&session.Percentage, &session.Passed, &session.TimeSpentSeconds, &session.ShuffleSeed,
+			&session.Deadline, &session.SubmitKey,
+		)
+
+		if err == sql.ErrNoRows {
+			return pkgErrors.NotFound("Quiz session not found")
+		}
+		if err != nil {
+			return fmt.Errorf("error locking quiz session: %w", err)
+		}
+
+		return fn(&quizRepository{db: r.db, q: tx}, session)
+	})
+}
+
+func (r *quizRepository) GetExpiredQuizSessions(ctx context.Context, before time.Time, limit int) ([]models.QuizSession, error) {
 	query := `
 		SELECT id, user_id, quiz_id, started_at, completed_at, score,
-		       total_points, percentage, passed, time_spent_seconds
+		       total_points, percentage, passed, time_spent_seconds, shuffle_seed, deadline
 		FROM quiz_sessions
-		WHERE user_id = $1
-		ORDER BY started_at DESC
-		LIMIT $2 OFFSET $3
+		WHERE completed_at IS NULL AND deadline IS NOT NULL AND deadline < $1
+		ORDER BY deadline
+		LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.q.QueryContext(ctx, query, before, limit)
 	if err != nil {
-		return nil, fmt.Errorf("error querying user quiz sessions: %w", err)
+		return nil, fmt.Errorf("error querying expired quiz sessions: %w", err)
 	}
 	defer rows.Close()
 
@@ -192,7 +304,7 @@ func (r *quizRepository) GetUserQuizSessions(ctx context.Context, userID int, li
 		var s models.QuizSession
 		err := rows.Scan(&s.ID, &s.UserID, &s.QuizID, &s.StartedAt,
 			&s.CompletedAt, &s.Score, &s.TotalPoints,
-			&s.Percentage, &s.Passed, &s.TimeSpentSeconds)
+			&s.Percentage, &s.Passed, &s.TimeSpentSeconds, &s.ShuffleSeed, &s.Deadline)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning quiz session: %w", err)
 		}
@@ -202,20 +314,102 @@ func (r *quizRepository) GetUserQuizSessions(ctx context.Context, userID int, li
 	return sessions, rows.Err()
 }
 
-func (r *quizRepository) CreateQuizAnswer(ctx context.Context, answer *models.QuizAnswer) error {
+func (r *quizRepository) DeleteStaleGeneratedQuizzes(ctx context.Context, before time.Time, limit int) (int, error) {
+	// Locked quizzes are being started and are left for the next sweep
 	query := `
-		INSERT INTO quiz_answers (quiz_session_id, quiz_question_id, user_answer, is_correct, answered_at)
-		VALUES ($1, $2, $3, $4, $5)
+		DELETE FROM quizzes
+		WHERE id IN (
+			SELECT q.id FROM quizzes q
+			WHERE q.owner_id IS NOT NULL AND q.created_at < $1
+			  AND NOT EXISTS (
+			      SELECT 1 FROM quiz_sessions s
+			      WHERE s.quiz_id = q.id AND s.completed_at IS NULL)
+			ORDER BY q.id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED)
+	`
+
+	result, err := r.q.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting stale generated quizzes: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// quizSessionSortColumns is the whitelist of quiz history sort fields
+var quizSessionSortColumns = sortColumns{
+	repository.SortByStartedAt: {Expr: "started_at", Type: "timestamptz"},
+}
+
+func (r *quizRepository) GetUserQuizSessions(ctx context.Context, userID int, after *repository.Cursor, limit, offset int) ([]models.QuizSession, *repository.Cursor, error) {
+	afterCondition, afterArgs := keyset(after, repository.QuizHistorySort, quizSessionSortColumns, repository.SortByStartedAt, "id", 4)
+
+	query := `
+		SELECT id, user_id, quiz_id, started_at, completed_at, score,
+		       total_points, percentage, passed, time_spent_seconds, shuffle_seed, deadline,
+		       ` + sortKeyColumn(repository.QuizHistorySort, quizSessionSortColumns, repository.SortByStartedAt) + `
+		FROM quiz_sessions
+		WHERE user_id = $1` + afterCondition + `
+		ORDER BY ` + orderBy(repository.QuizHistorySort, quizSessionSortColumns, repository.SortByStartedAt, "id") + `
+		LIMIT $2 OFFSET $3
+	`
+
+	// Fetch one extra row to know whether there is a next page
+	args := append([]interface{}{userID, limit + 1, offset}, afterArgs...)
+	rows, err := r.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error querying user quiz sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []models.QuizSession
+	var keys sortKeys
+	for rows.Next() {
+		var s models.QuizSession
+		err := rows.Scan(&s.ID, &s.UserID, &s.QuizID, &s.StartedAt,
+			&s.CompletedAt, &s.Score, &s.TotalPoints,
+			&s.Percentage, &s.Passed, &s.TimeSpentSeconds, &s.ShuffleSeed, &s.Deadline, &keys)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error scanning quiz session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *repository.Cursor
+	if len(sessions) > limit {
+		sessions = sessions[:limit]
+		next = keys.cursor(repository.QuizHistorySort, limit-1, sessions[limit-1].ID)
+	}
+
+	return sessions, next, nil
+}
+
+func (r *quizRepository) SaveQuizAnswer(ctx context.Context, answer *models.QuizAnswer) error {
+	query := `
+		INSERT INTO quiz_answers (quiz_session_id, quiz_question_id, user_answer, is_correct, points_awarded, answered_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (quiz_session_id, quiz_question_id)
+		DO UPDATE SET user_answer = EXCLUDED.user_answer, is_correct = EXCLUDED.is_correct,
+		              points_awarded = EXCLUDED.points_awarded, answered_at = EXCLUDED.answered_at
 		RETURNING id
 	`
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := r.q.QueryRowContext(ctx, query,
 		answer.QuizSessionID, answer.QuizQuestionID, answer.UserAnswer,
-		answer.IsCorrect, answer.AnsweredAt,
+		answer.IsCorrect, answer.PointsAwarded, answer.AnsweredAt,
 	).Scan(&answer.ID)
 
 	if err != nil {
-		return fmt.Errorf("error creating quiz answer: %w", err)
+		return fmt.Errorf("error saving quiz answer: %w", err)
 	}
 
 	return nil
@@ -223,13 +417,13 @@ func (r *quizRepository) CreateQuizAnswer(ctx context.Context, answer *models.Qu
 
 func (r *quizRepository) GetSessionAnswers(ctx context.Context, sessionID int) ([]models.QuizAnswer, error) {
 	query := `
-		SELECT id, quiz_session_id, quiz_question_id, user_answer, is_correct, answered_at
+		SELECT id, quiz_session_id, quiz_question_id, user_answer, is_correct, points_awarded, answered_at
 		FROM quiz_answers
 		WHERE quiz_session_id = $1
 		ORDER BY id
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, sessionID)
+	rows, err := r.q.QueryContext(ctx, query, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("error querying session answers: %w", err)
 	}
@@ -239,7 +433,7 @@ func (r *quizRepository) GetSessionAnswers(ctx context.Context, sessionID int) (
 	for rows.Next() {
 		var a models.QuizAnswer
 		err := rows.Scan(&a.ID, &a.QuizSessionID, &a.QuizQuestionID, &a.UserAnswer,
-			&a.IsCorrect, &a.AnsweredAt)
+			&a.IsCorrect, &a.PointsAwarded, &a.AnsweredAt)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning quiz answer: %w", err)
 		}
@@ -253,14 +447,30 @@ func (r *quizRepository) CountQuizzes(ctx context.Context, jlptLevel *int) (int,
 	query := `
 		SELECT COUNT(*)
 		FROM quizzes
-		WHERE ($1::int IS NULL OR jlpt_level = $1)
+		WHERE owner_id IS NULL AND ($1::int IS NULL OR jlpt_level = $1)
 	`
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, jlptLevel).Scan(&count)
+	err := r.q.QueryRowContext(ctx, query, jlptLevel).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("error counting quizzes: %w", err)
 	}
 
 	return count, nil
 }
+
+func (r *quizRepository) CountUserQuizSessions(ctx context.Context, userID int) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM quiz_sessions
+		WHERE user_id = $1
+	`
+
+	var count int
+	err := r.q.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting user quiz sessions: %w", err)
+	}
+
+	return count, nil
+}
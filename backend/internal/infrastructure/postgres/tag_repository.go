@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/joaosantos/jlpt5/internal/domain/models"
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
+	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type tagRepository struct {
+	db *database.DB
+}
+
+func NewTagRepository(db *database.DB) repository.TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) CreateTag(ctx context.Context, tag *models.Tag) error {
+	query := `
+		INSERT INTO tags (user_id, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, tag.UserID, tag.Name).Scan(&tag.ID, &tag.CreatedAt)
+
+	if isUniqueViolation(err) {
+		return pkgErrors.Conflict("Tag already exists")
+	}
+	if err != nil {
+		return fmt.Errorf("error creating tag: %w", err)
+	}
+
+	return nil
+}
+
+func (r *tagRepository) GetTagByID(ctx context.Context, tagID int) (*models.Tag, error) {
+	query := `
+		SELECT t.id, t.user_id, t.name,
+		       (SELECT COUNT(*) FROM vocabulary_tags vt WHERE vt.tag_id = t.id),
+		       t.created_at
+		FROM tags t
+		WHERE t.id = $1
+	`
+
+	t := &models.Tag{}
+	err := r.db.QueryRowContext(ctx, query, tagID).Scan(&t.ID, &t.UserID, &t.Name, &t.ItemCount, &t.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, pkgErrors.NotFound("Tag not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting tag: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *tagRepository) GetUserTags(ctx context.Context, userID int) ([]models.Tag, error) {
+	query := `
+		SELECT t.id, t.user_id, t.name, COUNT(vt.vocabulary_id), t.created_at
+		FROM tags t
+		LEFT JOIN vocabulary_tags vt ON vt.tag_id = t.id
+		WHERE t.user_id = $1
+		GROUP BY t.id
+		ORDER BY t.name, t.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		var t models.Tag
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Name, &t.ItemCount, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning tag: %w", err)
+		}
+		tags = append(tags, t)
+	}
+
+	return tags, rows.Err()
+}
+
+func (r *tagRepository) DeleteTag(ctx context.Context, tagID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tags WHERE id = $1`, tagID)
+	if err != nil {
+		return fmt.Errorf("error deleting tag: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return pkgErrors.NotFound("Tag not found")
+	}
+
+	return nil
+}
+
+func (r *tagRepository) TagItems(ctx context.Context, tagID int, vocabularyIDs []int) (int, error) {
+	query := `
+		INSERT INTO vocabulary_tags (tag_id, vocabulary_id)
+		SELECT $1, v.id
+		FROM vocabulary v
+		WHERE v.id = ANY($2::int[])
+		  AND (v.visibility = 'public' OR v.owner_id = (SELECT user_id FROM tags WHERE id = $1))
+		ON CONFLICT (tag_id, vocabulary_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, tagID, pq.Array(vocabularyIDs))
+	if err != nil {
+		return 0, fmt.Errorf("error tagging vocabulary: %w", err)
+	}
+
+	tagged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	return int(tagged), nil
+}
+
+func (r *tagRepository) UntagItem(ctx context.Context, tagID, vocabularyID int) error {
+	query := `DELETE FROM vocabulary_tags WHERE tag_id = $1 AND vocabulary_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, tagID, vocabularyID)
+	if err != nil {
+		return fmt.Errorf("error untagging vocabulary: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return pkgErrors.NotFound("Vocabulary item does not have this tag")
+	}
+
+	return nil
+}
@@ -50,7 +50,7 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 // GetByID retrieves a user by ID
 func (r *userRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
 	query := `
-		SELECT id, email, username, password_hash, created_at, updated_at, last_login_at, is_active
+		SELECT id, email, username, password_hash, created_at, updated_at, last_login_at, is_active, is_admin
 		FROM users
 		WHERE id = $1 AND is_active = true
 	`
@@ -58,7 +58,7 @@ func (r *userRepository) GetByID(ctx context.Context, id int) (*models.User, err
 	user := &models.User{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&user.ID, &user.Email, &user.Username, &user.PasswordHash,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.IsActive,
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.IsActive, &user.IsAdmin,
 	)
 
 	if err == sql.ErrNoRows {
@@ -74,7 +74,7 @@ func (r *userRepository) GetByID(ctx context.Context, id int) (*models.User, err
 // GetByEmail retrieves a user by email
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, email, username, password_hash, created_at, updated_at, last_login_at, is_active
+		SELECT id, email, username, password_hash, created_at, updated_at, last_login_at, is_active, is_admin
 		FROM users
 		WHERE email = $1
 	`
@@ -82,7 +82,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 	user := &models.User{}
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
 		&user.ID, &user.Email, &user.Username, &user.PasswordHash,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.IsActive,
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.IsActive, &user.IsAdmin,
 	)
 
 	if err == sql.ErrNoRows {
@@ -98,7 +98,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 // GetByUsername retrieves a user by username
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
 	query := `
-		SELECT id, email, username, password_hash, created_at, updated_at, last_login_at, is_active
+		SELECT id, email, username, password_hash, created_at, updated_at, last_login_at, is_active, is_admin
 		FROM users
 		WHERE username = $1
 	`
@@ -106,7 +106,7 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*m
 	user := &models.User{}
 	err := r.db.QueryRowContext(ctx, query, username).Scan(
 		&user.ID, &user.Email, &user.Username, &user.PasswordHash,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.IsActive,
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.IsActive, &user.IsAdmin,
 	)
 
 	if err == sql.ErrNoRows {
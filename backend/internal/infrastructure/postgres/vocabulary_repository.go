@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/joaosantos/jlpt5/internal/domain/models"
 	"github.com/joaosantos/jlpt5/internal/domain/repository"
 	"github.com/joaosantos/jlpt5/internal/infrastructure/database"
 	pkgErrors "github.com/joaosantos/jlpt5/pkg/errors"
+	"github.com/lib/pq"
 )
 
 type vocabularyRepository struct {
@@ -22,9 +25,10 @@ func NewVocabularyRepository(db *database.DB) repository.VocabularyRepository {
 func (r *vocabularyRepository) GetAll(ctx context.Context, jlptLevel *int, limit, offset int) ([]models.Vocabulary, error) {
 	query := `
 		SELECT id, word, reading, meaning, part_of_speech, jlpt_level,
-		       example_sentence, example_translation, audio_url, created_at, updated_at
+		       example_sentence, example_translation, audio_url, tags,
+		       owner_id, visibility, suggested_for_inclusion, created_at, updated_at
 		FROM vocabulary
-		WHERE ($1::int IS NULL OR jlpt_level = $1)
+		WHERE visibility = 'public' AND ($1::int IS NULL OR jlpt_level = $1)
 		ORDER BY id
 		LIMIT $2 OFFSET $3
 	`
@@ -40,7 +44,8 @@ func (r *vocabularyRepository) GetAll(ctx context.Context, jlptLevel *int, limit
 		var v models.Vocabulary
 		err := rows.Scan(
 			&v.ID, &v.Word, &v.Reading, &v.Meaning, &v.PartOfSpeech, &v.JLPTLevel,
-			&v.ExampleSentence, &v.ExampleTranslation, &v.AudioURL, &v.CreatedAt, &v.UpdatedAt,
+			&v.ExampleSentence, &v.ExampleTranslation, &v.AudioURL, pq.Array(&v.Tags),
+			&v.OwnerID, &v.Visibility, &v.SuggestedForInclusion, &v.CreatedAt, &v.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning vocabulary: %w", err)
@@ -51,10 +56,75 @@ func (r *vocabularyRepository) GetAll(ctx context.Context, jlptLevel *int, limit
 	return items, rows.Err()
 }
 
+func (r *vocabularyRepository) GetRandom(ctx context.Context, jlptLevel, limit int) ([]models.Vocabulary, error) {
+	query := `
+		SELECT id, word, reading, meaning, part_of_speech, jlpt_level,
+		       example_sentence, example_translation, audio_url, tags,
+		       owner_id, visibility, suggested_for_inclusion, created_at, updated_at
+		FROM vocabulary
+		WHERE visibility = 'public' AND jlpt_level = $1
+		ORDER BY random()
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, jlptLevel, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying random vocabulary: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.Vocabulary
+	for rows.Next() {
+		var v models.Vocabulary
+		if err := rows.Scan(vocabularyFields(&v)...); err != nil {
+			return nil, fmt.Errorf("error scanning vocabulary: %w", err)
+		}
+		items = append(items, v)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *vocabularyRepository) FindByTerms(ctx context.Context, terms []string, limit int) ([]models.Vocabulary, error) {
+	patterns := make([]string, len(terms))
+	for i, term := range terms {
+		patterns[i] = "%" + escapeLike(term) + "%"
+	}
+
+	query := `
+		SELECT id, word, reading, meaning, part_of_speech, jlpt_level,
+		       example_sentence, example_translation, audio_url, tags,
+		       owner_id, visibility, suggested_for_inclusion, created_at, updated_at
+		FROM vocabulary
+		WHERE visibility = 'public'
+		  AND (word = ANY($1::text[]) OR reading = ANY($1::text[]) OR meaning ILIKE ANY($2::text[]))
+		ORDER BY jlpt_level DESC, id
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(terms), pq.Array(patterns), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying vocabulary by terms: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.Vocabulary
+	for rows.Next() {
+		var v models.Vocabulary
+		if err := rows.Scan(vocabularyFields(&v)...); err != nil {
+			return nil, fmt.Errorf("error scanning vocabulary: %w", err)
+		}
+		items = append(items, v)
+	}
+
+	return items, rows.Err()
+}
+
 func (r *vocabularyRepository) GetByID(ctx context.Context, id int) (*models.Vocabulary, error) {
 	query := `
 		SELECT id, word, reading, meaning, part_of_speech, jlpt_level,
-		       example_sentence, example_translation, audio_url, created_at, updated_at
+		       example_sentence, example_translation, audio_url, tags,
+		       owner_id, visibility, suggested_for_inclusion, created_at, updated_at
 		FROM vocabulary
 		WHERE id = $1
 	`
@@ -62,7 +132,8 @@ func (r *vocabularyRepository) GetByID(ctx context.Context, id int) (*models.Voc
 	v := &models.Vocabulary{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&v.ID, &v.Word, &v.Reading, &v.Meaning, &v.PartOfSpeech, &v.JLPTLevel,
-		&v.ExampleSentence, &v.ExampleTranslation, &v.AudioURL, &v.CreatedAt, &v.UpdatedAt,
+		&v.ExampleSentence, &v.ExampleTranslation, &v.AudioURL, pq.Array(&v.Tags),
+		&v.OwnerID, &v.Visibility, &v.SuggestedForInclusion, &v.CreatedAt, &v.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -75,21 +146,309 @@ func (r *vocabularyRepository) GetByID(ctx context.Context, id int) (*models.Voc
 	return v, nil
 }
 
-func (r *vocabularyRepository) GetDueForReview(ctx context.Context, userID int, limit int) ([]models.VocabularyWithProgress, error) {
+func (r *vocabularyRepository) FindByWordReading(ctx context.Context, userID int, word, reading string) (*models.Vocabulary, error) {
+	query := `
+		SELECT id, word, reading, meaning, part_of_speech, jlpt_level,
+		       example_sentence, example_translation, audio_url, tags,
+		       owner_id, visibility, suggested_for_inclusion, created_at, updated_at
+		FROM vocabulary
+		WHERE word = $1 AND reading = $2 AND (visibility = 'public' OR owner_id = $3)
+		ORDER BY visibility = 'public' DESC, id
+		LIMIT 1
+	`
+
+	v := &models.Vocabulary{}
+	err := r.db.QueryRowContext(ctx, query, word, reading, userID).Scan(
+		&v.ID, &v.Word, &v.Reading, &v.Meaning, &v.PartOfSpeech, &v.JLPTLevel,
+		&v.ExampleSentence, &v.ExampleTranslation, &v.AudioURL, pq.Array(&v.Tags),
+		&v.OwnerID, &v.Visibility, &v.SuggestedForInclusion, &v.CreatedAt, &v.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, pkgErrors.NotFound("Vocabulary not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding vocabulary: %w", err)
+	}
+
+	return v, nil
+}
+
+func (r *vocabularyRepository) FindByWords(ctx context.Context, userID int, words []string) ([]models.Vocabulary, error) {
+	query := `
+		SELECT id, word, reading, meaning, part_of_speech, jlpt_level,
+		       example_sentence, example_translation, audio_url, tags,
+		       owner_id, visibility, suggested_for_inclusion, created_at, updated_at
+		FROM vocabulary
+		WHERE word = ANY($1::text[]) AND (visibility = 'public' OR owner_id = $2)
+		ORDER BY visibility = 'public' DESC, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(words), userID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding vocabulary: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.Vocabulary
+	for rows.Next() {
+		var v models.Vocabulary
+		if err := rows.Scan(
+			&v.ID, &v.Word, &v.Reading, &v.Meaning, &v.PartOfSpeech, &v.JLPTLevel,
+			&v.ExampleSentence, &v.ExampleTranslation, &v.AudioURL, pq.Array(&v.Tags),
+			&v.OwnerID, &v.Visibility, &v.SuggestedForInclusion, &v.CreatedAt, &v.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning vocabulary: %w", err)
+		}
+		items = append(items, v)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *vocabularyRepository) Create(ctx context.Context, vocab *models.Vocabulary) error {
+	query := `
+		INSERT INTO vocabulary (word, reading, meaning, part_of_speech, jlpt_level,
+		                        example_sentence, example_translation, audio_url, tags,
+		                        owner_id, visibility, suggested_for_inclusion)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, created_at, updated_at
+	`
+
+	if vocab.Tags == nil {
+		vocab.Tags = []string{}
+	}
+
+	err := r.db.QueryRowContext(ctx, query,
+		vocab.Word, vocab.Reading, vocab.Meaning, vocab.PartOfSpeech, vocab.JLPTLevel,
+		vocab.ExampleSentence, vocab.ExampleTranslation, vocab.AudioURL, pq.Array(vocab.Tags),
+		vocab.OwnerID, vocab.Visibility, vocab.SuggestedForInclusion,
+	).Scan(&vocab.ID, &vocab.CreatedAt, &vocab.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("error creating vocabulary: %w", err)
+	}
+
+	return nil
+}
+
+func (r *vocabularyRepository) UpdatePrivate(ctx context.Context, userID int, vocab *models.Vocabulary) error {
+	query := `
+		UPDATE vocabulary
+		SET meaning = $1, part_of_speech = $2, jlpt_level = $3,
+		    example_sentence = $4, example_translation = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6 AND owner_id = $7 AND visibility = 'private'
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		vocab.Meaning, vocab.PartOfSpeech, vocab.JLPTLevel,
+		vocab.ExampleSentence, vocab.ExampleTranslation, vocab.ID, userID,
+	).Scan(&vocab.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return pkgErrors.NotFound("Vocabulary not found")
+	}
+	if err != nil {
+		return fmt.Errorf("error updating vocabulary: %w", err)
+	}
+
+	return nil
+}
+
+func (r *vocabularyRepository) DeletePrivate(ctx context.Context, userID, id int) error {
+	query := `DELETE FROM vocabulary WHERE id = $1 AND owner_id = $2 AND visibility = 'private'`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting vocabulary: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return pkgErrors.NotFound("Private vocabulary not found")
+	}
+
+	return nil
+}
+
+func (r *vocabularyRepository) SetSuggested(ctx context.Context, userID, id int, suggested bool) error {
+	query := `
+		UPDATE vocabulary
+		SET suggested_for_inclusion = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND owner_id = $3 AND visibility = 'private'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, suggested, id, userID)
+	if err != nil {
+		return fmt.Errorf("error updating vocabulary suggestion: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return pkgErrors.NotFound("Private vocabulary not found")
+	}
+
+	return nil
+}
+
+func (r *vocabularyRepository) GetSuggested(ctx context.Context, limit, offset int) ([]models.Vocabulary, error) {
+	query := `
+		SELECT id, word, reading, meaning, part_of_speech, jlpt_level,
+		       example_sentence, example_translation, audio_url, tags,
+		       owner_id, visibility, suggested_for_inclusion, created_at, updated_at
+		FROM vocabulary
+		WHERE suggested_for_inclusion AND visibility = 'private'
+		ORDER BY updated_at, id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error querying suggested vocabulary: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.Vocabulary
+	for rows.Next() {
+		var v models.Vocabulary
+		err := rows.Scan(
+			&v.ID, &v.Word, &v.Reading, &v.Meaning, &v.PartOfSpeech, &v.JLPTLevel,
+			&v.ExampleSentence, &v.ExampleTranslation, &v.AudioURL, pq.Array(&v.Tags),
+			&v.OwnerID, &v.Visibility, &v.SuggestedForInclusion, &v.CreatedAt, &v.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning suggested vocabulary: %w", err)
+		}
+		items = append(items, v)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *vocabularyRepository) CountSuggested(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM vocabulary WHERE suggested_for_inclusion AND visibility = 'private'`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting suggested vocabulary: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *vocabularyRepository) Promote(ctx context.Context, id int) (*models.Vocabulary, error) {
+	v := &models.Vocabulary{}
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var word, reading string
+		err := tx.QueryRowContext(ctx,
+			`SELECT word, reading FROM vocabulary WHERE id = $1 AND visibility = 'private' FOR UPDATE`, id,
+		).Scan(&word, &reading)
+		if err == sql.ErrNoRows {
+			return pkgErrors.NotFound("Private vocabulary not found")
+		}
+		if err != nil {
+			return fmt.Errorf("error getting vocabulary to promote: %w", err)
+		}
+
+		var exists bool
+		err = tx.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM vocabulary WHERE visibility = 'public' AND word = $1 AND reading = $2)`,
+			word, reading,
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("error checking public vocabulary: %w", err)
+		}
+		if exists {
+			return pkgErrors.Conflict("A public entry with this word and reading already exists")
+		}
+
+		// The owner is cleared so that deleting their account keeps the entry
+		query := `
+			UPDATE vocabulary
+			SET visibility = 'public', owner_id = NULL, suggested_for_inclusion = FALSE,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1
+			RETURNING id, word, reading, meaning, part_of_speech, jlpt_level,
+			          example_sentence, example_translation, audio_url, tags,
+			          owner_id, visibility, suggested_for_inclusion, created_at, updated_at
+		`
+
+		err = tx.QueryRowContext(ctx, query, id).Scan(
+			&v.ID, &v.Word, &v.Reading, &v.Meaning, &v.PartOfSpeech, &v.JLPTLevel,
+			&v.ExampleSentence, &v.ExampleTranslation, &v.AudioURL, pq.Array(&v.Tags),
+			&v.OwnerID, &v.Visibility, &v.SuggestedForInclusion, &v.CreatedAt, &v.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("error promoting vocabulary: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (r *vocabularyRepository) GetMasteredWords(ctx context.Context, userID int) ([]string, error) {
+	query := `
+		SELECT v.word
+		FROM user_vocabulary_progress p
+		JOIN vocabulary v ON v.id = p.vocabulary_id
+		WHERE p.user_id = $1 AND p.next_review_date > CURRENT_TIMESTAMP AND p.interval >= $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, repository.MasteredIntervalDays)
+	if err != nil {
+		return nil, fmt.Errorf("error getting mastered vocabulary: %w", err)
+	}
+	defer rows.Close()
+
+	var words []string
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			return nil, fmt.Errorf("error scanning vocabulary: %w", err)
+		}
+		words = append(words, word)
+	}
+
+	return words, rows.Err()
+}
+
+func (r *vocabularyRepository) GetDueForReview(ctx context.Context, userID int, deckID *int, limit int) ([]models.VocabularyWithProgress, error) {
 	query := `
 		SELECT v.id, v.word, v.reading, v.meaning, v.part_of_speech, v.jlpt_level,
-		       v.example_sentence, v.example_translation, v.audio_url, v.created_at, v.updated_at,
+		       v.example_sentence, v.example_translation, v.audio_url, v.tags,
+		       v.owner_id, v.visibility, v.suggested_for_inclusion, v.created_at, v.updated_at,
 		       p.id, p.user_id, p.vocabulary_id, p.ease_factor, p.interval, p.repetitions,
 		       p.next_review_date, p.last_reviewed_at, p.total_reviews, p.correct_reviews,
+		       p.mnemonic, p.personal_example, p.image_url,
 		       p.created_at, p.updated_at
 		FROM vocabulary v
 		INNER JOIN user_vocabulary_progress p ON v.id = p.vocabulary_id
 		WHERE p.user_id = $1 AND p.next_review_date <= CURRENT_TIMESTAMP
+		  AND (v.visibility = 'public' OR v.owner_id = $1)
+		  AND ($3::int IS NULL OR EXISTS (
+		      SELECT 1 FROM deck_items di
+		      JOIN decks d ON d.id = di.deck_id
+		      WHERE di.deck_id = $3 AND d.user_id = $1 AND di.vocabulary_id = v.id))
 		ORDER BY p.next_review_date
 		LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, deckID)
 	if err != nil {
 		return nil, fmt.Errorf("error querying due vocabulary: %w", err)
 	}
@@ -102,11 +461,13 @@ func (r *vocabularyRepository) GetDueForReview(ctx context.Context, userID int,
 
 		err := rows.Scan(
 			&item.ID, &item.Word, &item.Reading, &item.Meaning, &item.PartOfSpeech, &item.JLPTLevel,
-			&item.ExampleSentence, &item.ExampleTranslation, &item.AudioURL, &item.CreatedAt, &item.UpdatedAt,
+			&item.ExampleSentence, &item.ExampleTranslation, &item.AudioURL, pq.Array(&item.Tags),
+			&item.OwnerID, &item.Visibility, &item.SuggestedForInclusion, &item.CreatedAt, &item.UpdatedAt,
 			&item.Progress.ID, &item.Progress.UserID, &item.Progress.VocabularyID,
 			&item.Progress.EaseFactor, &item.Progress.Interval, &item.Progress.Repetitions,
 			&item.Progress.NextReviewDate, &item.Progress.LastReviewedAt, &item.Progress.TotalReviews,
-			&item.Progress.CorrectReviews, &item.Progress.CreatedAt, &item.Progress.UpdatedAt,
+			&item.Progress.CorrectReviews, &item.Progress.Mnemonic, &item.Progress.PersonalExample,
+			&item.Progress.ImageURL, &item.Progress.CreatedAt, &item.Progress.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning due vocabulary: %w", err)
@@ -121,7 +482,7 @@ func (r *vocabularyRepository) GetUserProgress(ctx context.Context, userID, voca
 	query := `
 		SELECT id, user_id, vocabulary_id, ease_factor, interval, repetitions,
 		       next_review_date, last_reviewed_at, total_reviews, correct_reviews,
-		       created_at, updated_at
+		       mnemonic, personal_example, image_url, created_at, updated_at
 		FROM user_vocabulary_progress
 		WHERE user_id = $1 AND vocabulary_id = $2
 	`
@@ -130,7 +491,7 @@ func (r *vocabularyRepository) GetUserProgress(ctx context.Context, userID, voca
 	err := r.db.QueryRowContext(ctx, query, userID, vocabularyID).Scan(
 		&p.ID, &p.UserID, &p.VocabularyID, &p.EaseFactor, &p.Interval, &p.Repetitions,
 		&p.NextReviewDate, &p.LastReviewedAt, &p.TotalReviews, &p.CorrectReviews,
-		&p.CreatedAt, &p.UpdatedAt,
+		&p.Mnemonic, &p.PersonalExample, &p.ImageURL, &p.CreatedAt, &p.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -143,6 +504,37 @@ func (r *vocabularyRepository) GetUserProgress(ctx context.Context, userID, voca
 	return p, nil
 }
 
+func (r *vocabularyRepository) GetUserProgressForVocabulary(ctx context.Context, userID int, vocabularyIDs []int) ([]models.UserVocabularyProgress, error) {
+	query := `
+		SELECT id, user_id, vocabulary_id, ease_factor, interval, repetitions,
+		       next_review_date, last_reviewed_at, total_reviews, correct_reviews,
+		       mnemonic, personal_example, image_url, created_at, updated_at
+		FROM user_vocabulary_progress
+		WHERE user_id = $1 AND vocabulary_id = ANY($2::int[])
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(vocabularyIDs))
+	if err != nil {
+		return nil, fmt.Errorf("error getting user progress: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.UserVocabularyProgress
+	for rows.Next() {
+		var p models.UserVocabularyProgress
+		if err := rows.Scan(
+			&p.ID, &p.UserID, &p.VocabularyID, &p.EaseFactor, &p.Interval, &p.Repetitions,
+			&p.NextReviewDate, &p.LastReviewedAt, &p.TotalReviews, &p.CorrectReviews,
+			&p.Mnemonic, &p.PersonalExample, &p.ImageURL, &p.CreatedAt, &p.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning user progress: %w", err)
+		}
+		items = append(items, p)
+	}
+
+	return items, rows.Err()
+}
+
 func (r *vocabularyRepository) CreateUserProgress(ctx context.Context, progress *models.UserVocabularyProgress) error {
 	query := `
 		INSERT INTO user_vocabulary_progress
@@ -164,6 +556,37 @@ func (r *vocabularyRepository) CreateUserProgress(ctx context.Context, progress
 	return nil
 }
 
+func (r *vocabularyRepository) SaveUserNotes(ctx context.Context, progress *models.UserVocabularyProgress) error {
+	query := `
+		INSERT INTO user_vocabulary_progress
+		(user_id, vocabulary_id, ease_factor, interval, repetitions, next_review_date, total_reviews, correct_reviews,
+		 mnemonic, personal_example, image_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (user_id, vocabulary_id) DO UPDATE
+		SET mnemonic = EXCLUDED.mnemonic, personal_example = EXCLUDED.personal_example,
+		    image_url = EXCLUDED.image_url, updated_at = CURRENT_TIMESTAMP
+		RETURNING id, ease_factor, interval, repetitions, next_review_date, last_reviewed_at,
+		          total_reviews, correct_reviews, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		progress.UserID, progress.VocabularyID, progress.EaseFactor,
+		progress.Interval, progress.Repetitions, progress.NextReviewDate,
+		progress.TotalReviews, progress.CorrectReviews,
+		progress.Mnemonic, progress.PersonalExample, progress.ImageURL,
+	).Scan(
+		&progress.ID, &progress.EaseFactor, &progress.Interval, &progress.Repetitions,
+		&progress.NextReviewDate, &progress.LastReviewedAt, &progress.TotalReviews,
+		&progress.CorrectReviews, &progress.CreatedAt, &progress.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("error saving user notes: %w", err)
+	}
+
+	return nil
+}
+
 func (r *vocabularyRepository) UpdateUserProgress(ctx context.Context, progress *models.UserVocabularyProgress) error {
 	query := `
 		UPDATE user_vocabulary_progress
@@ -192,77 +615,239 @@ func (r *vocabularyRepository) UpdateUserProgress(ctx context.Context, progress
 	return nil
 }
 
-func (r *vocabularyRepository) GetUserVocabularyList(ctx context.Context, userID int, jlptLevel *int, limit, offset int) ([]models.VocabularyWithProgress, error) {
+// vocabularyListCondition filters the vocabulary list joined to the user's progress (p):
+// $2 JLPT level, $3 part of speech escaped for LIKE (verb also matches godan verb), $4 has audio, $5 tags, $6 learning state,
+// $7 the mastered interval, $8 a deck of the user and $9 visibility. The learning
+// states are mutually exclusive. Tags match both built-in tags and the user's own
+// tags. Private items are only listed for their owner ($1).
+const vocabularyListCondition = `
+	(v.visibility = 'public' OR v.owner_id = $1)
+	AND ($9::text IS NULL OR v.visibility = $9)
+	AND ($2::int IS NULL OR v.jlpt_level = $2)
+	AND ($3::text IS NULL OR v.part_of_speech LIKE $3 ESCAPE '\'
+	     OR v.part_of_speech LIKE ('% ' || $3) ESCAPE '\' OR v.part_of_speech LIKE ('%-' || $3) ESCAPE '\')
+	AND ($4::boolean IS NULL OR (COALESCE(v.audio_url, '') <> '') = $4)
+	AND ($5::text[] IS NULL OR (v.tags || ARRAY(
+	     SELECT t.name FROM vocabulary_tags vt
+	     JOIN tags t ON t.id = vt.tag_id
+	     WHERE vt.vocabulary_id = v.id AND t.user_id = $1)) @> $5)
+	AND ($8::int IS NULL OR EXISTS (
+	     SELECT 1 FROM deck_items di
+	     JOIN decks d ON d.id = di.deck_id
+	     WHERE di.deck_id = $8 AND d.user_id = $1 AND di.vocabulary_id = v.id))
+	AND ($6::text IS NULL
+	     OR ($6 = 'new' AND p.id IS NULL)
+	     OR ($6 = 'due' AND p.next_review_date <= CURRENT_TIMESTAMP)
+	     OR ($6 = 'learning' AND p.next_review_date > CURRENT_TIMESTAMP AND p.interval < $7)
+	     OR ($6 = 'mastered' AND p.next_review_date > CURRENT_TIMESTAMP AND p.interval >= $7))
+`
+
+// vocabularySortColumns is the whitelist of vocabulary list sort fields
+var vocabularySortColumns = sortColumns{
+	repository.SortByID:          {Expr: "v.id", Type: "int"},
+	repository.SortByWord:        {Expr: "v.word", Type: "text"},
+	repository.SortByReading:     {Expr: "v.reading", Type: "text"},
+	repository.SortByNextReview:  {Expr: "p.next_review_date", Type: "timestamptz"},
+	repository.SortBySuccessRate: {Expr: "p.correct_reviews::float / NULLIF(p.total_reviews, 0)", Type: "float"},
+}
+
+func (r *vocabularyRepository) GetUserVocabularyList(ctx context.Context, userID int, filter repository.VocabularyFilter, limit, offset int) ([]models.VocabularyWithProgress, *repository.Cursor, error) {
+	after, afterArgs := keyset(filter.After, filter.Sort, vocabularySortColumns, repository.SortByID, "v.id", 12)
+
+	query := `
+		SELECT v.id, v.word, v.reading, v.meaning, v.part_of_speech, v.jlpt_level,
+		       v.example_sentence, v.example_translation, v.audio_url, v.tags,
+		       v.owner_id, v.visibility, v.suggested_for_inclusion, v.created_at, v.updated_at,
+		       p.id, p.user_id, p.vocabulary_id, p.ease_factor, p.interval, p.repetitions,
+		       p.next_review_date, p.last_reviewed_at, p.total_reviews, p.correct_reviews,
+		       p.mnemonic, p.personal_example, p.image_url,
+		       p.created_at, p.updated_at,
+		       ` + sortKeyColumn(filter.Sort, vocabularySortColumns, repository.SortByID) + `
+		FROM vocabulary v
+		LEFT JOIN user_vocabulary_progress p ON v.id = p.vocabulary_id AND p.user_id = $1
+		WHERE ` + vocabularyListCondition + after + `
+		ORDER BY ` + orderBy(filter.Sort, vocabularySortColumns, repository.SortByID, "v.id") + `
+		LIMIT $10 OFFSET $11
+	`
+
+	// Fetch one extra row to know whether there is a next page
+	args := append(vocabularyListArgs(userID, filter), limit+1, offset)
+	args = append(args, afterArgs...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error querying user vocabulary list: %w", err)
+	}
+	defer rows.Close()
+
+	var keys sortKeys
+	items, err := scanVocabularyWithProgress(rows, &keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *repository.Cursor
+	if len(items) > limit {
+		items = items[:limit]
+		next = keys.cursor(filter.Sort, limit-1, items[limit-1].ID)
+	}
+
+	return items, next, nil
+}
+
+func (r *vocabularyRepository) GetUserVocabularyForExport(ctx context.Context, userID int, deckID *int, after *repository.Cursor, limit int) ([]models.VocabularyWithProgress, *repository.Cursor, error) {
+	afterID, afterArgs := keyset(after, repository.SortOrder{}, vocabularySortColumns, repository.SortByID, "v.id", 4)
+
 	query := `
 		SELECT v.id, v.word, v.reading, v.meaning, v.part_of_speech, v.jlpt_level,
-		       v.example_sentence, v.example_translation, v.audio_url, v.created_at, v.updated_at,
+		       v.example_sentence, v.example_translation, v.audio_url, v.tags,
+		       v.owner_id, v.visibility, v.suggested_for_inclusion, v.created_at, v.updated_at,
 		       p.id, p.user_id, p.vocabulary_id, p.ease_factor, p.interval, p.repetitions,
 		       p.next_review_date, p.last_reviewed_at, p.total_reviews, p.correct_reviews,
+		       p.mnemonic, p.personal_example, p.image_url,
 		       p.created_at, p.updated_at
 		FROM vocabulary v
 		LEFT JOIN user_vocabulary_progress p ON v.id = p.vocabulary_id AND p.user_id = $1
-		WHERE ($2::int IS NULL OR v.jlpt_level = $2)
+		WHERE (v.visibility = 'public' OR v.owner_id = $1)
+		  AND (($2::int IS NULL AND (p.id IS NOT NULL OR v.owner_id = $1))
+		       OR EXISTS (
+		           SELECT 1 FROM deck_items di
+		           JOIN decks d ON d.id = di.deck_id
+		           WHERE di.deck_id = $2 AND d.user_id = $1 AND di.vocabulary_id = v.id))` + afterID + `
 		ORDER BY v.id
-		LIMIT $3 OFFSET $4
+		LIMIT $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, jlptLevel, limit, offset)
+	// Fetch one extra row to know whether there is a next page
+	args := append([]interface{}{userID, deckID, limit + 1}, afterArgs...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error querying user vocabulary list: %w", err)
+		return nil, nil, fmt.Errorf("error querying vocabulary for export: %w", err)
 	}
 	defer rows.Close()
 
-	var items []models.VocabularyWithProgress
-	for rows.Next() {
-		var item models.VocabularyWithProgress
-		var progressID sql.NullInt64
-		var progressUserID, progressVocabID sql.NullInt64
-		var easeFactor sql.NullFloat64
-		var interval, repetitions, totalReviews, correctReviews sql.NullInt64
-		var nextReviewDate, lastReviewedAt, progressCreatedAt, progressUpdatedAt sql.NullTime
+	items, err := scanVocabularyWithProgress(rows)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		err := rows.Scan(
-			&item.ID, &item.Word, &item.Reading, &item.Meaning, &item.PartOfSpeech, &item.JLPTLevel,
-			&item.ExampleSentence, &item.ExampleTranslation, &item.AudioURL, &item.CreatedAt, &item.UpdatedAt,
-			&progressID, &progressUserID, &progressVocabID, &easeFactor, &interval, &repetitions,
-			&nextReviewDate, &lastReviewedAt, &totalReviews, &correctReviews,
-			&progressCreatedAt, &progressUpdatedAt,
-		)
+	var next *repository.Cursor
+	if len(items) > limit {
+		items = items[:limit]
+		next = &repository.Cursor{ID: items[limit-1].ID}
+	}
+
+	return items, next, nil
+}
+
+func (r *vocabularyRepository) EachReviewedVocabulary(ctx context.Context, userID int, deckID *int, fn func(*models.VocabularyWithProgress) error) error {
+	query := `
+		SELECT v.id, v.word, v.reading, v.meaning, v.part_of_speech, v.jlpt_level,
+		       v.example_sentence, v.example_translation, v.audio_url, v.tags,
+		       v.owner_id, v.visibility, v.suggested_for_inclusion, v.created_at, v.updated_at,
+		       p.id, p.user_id, p.vocabulary_id, p.ease_factor, p.interval, p.repetitions,
+		       p.next_review_date, p.last_reviewed_at, p.total_reviews, p.correct_reviews,
+		       p.mnemonic, p.personal_example, p.image_url,
+		       p.created_at, p.updated_at
+		FROM vocabulary v
+		JOIN user_vocabulary_progress p ON v.id = p.vocabulary_id AND p.user_id = $1
+		WHERE (v.visibility = 'public' OR v.owner_id = $1)
+		  AND p.total_reviews > 0
+		  AND ($2::int IS NULL OR EXISTS (
+		           SELECT 1 FROM deck_items di
+		           JOIN decks d ON d.id = di.deck_id
+		           WHERE di.deck_id = $2 AND d.user_id = $1 AND di.vocabulary_id = v.id))
+		ORDER BY v.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, deckID)
+	if err != nil {
+		return fmt.Errorf("error querying reviewed vocabulary: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanVocabularyWithProgressRow(rows)
 		if err != nil {
-			return nil, fmt.Errorf("error scanning user vocabulary: %w", err)
+			return err
 		}
-
-		// Only populate progress if it exists
-		if progressID.Valid {
-			item.Progress = &models.UserVocabularyProgress{
-				ID:             int(progressID.Int64),
-				UserID:         int(progressUserID.Int64),
-				VocabularyID:   int(progressVocabID.Int64),
-				EaseFactor:     easeFactor.Float64,
-				Interval:       int(interval.Int64),
-				Repetitions:    int(repetitions.Int64),
-				NextReviewDate: nextReviewDate.Time,
-				TotalReviews:   int(totalReviews.Int64),
-				CorrectReviews: int(correctReviews.Int64),
-				CreatedAt:      progressCreatedAt.Time,
-				UpdatedAt:      progressUpdatedAt.Time,
-			}
-			if lastReviewedAt.Valid {
-				item.Progress.LastReviewedAt = &lastReviewedAt.Time
-			}
+		if err := fn(&item); err != nil {
+			return err
 		}
+	}
+
+	return rows.Err()
+}
 
+// scanVocabularyWithProgress scans rows of vocabulary columns followed by
+// LEFT JOINed user_vocabulary_progress columns, which may all be NULL, and
+// then by any extra columns, which are scanned into extra
+func scanVocabularyWithProgress(rows *sql.Rows, extra ...interface{}) ([]models.VocabularyWithProgress, error) {
+	var items []models.VocabularyWithProgress
+	for rows.Next() {
+		item, err := scanVocabularyWithProgressRow(rows, extra...)
+		if err != nil {
+			return nil, err
+		}
 		items = append(items, item)
 	}
 
 	return items, rows.Err()
 }
 
+// scanVocabularyWithProgressRow scans the current row the way
+// scanVocabularyWithProgress does
+func scanVocabularyWithProgressRow(rows *sql.Rows, extra ...interface{}) (models.VocabularyWithProgress, error) {
+	var item models.VocabularyWithProgress
+	var progressID sql.NullInt64
+	var progressUserID, progressVocabID sql.NullInt64
+	var easeFactor sql.NullFloat64
+	var interval, repetitions, totalReviews, correctReviews sql.NullInt64
+	var nextReviewDate, lastReviewedAt, progressCreatedAt, progressUpdatedAt sql.NullTime
+	var mnemonic, personalExample, imageURL *string
+
+	dest := []interface{}{
+		&item.ID, &item.Word, &item.Reading, &item.Meaning, &item.PartOfSpeech, &item.JLPTLevel,
+		&item.ExampleSentence, &item.ExampleTranslation, &item.AudioURL, pq.Array(&item.Tags),
+		&item.OwnerID, &item.Visibility, &item.SuggestedForInclusion, &item.CreatedAt, &item.UpdatedAt,
+		&progressID, &progressUserID, &progressVocabID, &easeFactor, &interval, &repetitions,
+		&nextReviewDate, &lastReviewedAt, &totalReviews, &correctReviews,
+		&mnemonic, &personalExample, &imageURL, &progressCreatedAt, &progressUpdatedAt,
+	}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return item, fmt.Errorf("error scanning user vocabulary: %w", err)
+	}
+
+	// Only populate progress if it exists
+	if progressID.Valid {
+		item.Progress = &models.UserVocabularyProgress{
+			ID:              int(progressID.Int64),
+			UserID:          int(progressUserID.Int64),
+			VocabularyID:    int(progressVocabID.Int64),
+			EaseFactor:      easeFactor.Float64,
+			Interval:        int(interval.Int64),
+			Repetitions:     int(repetitions.Int64),
+			NextReviewDate:  nextReviewDate.Time,
+			TotalReviews:    int(totalReviews.Int64),
+			CorrectReviews:  int(correctReviews.Int64),
+			Mnemonic:        mnemonic,
+			PersonalExample: personalExample,
+			ImageURL:        imageURL,
+			CreatedAt:       progressCreatedAt.Time,
+			UpdatedAt:       progressUpdatedAt.Time,
+		}
+		if lastReviewedAt.Valid {
+			item.Progress.LastReviewedAt = &lastReviewedAt.Time
+		}
+	}
+
+	return item, nil
+}
+
 func (r *vocabularyRepository) Count(ctx context.Context, jlptLevel *int) (int, error) {
 	query := `
 		SELECT COUNT(*)
 		FROM vocabulary
-		WHERE ($1::int IS NULL OR jlpt_level = $1)
+		WHERE visibility = 'public' AND ($1::int IS NULL OR jlpt_level = $1)
 	`
 
 	var count int
@@ -273,3 +858,266 @@ func (r *vocabularyRepository) Count(ctx context.Context, jlptLevel *int) (int,
 
 	return count, nil
 }
+
+func (r *vocabularyRepository) CountUserVocabularyList(ctx context.Context, userID int, filter repository.VocabularyFilter) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM vocabulary v
+		LEFT JOIN user_vocabulary_progress p ON v.id = p.vocabulary_id AND p.user_id = $1
+		WHERE ` + vocabularyListCondition
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, vocabularyListArgs(userID, filter)...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting user vocabulary list: %w", err)
+	}
+
+	return count, nil
+}
+
+// vocabularyListArgs builds the $1-$9 arguments used by vocabularyListCondition
+func vocabularyListArgs(userID int, filter repository.VocabularyFilter) []interface{} {
+	partOfSpeech := filter.PartOfSpeech
+	if partOfSpeech != nil {
+		escaped := escapeLike(*partOfSpeech)
+		partOfSpeech = &escaped
+	}
+
+	return []interface{}{
+		userID, filter.JLPTLevel, partOfSpeech, filter.HasAudio,
+		optionalTags(filter.Tags), filter.LearningState, repository.MasteredIntervalDays,
+		filter.DeckID, filter.Visibility,
+	}
+}
+
+// vocabularySearchCondition matches word and reading by exact, prefix or substring
+// spelling ($1 exact terms, $2 prefix patterns, $3 substring patterns) and English
+// tokens in the meaning ($4), optionally restricted to a JLPT level ($5). Private
+// items only match for their owner ($6). The owner's notes on the progress row (p)
+// match by substring, case-insensitively. Substring matching is skipped unless $7
+// is true (see vocabularySearchArgs).
+//
+// Each prefix pattern gets its own LIKE (see searchPrefixConditions), as LIKE
+// ANY cannot use the text_pattern_ops indexes of word and reading. Once the
+// parameters are bound, the unused patterns and a false $7 fold away, so short
+// queries are served by indexes alone.
+var vocabularySearchCondition = `
+	(v.word = ANY($1) OR v.reading = ANY($1)` + searchPrefixConditions() + `
+	 OR ($7::boolean AND (v.word LIKE ANY($3) OR v.reading LIKE ANY($3)))
+	 OR to_tsvector('english', v.meaning) @@ plainto_tsquery('english', $4)
+	 OR ($7::boolean AND (p.mnemonic ILIKE ANY($3) OR p.personal_example ILIKE ANY($3))))
+	AND ($5::int IS NULL OR v.jlpt_level = $5)
+	AND (v.visibility = 'public' OR v.owner_id = $6)
+`
+
+// vocabularySearchRank scores a match: exact spellings first, then prefixes,
+// substrings and finally meaning matches weighted by full-text rank
+const vocabularySearchRank = `
+	CASE
+		WHEN v.word = ANY($1) OR v.reading = ANY($1) THEN 100
+		WHEN v.word LIKE ANY($2) OR v.reading LIKE ANY($2) THEN 50
+		WHEN v.word LIKE ANY($3) OR v.reading LIKE ANY($3) THEN 20
+		ELSE 0
+	END
+	+ 10 * ts_rank(to_tsvector('english', v.meaning), plainto_tsquery('english', $4))
+	+ CASE WHEN lower(v.meaning) = lower($4) THEN 30 ELSE 0 END
+`
+
+// searchPrefixConditions matches word and reading against each of the
+// repository.MaxSearchTerms prefix patterns of $2
+func searchPrefixConditions() string {
+	var b strings.Builder
+	for i := 1; i <= repository.MaxSearchTerms; i++ {
+		fmt.Fprintf(&b, "\n\t OR v.word LIKE ($2::text[])[%d] OR v.reading LIKE ($2::text[])[%d]", i, i)
+	}
+	return b.String()
+}
+
+func (r *vocabularyRepository) Search(ctx context.Context, userID int, search repository.VocabularySearch, limit, offset int) ([]models.VocabularyWithProgress, error) {
+	query := `
+		SELECT v.id, v.word, v.reading, v.meaning, v.part_of_speech, v.jlpt_level,
+		       v.example_sentence, v.example_translation, v.audio_url, v.tags,
+		       v.owner_id, v.visibility, v.suggested_for_inclusion, v.created_at, v.updated_at,
+		       p.id, p.user_id, p.vocabulary_id, p.ease_factor, p.interval, p.repetitions,
+		       p.next_review_date, p.last_reviewed_at, p.total_reviews, p.correct_reviews,
+		       p.mnemonic, p.personal_example, p.image_url,
+		       p.created_at, p.updated_at
+		FROM vocabulary v
+		LEFT JOIN user_vocabulary_progress p ON v.id = p.vocabulary_id AND p.user_id = $6
+		WHERE ` + vocabularySearchCondition + `
+		ORDER BY ` + vocabularySearchRank + ` DESC, v.id
+		LIMIT $8 OFFSET $9
+	`
+
+	args := append(vocabularySearchArgs(userID, search), limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error searching vocabulary: %w", err)
+	}
+	defer rows.Close()
+
+	return scanVocabularyWithProgress(rows)
+}
+
+func (r *vocabularyRepository) CountSearch(ctx context.Context, userID int, search repository.VocabularySearch) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM vocabulary v
+		LEFT JOIN user_vocabulary_progress p ON v.id = p.vocabulary_id AND p.user_id = $6
+		WHERE ` + vocabularySearchCondition
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, vocabularySearchArgs(userID, search)...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting vocabulary search results: %w", err)
+	}
+
+	return count, nil
+}
+
+// minSubstringLength is the length of the shortest term matched by substring.
+// pg_trgm cannot extract trigrams from shorter patterns, so their substring
+// matches would read every row; shorter terms only match by exact spelling and
+// by prefix.
+const minSubstringLength = 3
+
+// vocabularySearchArgs builds the $1-$7 arguments used by vocabularySearchCondition
+func vocabularySearchArgs(userID int, search repository.VocabularySearch) []interface{} {
+	// Only MaxSearchTerms prefix patterns have a slot in the condition
+	terms := search.Terms
+	if len(terms) > repository.MaxSearchTerms {
+		terms = terms[:repository.MaxSearchTerms]
+	}
+
+	prefixes := make([]string, 0, len(terms))
+	substrings := make([]string, 0, len(terms))
+	for _, term := range terms {
+		escaped := escapeLike(term)
+		prefixes = append(prefixes, escaped+"%")
+		if utf8.RuneCountInString(term) >= minSubstringLength {
+			substrings = append(substrings, "%"+escaped+"%")
+		}
+	}
+
+	return []interface{}{
+		pq.Array(terms), pq.Array(prefixes), pq.Array(substrings),
+		search.Text, search.JLPTLevel, userID, len(substrings) > 0,
+	}
+}
+
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (r *vocabularyRepository) CreateReviewLog(ctx context.Context, log *models.VocabularyReviewLog) error {
+	query := `
+		INSERT INTO vocabulary_review_log
+		(user_id, vocabulary_id, quality, is_correct, self_graded, near_miss, previous_interval, interval,
+		 ease_factor, reviewed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		log.UserID, log.VocabularyID, log.Quality, log.IsCorrect, log.SelfGraded, log.NearMiss,
+		log.PreviousInterval, log.Interval, log.EaseFactor, log.ReviewedAt,
+	).Scan(&log.ID)
+
+	if err != nil {
+		return fmt.Errorf("error creating review log: %w", err)
+	}
+
+	return nil
+}
+
+func (r *vocabularyRepository) GetLastReviewLog(ctx context.Context, userID, vocabularyID int) (*models.VocabularyReviewLog, error) {
+	query := `
+		SELECT id, user_id, vocabulary_id, quality, is_correct, self_graded, near_miss, previous_interval,
+		       interval, ease_factor, reviewed_at
+		FROM vocabulary_review_log
+		WHERE user_id = $1 AND vocabulary_id = $2
+		ORDER BY reviewed_at DESC, id DESC
+		LIMIT 1
+	`
+
+	l := &models.VocabularyReviewLog{}
+	err := r.db.QueryRowContext(ctx, query, userID, vocabularyID).Scan(
+		&l.ID, &l.UserID, &l.VocabularyID, &l.Quality, &l.IsCorrect, &l.SelfGraded, &l.NearMiss,
+		&l.PreviousInterval, &l.Interval, &l.EaseFactor, &l.ReviewedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, pkgErrors.NotFound("No review found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting last review: %w", err)
+	}
+
+	return l, nil
+}
+
+// reviewLogSortColumns is the whitelist of review log sort fields
+var reviewLogSortColumns = sortColumns{
+	repository.SortByReviewedAt: {Expr: "reviewed_at", Type: "timestamptz"},
+}
+
+func (r *vocabularyRepository) GetUserReviewLog(ctx context.Context, userID int, vocabularyID *int, after *repository.Cursor, limit, offset int) ([]models.VocabularyReviewLog, *repository.Cursor, error) {
+	afterCondition, afterArgs := keyset(after, repository.ReviewLogSort, reviewLogSortColumns, repository.SortByReviewedAt, "id", 5)
+
+	query := `
+		SELECT id, user_id, vocabulary_id, quality, is_correct, self_graded, near_miss, previous_interval,
+		       interval, ease_factor, reviewed_at, ` + sortKeyColumn(repository.ReviewLogSort, reviewLogSortColumns, repository.SortByReviewedAt) + `
+		FROM vocabulary_review_log
+		WHERE user_id = $1 AND ($2::int IS NULL OR vocabulary_id = $2)` + afterCondition + `
+		ORDER BY ` + orderBy(repository.ReviewLogSort, reviewLogSortColumns, repository.SortByReviewedAt, "id") + `
+		LIMIT $3 OFFSET $4
+	`
+
+	// Fetch one extra row to know whether there is a next page
+	args := append([]interface{}{userID, vocabularyID, limit + 1, offset}, afterArgs...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error querying review log: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []models.VocabularyReviewLog
+	var keys sortKeys
+	for rows.Next() {
+		var l models.VocabularyReviewLog
+		err := rows.Scan(&l.ID, &l.UserID, &l.VocabularyID, &l.Quality, &l.IsCorrect, &l.SelfGraded, &l.NearMiss,
+			&l.PreviousInterval, &l.Interval, &l.EaseFactor, &l.ReviewedAt, &keys)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error scanning review log: %w", err)
+		}
+		logs = append(logs, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *repository.Cursor
+	if len(logs) > limit {
+		logs = logs[:limit]
+		next = keys.cursor(repository.ReviewLogSort, limit-1, logs[limit-1].ID)
+	}
+
+	return logs, next, nil
+}
+
+func (r *vocabularyRepository) CountUserReviewLog(ctx context.Context, userID int, vocabularyID *int) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM vocabulary_review_log
+		WHERE user_id = $1 AND ($2::int IS NULL OR vocabulary_id = $2)
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID, vocabularyID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting review log: %w", err)
+	}
+
+	return count, nil
+}
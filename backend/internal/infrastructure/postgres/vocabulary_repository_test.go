@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/joaosantos/jlpt5/internal/domain/repository"
+	"github.com/lib/pq"
+)
+
+func TestVocabularySearchPrefixSlots(t *testing.T) {
+	for i := 1; i <= repository.MaxSearchTerms; i++ {
+		slot := fmt.Sprintf("v.word LIKE ($2::text[])[%d] OR v.reading LIKE ($2::text[])[%d]", i, i)
+		if !strings.Contains(vocabularySearchCondition, slot) {
+			t.Errorf("vocabularySearchCondition has no prefix slot %d", i)
+		}
+	}
+	if extra := fmt.Sprintf("($2::text[])[%d]", repository.MaxSearchTerms+1); strings.Contains(vocabularySearchCondition, extra) {
+		t.Errorf("vocabularySearchCondition has more than %d prefix slots", repository.MaxSearchTerms)
+	}
+}
+
+func TestVocabularySearchArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		terms        []string
+		prefixes     []string
+		substrings   []string
+		useSubstring bool
+	}{
+		{
+			name:       "short terms match by prefix only",
+			terms:      []string{"ねこ", "ネコ"},
+			prefixes:   []string{"ねこ%", "ネコ%"},
+			substrings: []string{},
+		},
+		{
+			name:         "long terms also match by substring",
+			terms:        []string{"neko", "ねこ", "ネコ"},
+			prefixes:     []string{"neko%", "ねこ%", "ネコ%"},
+			substrings:   []string{"%neko%"},
+			useSubstring: true,
+		},
+		{
+			name:         "wildcards are escaped",
+			terms:        []string{"5%_"},
+			prefixes:     []string{`5\%\_%`},
+			substrings:   []string{`%5\%\_%`},
+			useSubstring: true,
+		},
+		{
+			name:       "terms past MaxSearchTerms are dropped",
+			terms:      []string{"a", "b", "c", "d", "e", "f"},
+			prefixes:   []string{"a%", "b%", "c%", "d%"},
+			substrings: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := vocabularySearchArgs(1, repository.VocabularySearch{Text: "q", Terms: tt.terms})
+
+			prefixes := []string(*args[1].(*pq.StringArray))
+			if !reflect.DeepEqual(prefixes, tt.prefixes) {
+				t.Errorf("prefix patterns = %q, want %q", prefixes, tt.prefixes)
+			}
+			substrings := []string(*args[2].(*pq.StringArray))
+			if !reflect.DeepEqual(substrings, tt.substrings) {
+				t.Errorf("substring patterns = %q, want %q", substrings, tt.substrings)
+			}
+			if got := args[6].(bool); got != tt.useSubstring {
+				t.Errorf("substring flag = %v, want %v", got, tt.useSubstring)
+			}
+			if terms := []string(*args[0].(*pq.StringArray)); len(terms) > repository.MaxSearchTerms {
+				t.Errorf("%d exact terms, more than MaxSearchTerms (%d)", len(terms), repository.MaxSearchTerms)
+			}
+		})
+	}
+}
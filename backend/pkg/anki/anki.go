@@ -0,0 +1,404 @@
+// Package anki reads Anki collection packages (.apkg deck exports and .colpkg
+// collection backups): note types, notes, cards and the review log. It also
+// writes .apkg decks.
+package anki
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// maxCollectionSize limits the size of the extracted collection database
+const maxCollectionSize = 1 << 30
+
+// ErrNoCollection is returned when a package does not contain a collection database
+var ErrNoCollection = errors.New("anki: package does not contain a collection")
+
+// collectionFiles lists the collection database names in order of preference.
+// Packages exported for recent Anki versions contain a zstd-compressed
+// collection.anki21b next to a legacy collection.anki2 that only holds a
+// placeholder note, so the newest format has to win.
+var collectionFiles = []string{"collection.anki21b", "collection.anki21", "collection.anki2"}
+
+// Package is an opened Anki package. Close it to remove the extracted database.
+type Package struct {
+	db        *sql.DB
+	dir       string
+	created   time.Time
+	noteTypes map[int64]NoteType
+}
+
+// NoteType describes the fields of a kind of note
+type NoteType struct {
+	ID     int64
+	Name   string
+	Fields []string // Field names in order
+}
+
+// Note is a single Anki note. Fields are raw HTML in the order of the note type's fields.
+type Note struct {
+	ID         int64
+	NoteTypeID int64
+	Fields     []string
+	Tags       []string
+}
+
+// Card types
+const (
+	CardTypeNew        = 0
+	CardTypeLearning   = 1
+	CardTypeReview     = 2
+	CardTypeRelearning = 3
+)
+
+// Card is the schedule of one side of a note
+type Card struct {
+	ID       int64
+	NoteID   int64
+	Ord      int
+	Type     int
+	Queue    int
+	Due      int64
+	Interval int // Days, or negative seconds while learning
+	Factor   int // Ease in permille, 2500 = 250%
+	Reps     int
+	Lapses   int
+}
+
+// Review is an entry of the review log
+type Review struct {
+	ID           int64 // Review time in milliseconds since the epoch
+	CardID       int64
+	Ease         int // Answer button: 1 again, 2 hard, 3 good, 4 easy; 0 for manual changes
+	Interval     int // Days, or negative seconds while learning
+	LastInterval int
+	Factor       int
+	Type         int // 0 learn, 1 review, 2 relearn, 3 filtered, 4 manual
+}
+
+// Open extracts the collection database of an .apkg or .colpkg file and opens it read-only
+func Open(path string) (*Package, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("anki: opening package: %w", err)
+	}
+	defer archive.Close()
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	var collection *zip.File
+	for _, name := range collectionFiles {
+		if f, ok := files[name]; ok {
+			collection = f
+			break
+		}
+	}
+	if collection == nil {
+		return nil, ErrNoCollection
+	}
+
+	dir, err := os.MkdirTemp("", "anki-*")
+	if err != nil {
+		return nil, fmt.Errorf("anki: creating temp dir: %w", err)
+	}
+
+	dbPath := filepath.Join(dir, "collection.db")
+	if err := extract(collection, dbPath); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("anki: opening collection: %w", err)
+	}
+
+	p := &Package{db: db, dir: dir}
+	if err := p.load(context.Background()); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// extract writes a collection file to dst, decompressing the anki21b format
+func extract(f *zip.File, dst string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("anki: reading collection: %w", err)
+	}
+	defer rc.Close()
+
+	var src io.Reader = rc
+	if strings.HasSuffix(f.Name, ".anki21b") {
+		dec, err := zstd.NewReader(rc)
+		if err != nil {
+			return fmt.Errorf("anki: decompressing collection: %w", err)
+		}
+		defer dec.Close()
+		src = dec
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("anki: extracting collection: %w", err)
+	}
+	defer out.Close()
+
+	n, err := io.Copy(out, io.LimitReader(src, maxCollectionSize+1))
+	if err != nil {
+		return fmt.Errorf("anki: extracting collection: %w", err)
+	}
+	if n > maxCollectionSize {
+		return errors.New("anki: collection is too large")
+	}
+
+	return out.Close()
+}
+
+// load reads the collection creation time and the note types
+func (p *Package) load(ctx context.Context) error {
+	var crt int64
+	var models sql.NullString
+	err := p.db.QueryRowContext(ctx, `SELECT crt, models FROM col`).Scan(&crt, &models)
+	if err != nil {
+		return fmt.Errorf("anki: reading collection: %w", err)
+	}
+	p.created = time.Unix(crt, 0)
+
+	// Older collections keep note types as JSON in col.models, newer ones in
+	// the notetypes and fields tables and leave col.models empty
+	if models.Valid && strings.TrimSpace(models.String) != "" && models.String != "{}" {
+		p.noteTypes, err = parseModels(models.String)
+	} else {
+		p.noteTypes, err = p.queryNoteTypes(ctx)
+	}
+	return err
+}
+
+// parseModels parses the note types stored as JSON in col.models
+func parseModels(data string) (map[int64]NoteType, error) {
+	var models map[string]struct {
+		Name   string `json:"name"`
+		Fields []struct {
+			Name string `json:"name"`
+			Ord  int    `json:"ord"`
+		} `json:"flds"`
+	}
+	if err := json.Unmarshal([]byte(data), &models); err != nil {
+		return nil, fmt.Errorf("anki: parsing note types: %w", err)
+	}
+
+	noteTypes := make(map[int64]NoteType, len(models))
+	for key, m := range models {
+		id, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("anki: invalid note type id %q", key)
+		}
+
+		sort.Slice(m.Fields, func(i, j int) bool { return m.Fields[i].Ord < m.Fields[j].Ord })
+		nt := NoteType{ID: id, Name: m.Name}
+		for _, f := range m.Fields {
+			nt.Fields = append(nt.Fields, f.Name)
+		}
+		noteTypes[id] = nt
+	}
+
+	return noteTypes, nil
+}
+
+// queryNoteTypes reads the note types of collections in the newer schema
+func (p *Package) queryNoteTypes(ctx context.Context) (map[int64]NoteType, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT n.id, n.name, f.name
+		FROM notetypes n
+		JOIN fields f ON f.ntid = n.id
+		ORDER BY n.id, f.ord
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("anki: querying note types: %w", err)
+	}
+	defer rows.Close()
+
+	noteTypes := make(map[int64]NoteType)
+	for rows.Next() {
+		var id int64
+		var name, field string
+		if err := rows.Scan(&id, &name, &field); err != nil {
+			return nil, fmt.Errorf("anki: scanning note type: %w", err)
+		}
+		nt := noteTypes[id]
+		nt.ID, nt.Name = id, name
+		nt.Fields = append(nt.Fields, field)
+		noteTypes[id] = nt
+	}
+
+	return noteTypes, rows.Err()
+}
+
+// Close closes the collection and removes the extracted database
+func (p *Package) Close() error {
+	err := p.db.Close()
+	os.RemoveAll(p.dir)
+	return err
+}
+
+// CreatedAt returns the collection creation time, the origin of review card due days
+func (p *Package) CreatedAt() time.Time {
+	return p.created
+}
+
+// NoteTypes returns the note types of the collection, sorted by name
+func (p *Package) NoteTypes() []NoteType {
+	noteTypes := make([]NoteType, 0, len(p.noteTypes))
+	for _, nt := range p.noteTypes {
+		noteTypes = append(noteTypes, nt)
+	}
+	sort.Slice(noteTypes, func(i, j int) bool { return noteTypes[i].Name < noteTypes[j].Name })
+	return noteTypes
+}
+
+// NoteType returns a note type by ID
+func (p *Package) NoteType(id int64) (NoteType, bool) {
+	nt, ok := p.noteTypes[id]
+	return nt, ok
+}
+
+// Notes returns all notes in the collection
+func (p *Package) Notes(ctx context.Context) ([]Note, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT id, mid, flds, tags FROM notes ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("anki: querying notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []Note
+	for rows.Next() {
+		var n Note
+		var fields, tags string
+		if err := rows.Scan(&n.ID, &n.NoteTypeID, &fields, &tags); err != nil {
+			return nil, fmt.Errorf("anki: scanning note: %w", err)
+		}
+		n.Fields = strings.Split(fields, "\x1f")
+		n.Tags = strings.Fields(tags)
+		notes = append(notes, n)
+	}
+
+	return notes, rows.Err()
+}
+
+// Cards returns all cards in the collection grouped by note ID
+func (p *Package) Cards(ctx context.Context) (map[int64][]Card, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, nid, ord, type, queue, due, ivl, factor, reps, lapses
+		FROM cards
+		ORDER BY nid, ord
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("anki: querying cards: %w", err)
+	}
+	defer rows.Close()
+
+	cards := make(map[int64][]Card)
+	for rows.Next() {
+		var c Card
+		err := rows.Scan(&c.ID, &c.NoteID, &c.Ord, &c.Type, &c.Queue, &c.Due,
+			&c.Interval, &c.Factor, &c.Reps, &c.Lapses)
+		if err != nil {
+			return nil, fmt.Errorf("anki: scanning card: %w", err)
+		}
+		cards[c.NoteID] = append(cards[c.NoteID], c)
+	}
+
+	return cards, rows.Err()
+}
+
+// Reviews returns the review log grouped by card ID, oldest first
+func (p *Package) Reviews(ctx context.Context) (map[int64][]Review, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, cid, ease, ivl, lastIvl, factor, type
+		FROM revlog
+		ORDER BY cid, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("anki: querying review log: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := make(map[int64][]Review)
+	for rows.Next() {
+		var r Review
+		err := rows.Scan(&r.ID, &r.CardID, &r.Ease, &r.Interval, &r.LastInterval, &r.Factor, &r.Type)
+		if err != nil {
+			return nil, fmt.Errorf("anki: scanning review: %w", err)
+		}
+		reviews[r.CardID] = append(reviews[r.CardID], r)
+	}
+
+	return reviews, rows.Err()
+}
+
+// Field returns the value of a named field of a note, or "" when the note
+// type has no such field
+func (nt NoteType) Field(n Note, name string) string {
+	for i, field := range nt.Fields {
+		if field == name && i < len(n.Fields) {
+			return n.Fields[i]
+		}
+	}
+	return ""
+}
+
+// HasField reports whether the note type has a field with the given name
+func (nt NoteType) HasField(name string) bool {
+	for _, field := range nt.Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NextReview returns when the card is next due, or the zero time for new cards.
+// Cards in the intraday learning queue store a timestamp, others a day number
+// relative to the collection creation.
+func (c Card) NextReview(collectionCreated time.Time) time.Time {
+	switch {
+	case c.Type == CardTypeNew:
+		return time.Time{}
+	case c.Queue == 1:
+		return time.Unix(c.Due, 0)
+	default:
+		return collectionCreated.AddDate(0, 0, int(c.Due))
+	}
+}
+
+// Time returns when the review happened
+func (r Review) Time() time.Time {
+	return time.UnixMilli(r.ID)
+}
+
+// IsAnswer reports whether the entry records an answer rather than a manual
+// reschedule or a review in a filtered deck that did not affect scheduling
+func (r Review) IsAnswer() bool {
+	return r.Ease >= 1 && r.Ease <= 4 && r.Type <= 2
+}
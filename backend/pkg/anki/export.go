@@ -0,0 +1,417 @@
+package anki
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schemaVersion is the legacy collection schema written by Export, which every
+// Anki version since 2.1 can import
+const schemaVersion = 11
+
+// defaultDeckID is the ID of the deck every collection contains
+const defaultDeckID = 1
+
+// collectionSchema creates the tables of a schema 11 collection
+var collectionSchema = []string{
+	`CREATE TABLE col (
+		id integer PRIMARY KEY, crt integer NOT NULL, mod integer NOT NULL, scm integer NOT NULL,
+		ver integer NOT NULL, dty integer NOT NULL, usn integer NOT NULL, ls integer NOT NULL,
+		conf text NOT NULL, models text NOT NULL, decks text NOT NULL, dconf text NOT NULL, tags text NOT NULL
+	)`,
+	`CREATE TABLE notes (
+		id integer PRIMARY KEY, guid text NOT NULL, mid integer NOT NULL, mod integer NOT NULL,
+		usn integer NOT NULL, tags text NOT NULL, flds text NOT NULL, sfld integer NOT NULL,
+		csum integer NOT NULL, flags integer NOT NULL, data text NOT NULL
+	)`,
+	`CREATE TABLE cards (
+		id integer PRIMARY KEY, nid integer NOT NULL, did integer NOT NULL, ord integer NOT NULL,
+		mod integer NOT NULL, usn integer NOT NULL, type integer NOT NULL, queue integer NOT NULL,
+		due integer NOT NULL, ivl integer NOT NULL, factor integer NOT NULL, reps integer NOT NULL,
+		lapses integer NOT NULL, left integer NOT NULL, odue integer NOT NULL, odid integer NOT NULL,
+		flags integer NOT NULL, data text NOT NULL
+	)`,
+	`CREATE TABLE revlog (
+		id integer PRIMARY KEY, cid integer NOT NULL, usn integer NOT NULL, ease integer NOT NULL,
+		ivl integer NOT NULL, lastIvl integer NOT NULL, factor integer NOT NULL, time integer NOT NULL,
+		type integer NOT NULL
+	)`,
+	`CREATE TABLE graves (usn integer NOT NULL, oid integer NOT NULL, type integer NOT NULL)`,
+	`CREATE INDEX ix_notes_usn ON notes (usn)`,
+	`CREATE INDEX ix_cards_usn ON cards (usn)`,
+	`CREATE INDEX ix_revlog_usn ON revlog (usn)`,
+	`CREATE INDEX ix_cards_nid ON cards (nid)`,
+	`CREATE INDEX ix_cards_sched ON cards (did, queue, due)`,
+	`CREATE INDEX ix_revlog_cid ON revlog (cid)`,
+	`CREATE INDEX ix_notes_csum ON notes (csum)`,
+}
+
+// Export describes a deck to write as an .apkg package. Every note gets a
+// single card from the Front and Back templates, which use Anki's
+// {{Field}} syntax.
+type Export struct {
+	DeckID       int64 // Stable IDs let Anki update earlier imports
+	DeckName     string
+	NoteTypeID   int64
+	NoteTypeName string
+	Fields       []string
+	Front        string
+	Back         string
+	CSS          string
+	// EachNote calls add for every note to export, in order, so that notes are
+	// written as they are read instead of all being held in memory
+	EachNote func(add func(ExportNote) error) error
+}
+
+// ExportNote is a note to export
+type ExportNote struct {
+	GUID     string   // Stable ID that lets Anki update the note on re-import
+	Fields   []string // Plain text values in the order of Export.Fields
+	Tags     []string
+	Schedule *Schedule // Nil for cards that have not been studied
+}
+
+// Schedule is the review state of an exported card
+type Schedule struct {
+	Due      time.Time
+	Interval int // Days
+	Factor   int // Ease in permille, 2500 = 250%
+	Reps     int
+	Lapses   int
+}
+
+// Archive is an exported collection ready to be written as a package. Close it
+// to remove the collection database.
+type Archive struct {
+	dir    string
+	dbPath string
+}
+
+// NewArchive builds the collection database of an export in a temporary directory
+func NewArchive(ctx context.Context, e Export) (*Archive, error) {
+	dir, err := os.MkdirTemp("", "anki-export-*")
+	if err != nil {
+		return nil, fmt.Errorf("anki: creating temp dir: %w", err)
+	}
+
+	a := &Archive{dir: dir, dbPath: filepath.Join(dir, "collection.anki2")}
+	if err := a.build(ctx, e); err != nil {
+		a.Close()
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// build writes the collection database
+func (a *Archive) build(ctx context.Context, e Export) error {
+	db, err := sql.Open("sqlite", "file:"+a.dbPath)
+	if err != nil {
+		return fmt.Errorf("anki: creating collection: %w", err)
+	}
+	defer db.Close()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("anki: creating collection: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range collectionSchema {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("anki: creating collection: %w", err)
+		}
+	}
+
+	now := time.Now()
+	notes, err := newNoteWriter(ctx, tx, e, now)
+	if err != nil {
+		return err
+	}
+	defer notes.close()
+
+	if err := e.EachNote(notes.add); err != nil {
+		return err
+	}
+
+	created, err := notes.finish()
+	if err != nil {
+		return err
+	}
+	if err := insertCollection(ctx, tx, e, created, now, notes.count); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("anki: creating collection: %w", err)
+	}
+
+	return db.Close()
+}
+
+// insertCollection writes the collection row with the note type and deck
+func insertCollection(ctx context.Context, tx *sql.Tx, e Export, created, now time.Time, noteCount int) error {
+	mod := now.UnixMilli()
+
+	fields := make([]map[string]interface{}, len(e.Fields))
+	for i, name := range e.Fields {
+		fields[i] = map[string]interface{}{
+			"name": name, "ord": i, "sticky": false, "rtl": false,
+			"font": "Arial", "size": 20, "media": []string{},
+		}
+	}
+
+	noteType := map[string]interface{}{
+		"id": e.NoteTypeID, "name": e.NoteTypeName, "type": 0, "mod": now.Unix(), "usn": -1,
+		"sortf": 0, "did": e.DeckID, "flds": fields,
+		"tmpls": []map[string]interface{}{{
+			"name": "Card 1", "ord": 0, "qfmt": e.Front, "afmt": e.Back,
+			"did": nil, "bqfmt": "", "bafmt": "",
+		}},
+		"css":       e.CSS,
+		"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+		"latexPost": "\\end{document}",
+		"latexsvg":  false,
+		"req":       []interface{}{[]interface{}{0, "any", []int{0}}},
+		"tags":      []string{},
+		"vers":      []string{},
+	}
+
+	deck := func(id int64, name string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": id, "name": name, "desc": "", "mod": now.Unix(), "usn": -1, "conf": 1,
+			"collapsed": false, "browserCollapsed": false, "dyn": 0, "extendNew": 0, "extendRev": 0,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+		}
+	}
+
+	deckConfig := map[string]interface{}{
+		"id": 1, "name": "Default", "mod": 0, "usn": 0, "dyn": false,
+		"maxTaken": 60, "timer": 0, "autoplay": true, "replayq": true,
+		"new": map[string]interface{}{
+			"delays": []float64{1, 10}, "ints": []int{1, 4, 0}, "initialFactor": 2500,
+			"order": 1, "perDay": 20, "bury": false,
+		},
+		"rev": map[string]interface{}{
+			"perDay": 200, "ease4": 1.3, "ivlFct": 1, "maxIvl": 36500, "hardFactor": 1.2, "bury": false,
+		},
+		"lapse": map[string]interface{}{
+			"delays": []float64{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 1,
+		},
+	}
+
+	conf := map[string]interface{}{
+		"nextPos": noteCount + 1, "estTimes": true, "activeDecks": []int64{e.DeckID},
+		"sortType": "noteFld", "timeLim": 0, "sortBackwards": false, "addToCur": true,
+		"curDeck": e.DeckID, "newSpread": 0, "dueCounts": true, "curModel": e.NoteTypeID,
+		"collapseTime": 1200,
+	}
+
+	values := make([]interface{}, 0, 5)
+	for _, v := range []interface{}{
+		conf,
+		map[string]interface{}{strconv.FormatInt(e.NoteTypeID, 10): noteType},
+		map[string]interface{}{
+			strconv.Itoa(defaultDeckID):     deck(defaultDeckID, "Default"),
+			strconv.FormatInt(e.DeckID, 10): deck(e.DeckID, e.DeckName),
+		},
+		map[string]interface{}{"1": deckConfig},
+		map[string]interface{}{},
+	} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("anki: encoding collection: %w", err)
+		}
+		values = append(values, string(data))
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO col (id, crt, mod, scm, ver, dty, usn, ls, conf, models, decks, dconf, tags)
+		VALUES (1, ?, ?, ?, ?, 0, 0, 0, ?, ?, ?, ?, ?)
+	`, append([]interface{}{created.Unix(), mod, mod, schemaVersion}, values...)...)
+	if err != nil {
+		return fmt.Errorf("anki: writing collection: %w", err)
+	}
+
+	return nil
+}
+
+// noteWriter writes notes and their cards one at a time. The due day of a
+// review card counts from the creation of the collection, which is the start
+// of the day of the earliest review, so until every note is written it holds
+// days since the Unix epoch and finish makes it relative.
+type noteWriter struct {
+	ctx       context.Context
+	tx        *sql.Tx
+	e         Export
+	now       time.Time
+	noteStmt  *sql.Stmt
+	cardStmt  *sql.Stmt
+	count     int
+	earliest  time.Time
+	hasReview bool
+}
+
+// newNoteWriter prepares the statements that write notes and cards
+func newNoteWriter(ctx context.Context, tx *sql.Tx, e Export, now time.Time) (*noteWriter, error) {
+	noteStmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO notes (id, guid, mid, mod, usn, tags, flds, sfld, csum, flags, data)
+		VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("anki: writing notes: %w", err)
+	}
+
+	cardStmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO cards (id, nid, did, ord, mod, usn, type, queue, due, ivl, factor, reps, lapses, left, odue, odid, flags, data)
+		VALUES (?, ?, ?, 0, ?, -1, ?, ?, ?, ?, ?, ?, ?, 0, 0, 0, 0, '')
+	`)
+	if err != nil {
+		noteStmt.Close()
+		return nil, fmt.Errorf("anki: writing cards: %w", err)
+	}
+
+	return &noteWriter{ctx: ctx, tx: tx, e: e, now: now, noteStmt: noteStmt, cardStmt: cardStmt, earliest: now}, nil
+}
+
+// add writes a note and its card
+func (w *noteWriter) add(n ExportNote) error {
+	// Note and card IDs are creation times in milliseconds
+	id := w.now.UnixMilli() + int64(w.count)
+	w.count++
+
+	fields := make([]string, len(w.e.Fields))
+	for j := range fields {
+		if j < len(n.Fields) {
+			fields[j] = strings.ReplaceAll(html.EscapeString(n.Fields[j]), "\n", "<br>")
+		}
+	}
+	sortField := ""
+	if len(n.Fields) > 0 {
+		sortField = n.Fields[0]
+	}
+
+	tags := ""
+	if len(n.Tags) > 0 {
+		tags = " " + strings.Join(n.Tags, " ") + " "
+	}
+
+	_, err := w.noteStmt.ExecContext(w.ctx, id, n.GUID, w.e.NoteTypeID, w.now.Unix(), tags,
+		strings.Join(fields, "\x1f"), sortField, checksum(sortField))
+	if err != nil {
+		return fmt.Errorf("anki: writing note: %w", err)
+	}
+
+	// New cards are due in order of their position
+	cardType, queue, due := CardTypeNew, 0, int64(w.count)
+	var interval, factor, reps, lapses int
+	if s := n.Schedule; s != nil {
+		cardType, queue = CardTypeReview, 2
+		due = epochDay(s.Due)
+		interval, factor, reps, lapses = s.Interval, s.Factor, s.Reps, s.Lapses
+		if interval < 1 {
+			interval = 1
+		}
+		if s.Due.Before(w.earliest) {
+			w.earliest = s.Due
+		}
+		w.hasReview = true
+	}
+
+	_, err = w.cardStmt.ExecContext(w.ctx, id, id, w.e.DeckID, w.now.Unix(), cardType, queue, due,
+		interval, factor, reps, lapses)
+	if err != nil {
+		return fmt.Errorf("anki: writing card: %w", err)
+	}
+
+	return nil
+}
+
+// finish makes the due days of review cards relative to the creation of the
+// collection and returns the creation time
+func (w *noteWriter) finish() (time.Time, error) {
+	e := w.earliest.UTC()
+	created := time.Date(e.Year(), e.Month(), e.Day(), 0, 0, 0, 0, time.UTC)
+	if !w.hasReview {
+		return created, nil
+	}
+
+	_, err := w.tx.ExecContext(w.ctx, `UPDATE cards SET due = due - ? WHERE type = ?`,
+		epochDay(created), CardTypeReview)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("anki: writing cards: %w", err)
+	}
+
+	return created, nil
+}
+
+// close releases the prepared statements
+func (w *noteWriter) close() {
+	w.noteStmt.Close()
+	w.cardStmt.Close()
+}
+
+// epochDay returns the number of days from the Unix epoch to t in UTC
+func epochDay(t time.Time) int64 {
+	return int64(math.Floor(float64(t.Unix()) / 86400))
+}
+
+// checksum is Anki's duplicate check value: the first 8 hex digits of the
+// SHA-1 of the sort field
+func checksum(field string) int64 {
+	sum := sha1.Sum([]byte(field))
+	n, _ := strconv.ParseInt(hex.EncodeToString(sum[:4]), 16, 64)
+	return n
+}
+
+// Write streams the package to w as a zip archive
+func (a *Archive) Write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	f, err := os.Open(a.dbPath)
+	if err != nil {
+		return fmt.Errorf("anki: reading collection: %w", err)
+	}
+	defer f.Close()
+
+	dst, err := zw.Create("collection.anki2")
+	if err != nil {
+		return fmt.Errorf("anki: writing package: %w", err)
+	}
+	if _, err := io.Copy(dst, f); err != nil {
+		return fmt.Errorf("anki: writing package: %w", err)
+	}
+
+	// The media file maps numbered media files to their names; exports have none
+	media, err := zw.Create("media")
+	if err != nil {
+		return fmt.Errorf("anki: writing package: %w", err)
+	}
+	if _, err := io.WriteString(media, "{}"); err != nil {
+		return fmt.Errorf("anki: writing package: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("anki: writing package: %w", err)
+	}
+
+	return nil
+}
+
+// Close removes the collection database
+func (a *Archive) Close() error {
+	return os.RemoveAll(a.dir)
+}
@@ -0,0 +1,53 @@
+package anki
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	soundTag  = regexp.MustCompile(`\[sound:[^\]]*\]`)
+	lineBreak = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>`)
+	htmlTag   = regexp.MustCompile(`<[^>]*>`)
+	spaces    = regexp.MustCompile(`[ \t]+`)
+	// furigana matches Anki's "漢字[かんじ]" ruby syntax, with the optional
+	// space that separates the base text from preceding text
+	furigana = regexp.MustCompile(` ?([^ \[\]]+)\[([^\]]*)\]`)
+)
+
+// PlainText converts an HTML field value to plain text: tags and sound
+// references are removed, entities decoded and whitespace collapsed
+func PlainText(field string) string {
+	s := soundTag.ReplaceAllString(field, "")
+	s = lineBreak.ReplaceAllString(s, "\n")
+	s = htmlTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, " ", " ")
+
+	lines := strings.Split(s, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.TrimSpace(spaces.ReplaceAllString(line, " ")); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// FuriganaBase returns the base text of a field in furigana syntax:
+// "食[た]べ 物[もの]" becomes "食べ物"
+func FuriganaBase(s string) string {
+	return furigana.ReplaceAllString(s, "$1")
+}
+
+// FuriganaReading returns the reading of a field in furigana syntax:
+// "食[た]べ 物[もの]" becomes "たべもの"
+func FuriganaReading(s string) string {
+	return furigana.ReplaceAllString(s, "$2")
+}
+
+// HasFurigana reports whether a field uses furigana syntax
+func HasFurigana(s string) bool {
+	return furigana.MatchString(s)
+}
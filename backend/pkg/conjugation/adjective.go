@@ -0,0 +1,67 @@
+package conjugation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// iAdjectiveForms returns every form of an い-adjective. いい conjugates
+// from よい: よくない, よかった.
+func iAdjectiveForms(word string) (map[Form][]string, error) {
+	stem, ok := strings.CutSuffix(word, "い")
+	if !ok || stem == "" {
+		return nil, fmt.Errorf("%w: i-adjective %q must end in い", ErrInvalidWord, word)
+	}
+	if prefix, ok := strings.CutSuffix(word, "いい"); ok {
+		stem = prefix + "よ"
+	}
+
+	return map[Form][]string{
+		Plain:              {word},
+		Attributive:        {word},
+		Negative:           {stem + "くない"},
+		Past:               {stem + "かった"},
+		PastNegative:       {stem + "くなかった"},
+		Polite:             {word + "です"},
+		PoliteNegative:     {stem + "くないです", stem + "くありません"},
+		PolitePast:         {stem + "かったです"},
+		PolitePastNegative: {stem + "くなかったです", stem + "くありませんでした"},
+		Te:                 {stem + "くて"},
+		NegativeTe:         {stem + "くなくて"},
+		Adverbial:          {stem + "く"},
+		ConditionalBa:      {stem + "ければ"},
+		ConditionalTara:    {stem + "かったら"},
+		Tari:               {stem + "かったり"},
+	}, nil
+}
+
+// naAdjectiveForms returns every form of a な-adjective, given with or
+// without its な. The negative forms accept では next to the spoken じゃ.
+func naAdjectiveForms(word string) (map[Form][]string, error) {
+	stem := strings.TrimSuffix(word, "な")
+	if stem == "" {
+		return nil, fmt.Errorf("%w: na-adjective %q is empty", ErrInvalidWord, word)
+	}
+
+	negative := func(suffix string) []string {
+		return []string{stem + "じゃ" + suffix, stem + "では" + suffix}
+	}
+
+	return map[Form][]string{
+		Plain:              {stem + "だ"},
+		Attributive:        {stem + "な"},
+		Negative:           negative("ない"),
+		Past:               {stem + "だった"},
+		PastNegative:       negative("なかった"),
+		Polite:             {stem + "です"},
+		PoliteNegative:     append(negative("ありません"), stem+"じゃないです", stem+"ではないです"),
+		PolitePast:         {stem + "でした"},
+		PolitePastNegative: append(negative("ありませんでした"), stem+"じゃなかったです", stem+"ではなかったです"),
+		Te:                 {stem + "で"},
+		NegativeTe:         negative("なくて"),
+		Adverbial:          {stem + "に"},
+		ConditionalBa:      {stem + "なら", stem + "ならば"},
+		ConditionalTara:    {stem + "だったら"},
+		Tari:               {stem + "だったり"},
+	}, nil
+}
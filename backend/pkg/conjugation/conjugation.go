@@ -0,0 +1,203 @@
+// Package conjugation produces the inflected forms of Japanese verbs and
+// adjectives taught at JLPT N5 and N4. Only the dictionary form and the
+// conjugation class are needed; words may be written in kanji or kana, so
+// the reading of a word conjugates the same way as its spelling.
+package conjugation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Class is the conjugation class of a word. The values double as the
+// vocabulary's part_of_speech for verbs and adjectives.
+type Class string
+
+const (
+	// Godan verbs change the last kana across the vowel rows: 飲む, 書く, 帰る
+	Godan Class = "godan verb"
+	// Ichidan verbs drop る: 食べる, 見る
+	Ichidan Class = "ichidan verb"
+	// Irregular verbs are する and its compounds (勉強する), 来る, 行く, ある
+	// and the honorific verbs ending in -aru (いらっしゃる, くださる)
+	Irregular Class = "irregular verb"
+	// IAdjective is an adjective ending in い: 高い, いい
+	IAdjective Class = "i-adjective"
+	// NaAdjective is an adjective taking な before nouns: 静か, きれい
+	NaAdjective Class = "na-adjective"
+)
+
+// Classes lists every conjugation class
+var Classes = []Class{Godan, Ichidan, Irregular, IAdjective, NaAdjective}
+
+// Form is an inflected form
+type Form string
+
+// Forms shared by verbs and adjectives
+const (
+	Plain              Form = "plain"                // 食べる, 高い, 静かだ
+	Negative           Form = "negative"             // 食べない, 高くない, 静かじゃない
+	Past               Form = "past"                 // 食べた, 高かった, 静かだった
+	PastNegative       Form = "past_negative"        // 食べなかった, 高くなかった
+	Polite             Form = "polite"               // 食べます, 高いです, 静かです
+	PoliteNegative     Form = "polite_negative"      // 食べません, 高くないです
+	PolitePast         Form = "polite_past"          // 食べました, 高かったです
+	PolitePastNegative Form = "polite_past_negative" // 食べませんでした, 高くなかったです
+	Te                 Form = "te"                   // 食べて, 高くて, 静かで
+	NegativeTe         Form = "negative_te"          // 食べなくて, 高くなくて
+	ConditionalBa      Form = "conditional_ba"       // 食べれば, 高ければ, 静かなら
+	ConditionalTara    Form = "conditional_tara"     // 食べたら, 高かったら
+	Tari               Form = "tari"                 // 食べたり, 高かったり
+)
+
+// Verb forms
+const (
+	Stem             Form = "stem"              // ます-stem: 食べ, 飲み
+	PoliteVolitional Form = "polite_volitional" // 食べましょう
+	Volitional       Form = "volitional"        // 食べよう, 飲もう
+	Desire           Form = "desire"            // 食べたい
+	Progressive      Form = "progressive"       // 食べている
+	NegativeRequest  Form = "negative_request"  // 食べないで
+	Potential        Form = "potential"         // 食べられる, 飲める
+	Passive          Form = "passive"           // 食べられる, 飲まれる
+	Causative        Form = "causative"         // 食べさせる, 飲ませる
+	CausativePassive Form = "causative_passive" // 食べさせられる, 飲まされる
+	Imperative       Form = "imperative"        // 食べろ, 飲め
+	Prohibitive      Form = "prohibitive"       // 食べるな
+)
+
+// Adjective forms
+const (
+	Adverbial   Form = "adverbial"   // 高く, 静かに
+	Attributive Form = "attributive" // 高い, 静かな (before a noun)
+)
+
+// VerbForms lists the forms of verbs, from basic to advanced
+var VerbForms = []Form{
+	Plain, Polite, Negative, PoliteNegative, Past, PolitePast, PastNegative, PolitePastNegative,
+	Te, Stem, Desire, Progressive, Volitional, PoliteVolitional, NegativeTe, NegativeRequest,
+	ConditionalBa, ConditionalTara, Tari, Potential, Passive, Causative, CausativePassive,
+	Imperative, Prohibitive,
+}
+
+// AdjectiveForms lists the forms of adjectives, from basic to advanced
+var AdjectiveForms = []Form{
+	Plain, Polite, Negative, PoliteNegative, Past, PolitePast, PastNegative, PolitePastNegative,
+	Te, NegativeTe, Adverbial, Attributive, ConditionalBa, ConditionalTara, Tari,
+}
+
+var (
+	// ErrUnknownClass is returned for a class that is not one of Classes
+	ErrUnknownClass = errors.New("unknown conjugation class")
+	// ErrInvalidWord is returned when a word cannot belong to its class,
+	// e.g. a godan verb not ending in an u-row kana
+	ErrInvalidWord = errors.New("word does not match its conjugation class")
+	// ErrUnsupportedForm is returned for a form the class does not have
+	ErrUnsupportedForm = errors.New("form does not apply to the conjugation class")
+)
+
+// ParseClass returns the conjugation class named by a part of speech, which
+// is matched ignoring case and surrounding space
+func ParseClass(partOfSpeech string) (Class, bool) {
+	partOfSpeech = strings.ToLower(strings.TrimSpace(partOfSpeech))
+	for _, class := range Classes {
+		if string(class) == partOfSpeech {
+			return class, true
+		}
+	}
+	return "", false
+}
+
+// godanLookalikes are godan verbs ending in -iru or -eru, which look like
+// ichidan verbs. Kana spellings shared with a common ichidan verb, such as
+// ねる (寝る) and しめる (閉める), are left out.
+var godanLookalikes = []string{
+	"帰る", "入る", "はいる", "要る", "切る", "知る", "しる", "走る", "はしる", "減る", "へる",
+	"限る", "喋る", "しゃべる", "滑る", "すべる", "握る", "にぎる", "参る", "まいる", "散る", "ちる",
+	"照る", "蹴る", "ける", "焦る", "あせる", "湿る", "茂る", "しげる", "練る",
+	"混じる", "まじる", "覆る", "遮る", "さえぎる", "陥る", "罵る", "甦る", "蘇る", "よみがえる", "捻る", "ひねる",
+}
+
+// naAdjectivesInI are な-adjectives ending in い
+var naAdjectivesInI = []string{"きれい", "綺麗", "奇麗", "嫌い", "きらい", "大嫌い", "だいきらい", "ていねい", "しつれい"}
+
+// InferClass returns the conjugation class of a word whose part of speech is
+// only given as verb or adjective, guessed from its spelling and reading.
+// It returns false for any other part of speech.
+func InferClass(word, reading, partOfSpeech string) (Class, bool) {
+	switch strings.ToLower(strings.TrimSpace(partOfSpeech)) {
+	case "verb":
+		switch {
+		case strings.HasSuffix(word, "する"),
+			strings.HasSuffix(word, "来る") && strings.HasSuffix(reading, "くる"),
+			word == "くる", isIku(word), isAru(word), slices.Contains(honorificVerbs, word):
+			return Irregular, true
+		}
+
+		r := []rune(reading)
+		if len(r) >= 2 && r[len(r)-1] == 'る' && strings.ContainsRune(ichidanEndings, r[len(r)-2]) &&
+			!slices.Contains(godanLookalikes, word) {
+			return Ichidan, true
+		}
+		return Godan, true
+	case "adjective":
+		if strings.HasSuffix(word, "い") && !slices.Contains(naAdjectivesInI, word) {
+			return IAdjective, true
+		}
+		return NaAdjective, true
+	}
+	return "", false
+}
+
+// IsVerb reports whether the class is a verb class
+func (c Class) IsVerb() bool {
+	return c == Godan || c == Ichidan || c == Irregular
+}
+
+// Forms returns the forms of a class
+func (c Class) Forms() []Form {
+	if c.IsVerb() {
+		return VerbForms
+	}
+	return AdjectiveForms
+}
+
+// Conjugate returns the standard spelling of a form of a word
+func Conjugate(word string, class Class, form Form) (string, error) {
+	variants, err := Variants(word, class, form)
+	if err != nil {
+		return "", err
+	}
+	return variants[0], nil
+}
+
+// Variants returns every accepted spelling of a form of a word, the standard
+// one first. Forms with common alternatives, such as 静かではない next to
+// 静かじゃない or 高くありません next to 高くないです, have several.
+func Variants(word string, class Class, form Form) ([]string, error) {
+	var (
+		forms map[Form][]string
+		err   error
+	)
+	switch class {
+	case Godan, Ichidan, Irregular:
+		forms, err = verbForms(word, class)
+	case IAdjective:
+		forms, err = iAdjectiveForms(word)
+	case NaAdjective:
+		forms, err = naAdjectiveForms(word)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownClass, class)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	variants, ok := forms[form]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s of %s", ErrUnsupportedForm, form, class)
+	}
+	return variants, nil
+}
@@ -0,0 +1,429 @@
+package conjugation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// golden holds every form of one word of each class, variants in order
+var golden = []struct {
+	word  string
+	class Class
+	forms map[Form][]string
+}{
+	{
+		word:  "飲む",
+		class: Godan,
+		forms: map[Form][]string{
+			Plain:              {"飲む"},
+			Polite:             {"飲みます"},
+			Negative:           {"飲まない"},
+			PoliteNegative:     {"飲みません"},
+			Past:               {"飲んだ"},
+			PolitePast:         {"飲みました"},
+			PastNegative:       {"飲まなかった"},
+			PolitePastNegative: {"飲みませんでした"},
+			Te:                 {"飲んで"},
+			Stem:               {"飲み"},
+			Desire:             {"飲みたい"},
+			Progressive:        {"飲んでいる"},
+			Volitional:         {"飲もう"},
+			PoliteVolitional:   {"飲みましょう"},
+			NegativeTe:         {"飲まなくて"},
+			NegativeRequest:    {"飲まないで"},
+			ConditionalBa:      {"飲めば"},
+			ConditionalTara:    {"飲んだら"},
+			Tari:               {"飲んだり"},
+			Potential:          {"飲める"},
+			Passive:            {"飲まれる"},
+			Causative:          {"飲ませる"},
+			CausativePassive:   {"飲まされる", "飲ませられる"},
+			Imperative:         {"飲め"},
+			Prohibitive:        {"飲むな"},
+		},
+	},
+	{
+		word:  "食べる",
+		class: Ichidan,
+		forms: map[Form][]string{
+			Plain:              {"食べる"},
+			Polite:             {"食べます"},
+			Negative:           {"食べない"},
+			PoliteNegative:     {"食べません"},
+			Past:               {"食べた"},
+			PolitePast:         {"食べました"},
+			PastNegative:       {"食べなかった"},
+			PolitePastNegative: {"食べませんでした"},
+			Te:                 {"食べて"},
+			Stem:               {"食べ"},
+			Desire:             {"食べたい"},
+			Progressive:        {"食べている"},
+			Volitional:         {"食べよう"},
+			PoliteVolitional:   {"食べましょう"},
+			NegativeTe:         {"食べなくて"},
+			NegativeRequest:    {"食べないで"},
+			ConditionalBa:      {"食べれば"},
+			ConditionalTara:    {"食べたら"},
+			Tari:               {"食べたり"},
+			Potential:          {"食べられる", "食べれる"},
+			Passive:            {"食べられる"},
+			Causative:          {"食べさせる"},
+			CausativePassive:   {"食べさせられる"},
+			Imperative:         {"食べろ", "食べよ"},
+			Prohibitive:        {"食べるな"},
+		},
+	},
+	{
+		word:  "する",
+		class: Irregular,
+		forms: map[Form][]string{
+			Plain:              {"する"},
+			Polite:             {"します"},
+			Negative:           {"しない"},
+			PoliteNegative:     {"しません"},
+			Past:               {"した"},
+			PolitePast:         {"しました"},
+			PastNegative:       {"しなかった"},
+			PolitePastNegative: {"しませんでした"},
+			Te:                 {"して"},
+			Stem:               {"し"},
+			Desire:             {"したい"},
+			Progressive:        {"している"},
+			Volitional:         {"しよう"},
+			PoliteVolitional:   {"しましょう"},
+			NegativeTe:         {"しなくて"},
+			NegativeRequest:    {"しないで"},
+			ConditionalBa:      {"すれば"},
+			ConditionalTara:    {"したら"},
+			Tari:               {"したり"},
+			Potential:          {"できる"},
+			Passive:            {"される"},
+			Causative:          {"させる"},
+			CausativePassive:   {"させられる"},
+			Imperative:         {"しろ", "せよ"},
+			Prohibitive:        {"するな"},
+		},
+	},
+	{
+		word:  "くる",
+		class: Irregular,
+		forms: map[Form][]string{
+			Plain:              {"くる"},
+			Polite:             {"きます"},
+			Negative:           {"こない"},
+			PoliteNegative:     {"きません"},
+			Past:               {"きた"},
+			PolitePast:         {"きました"},
+			PastNegative:       {"こなかった"},
+			PolitePastNegative: {"きませんでした"},
+			Te:                 {"きて"},
+			Stem:               {"き"},
+			Desire:             {"きたい"},
+			Progressive:        {"きている"},
+			Volitional:         {"こよう"},
+			PoliteVolitional:   {"きましょう"},
+			NegativeTe:         {"こなくて"},
+			NegativeRequest:    {"こないで"},
+			ConditionalBa:      {"くれば"},
+			ConditionalTara:    {"きたら"},
+			Tari:               {"きたり"},
+			Potential:          {"こられる", "これる"},
+			Passive:            {"こられる"},
+			Causative:          {"こさせる"},
+			CausativePassive:   {"こさせられる"},
+			Imperative:         {"こい"},
+			Prohibitive:        {"くるな"},
+		},
+	},
+	{
+		word:  "高い",
+		class: IAdjective,
+		forms: map[Form][]string{
+			Plain:              {"高い"},
+			Polite:             {"高いです"},
+			Negative:           {"高くない"},
+			PoliteNegative:     {"高くないです", "高くありません"},
+			Past:               {"高かった"},
+			PolitePast:         {"高かったです"},
+			PastNegative:       {"高くなかった"},
+			PolitePastNegative: {"高くなかったです", "高くありませんでした"},
+			Te:                 {"高くて"},
+			NegativeTe:         {"高くなくて"},
+			Adverbial:          {"高く"},
+			Attributive:        {"高い"},
+			ConditionalBa:      {"高ければ"},
+			ConditionalTara:    {"高かったら"},
+			Tari:               {"高かったり"},
+		},
+	},
+	{
+		word:  "静か",
+		class: NaAdjective,
+		forms: map[Form][]string{
+			Plain:              {"静かだ"},
+			Polite:             {"静かです"},
+			Negative:           {"静かじゃない", "静かではない"},
+			PoliteNegative:     {"静かじゃありません", "静かではありません", "静かじゃないです", "静かではないです"},
+			Past:               {"静かだった"},
+			PolitePast:         {"静かでした"},
+			PastNegative:       {"静かじゃなかった", "静かではなかった"},
+			PolitePastNegative: {"静かじゃありませんでした", "静かではありませんでした", "静かじゃなかったです", "静かではなかったです"},
+			Te:                 {"静かで"},
+			NegativeTe:         {"静かじゃなくて", "静かではなくて"},
+			Adverbial:          {"静かに"},
+			Attributive:        {"静かな"},
+			ConditionalBa:      {"静かなら", "静かならば"},
+			ConditionalTara:    {"静かだったら"},
+			Tari:               {"静かだったり"},
+		},
+	},
+}
+
+func TestVariantsGolden(t *testing.T) {
+	covered := make(map[Class]bool)
+	for _, g := range golden {
+		covered[g.class] = true
+		t.Run(g.word, func(t *testing.T) {
+			if len(g.forms) != len(g.class.Forms()) {
+				t.Errorf("golden table of %s has %d forms, the class has %d", g.word, len(g.forms), len(g.class.Forms()))
+			}
+			for _, form := range g.class.Forms() {
+				want, ok := g.forms[form]
+				if !ok {
+					t.Errorf("golden table of %s is missing %s", g.word, form)
+					continue
+				}
+				got, err := Variants(g.word, g.class, form)
+				if err != nil {
+					t.Errorf("Variants(%q, %s, %s) error: %v", g.word, g.class, form, err)
+					continue
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Variants(%q, %s, %s) = %q, want %q", g.word, g.class, form, got, want)
+				}
+				if standard, _ := Conjugate(g.word, g.class, form); standard != want[0] {
+					t.Errorf("Conjugate(%q, %s, %s) = %q, want %q", g.word, g.class, form, standard, want[0])
+				}
+			}
+		})
+	}
+
+	for _, class := range Classes {
+		if !covered[class] {
+			t.Errorf("no golden table for %s", class)
+		}
+	}
+}
+
+func TestVariants(t *testing.T) {
+	tests := []struct {
+		word  string
+		class Class
+		form  Form
+		want  []string
+	}{
+		// 行く takes って and った instead of いて and いた
+		{"行く", Godan, Te, []string{"行って"}},
+		{"行く", Godan, Past, []string{"行った"}},
+		{"行く", Irregular, Te, []string{"行って"}},
+		{"行く", Irregular, Past, []string{"行った"}},
+		{"いく", Irregular, ConditionalTara, []string{"いったら"}},
+		{"持って行く", Irregular, Past, []string{"持って行った"}},
+		{"行く", Irregular, Negative, []string{"行かない"}},
+
+		// ある has no negative stem
+		{"ある", Irregular, Negative, []string{"ない"}},
+		{"ある", Irregular, PastNegative, []string{"なかった"}},
+		{"ある", Godan, Negative, []string{"ない"}},
+		{"ある", Irregular, Polite, []string{"あります"}},
+		{"有る", Irregular, NegativeTe, []string{"なくて"}},
+
+		// する and its compounds
+		{"する", Irregular, Potential, []string{"できる"}},
+		{"勉強する", Irregular, Potential, []string{"勉強できる"}},
+		{"勉強する", Irregular, Negative, []string{"勉強しない"}},
+		{"勉強する", Irregular, Polite, []string{"勉強します"}},
+
+		// 来る keeps its kanji while the reading changes
+		{"来る", Irregular, Negative, []string{"来ない"}},
+		{"来る", Irregular, Polite, []string{"来ます"}},
+		{"来る", Irregular, Potential, []string{"来られる", "来れる"}},
+		{"くる", Irregular, Negative, []string{"こない"}},
+		{"くる", Irregular, Potential, []string{"こられる", "これる"}},
+		{"持ってくる", Irregular, Te, []string{"持ってきて"}},
+
+		// いい conjugates from よい
+		{"いい", IAdjective, Plain, []string{"いい"}},
+		{"いい", IAdjective, Past, []string{"よかった"}},
+		{"いい", IAdjective, Negative, []string{"よくない"}},
+		{"いい", IAdjective, ConditionalBa, []string{"よければ"}},
+		{"かっこいい", IAdjective, Negative, []string{"かっこよくない"}},
+		{"かっこいい", IAdjective, Past, []string{"かっこよかった"}},
+		{"かっこいい", IAdjective, Attributive, []string{"かっこいい"}},
+
+		// Honorific verbs end their ます-stem and imperative in い
+		{"いらっしゃる", Irregular, Polite, []string{"いらっしゃいます"}},
+		{"くださる", Irregular, Imperative, []string{"ください"}},
+		{"くださる", Godan, Negative, []string{"くださらない"}},
+
+		// な-adjectives are accepted with their な
+		{"きれいな", NaAdjective, Negative, []string{"きれいじゃない", "きれいではない"}},
+		{"きれい", NaAdjective, Attributive, []string{"きれいな"}},
+
+		// Ichidan verbs written in kana
+		{"みる", Ichidan, Negative, []string{"みない"}},
+		{"おきる", Ichidan, Potential, []string{"おきられる", "おきれる"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word+" "+string(tt.form), func(t *testing.T) {
+			got, err := Variants(tt.word, tt.class, tt.form)
+			if err != nil {
+				t.Fatalf("Variants(%q, %s, %s) error: %v", tt.word, tt.class, tt.form, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Variants(%q, %s, %s) = %q, want %q", tt.word, tt.class, tt.form, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGodanEndings(t *testing.T) {
+	tests := []struct {
+		word             string
+		negative         string
+		polite           string
+		te               string
+		past             string
+		causativePassive []string
+	}{
+		{"買う", "買わない", "買います", "買って", "買った", []string{"買わされる", "買わせられる"}},
+		{"待つ", "待たない", "待ちます", "待って", "待った", []string{"待たされる", "待たせられる"}},
+		{"帰る", "帰らない", "帰ります", "帰って", "帰った", []string{"帰らされる", "帰らせられる"}},
+		{"読む", "読まない", "読みます", "読んで", "読んだ", []string{"読まされる", "読ませられる"}},
+		{"遊ぶ", "遊ばない", "遊びます", "遊んで", "遊んだ", []string{"遊ばされる", "遊ばせられる"}},
+		{"死ぬ", "死なない", "死にます", "死んで", "死んだ", []string{"死なされる", "死なせられる"}},
+		{"書く", "書かない", "書きます", "書いて", "書いた", []string{"書かされる", "書かせられる"}},
+		{"泳ぐ", "泳がない", "泳ぎます", "泳いで", "泳いだ", []string{"泳がされる", "泳がせられる"}},
+		{"話す", "話さない", "話します", "話して", "話した", []string{"話させられる"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			want := map[Form][]string{
+				Negative:         {tt.negative},
+				Polite:           {tt.polite},
+				Te:               {tt.te},
+				Past:             {tt.past},
+				CausativePassive: tt.causativePassive,
+			}
+			for form, variants := range want {
+				got, err := Variants(tt.word, Godan, form)
+				if err != nil {
+					t.Errorf("Variants(%q, %s, %s) error: %v", tt.word, Godan, form, err)
+					continue
+				}
+				if !reflect.DeepEqual(got, variants) {
+					t.Errorf("Variants(%q, %s, %s) = %q, want %q", tt.word, Godan, form, got, variants)
+				}
+			}
+		})
+	}
+}
+
+func TestVariantsErrors(t *testing.T) {
+	tests := []struct {
+		word  string
+		class Class
+		form  Form
+		want  error
+	}{
+		{"飲む", "noun", Plain, ErrUnknownClass},
+		{"きれい", Godan, Plain, ErrInvalidWord},
+		{"飲む", Ichidan, Plain, ErrInvalidWord},
+		{"わかる", Ichidan, Plain, ErrInvalidWord},
+		{"る", Ichidan, Plain, ErrInvalidWord},
+		{"飲む", Irregular, Plain, ErrInvalidWord},
+		{"静か", IAdjective, Plain, ErrInvalidWord},
+		{"い", IAdjective, Plain, ErrInvalidWord},
+		{"な", NaAdjective, Plain, ErrInvalidWord},
+		{"飲む", Godan, Adverbial, ErrUnsupportedForm},
+		{"高い", IAdjective, Potential, ErrUnsupportedForm},
+		{"静か", NaAdjective, Stem, ErrUnsupportedForm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word+" "+string(tt.class), func(t *testing.T) {
+			if _, err := Variants(tt.word, tt.class, tt.form); !errors.Is(err, tt.want) {
+				t.Errorf("Variants(%q, %s, %s) error = %v, want %v", tt.word, tt.class, tt.form, err, tt.want)
+			}
+			if _, err := Conjugate(tt.word, tt.class, tt.form); !errors.Is(err, tt.want) {
+				t.Errorf("Conjugate(%q, %s, %s) error = %v, want %v", tt.word, tt.class, tt.form, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseClass(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Class
+		ok    bool
+	}{
+		{"godan verb", Godan, true},
+		{"  Ichidan Verb ", Ichidan, true},
+		{"IRREGULAR VERB", Irregular, true},
+		{"i-adjective", IAdjective, true},
+		{"na-adjective", NaAdjective, true},
+		{"noun", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseClass(tt.input)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ParseClass(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestInferClass(t *testing.T) {
+	tests := []struct {
+		word         string
+		reading      string
+		partOfSpeech string
+		want         Class
+		ok           bool
+	}{
+		{"食べる", "たべる", "verb", Ichidan, true},
+		{"見る", "みる", " Verb ", Ichidan, true},
+		{"飲む", "のむ", "verb", Godan, true},
+		{"帰る", "かえる", "verb", Godan, true},
+		{"ねる", "ねる", "verb", Ichidan, true},
+		{"しめる", "しめる", "verb", Ichidan, true},
+		{"練る", "ねる", "verb", Godan, true},
+		{"勉強する", "べんきょうする", "verb", Irregular, true},
+		{"来る", "くる", "verb", Irregular, true},
+		{"持って行く", "もっていく", "verb", Irregular, true},
+		{"ある", "ある", "verb", Irregular, true},
+		{"いらっしゃる", "いらっしゃる", "verb", Irregular, true},
+		{"高い", "たかい", "adjective", IAdjective, true},
+		{"きれい", "きれい", "adjective", NaAdjective, true},
+		{"静か", "しずか", "ADJECTIVE", NaAdjective, true},
+		{"食べる", "たべる", "ichidan verb", "", false},
+		{"猫", "ねこ", "noun", "", false},
+		{"猫", "ねこ", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word+" "+tt.partOfSpeech, func(t *testing.T) {
+			got, ok := InferClass(tt.word, tt.reading, tt.partOfSpeech)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("InferClass(%q, %q, %q) = %q, %v, want %q, %v", tt.word, tt.reading, tt.partOfSpeech, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
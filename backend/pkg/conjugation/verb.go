@@ -0,0 +1,261 @@
+package conjugation
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/joaosantos/jlpt5/pkg/kana"
+)
+
+// The kana rows godan verbs inflect across, indexed by the dictionary
+// form's last kana
+var (
+	uRow = []rune("うくぐすつぬぶむる")
+	aRow = []rune("わかがさたなばまら")
+	iRow = []rune("いきぎしちにびみり")
+	eRow = []rune("えけげせてねべめれ")
+	oRow = []rune("おこごそとのぼもろ")
+)
+
+// ichidanEndings are the kana an ichidan verb can have before る
+const ichidanEndings = "いきぎしじちぢにひびぴみりえけげせぜてでねへべぺめれ"
+
+// honorificVerbs are the godan verbs whose ます-stem and imperative end in
+// い instead of り: いらっしゃいます, ください
+var honorificVerbs = []string{"いらっしゃる", "くださる", "下さる", "おっしゃる", "仰る", "なさる", "為さる", "ござる", "御座る"}
+
+// stems holds the pieces every verb form is built from
+type stems struct {
+	plain            string   // 飲む
+	negative         string   // Before ない: 飲ま
+	masu             string   // Before ます: 飲み
+	te, ta           string   // 飲んで, 飲んだ
+	ba               string   // 飲めば
+	volitional       string   // 飲もう
+	potential        []string // 飲める
+	passive          string   // 飲まれる
+	causative        string   // 飲ませる
+	causativePassive []string // 飲まされる, 飲ませられる
+	imperative       []string // 飲め
+}
+
+// verbForms returns every form of a verb
+func verbForms(word string, class Class) (map[Form][]string, error) {
+	var (
+		s   stems
+		err error
+	)
+	switch class {
+	case Godan:
+		s, err = godanStems(word)
+	case Ichidan:
+		s, err = ichidanStems(word)
+	case Irregular:
+		s, err = irregularStems(word)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return map[Form][]string{
+		Plain:              {s.plain},
+		Stem:               {s.masu},
+		Negative:           {s.negative + "ない"},
+		Past:               {s.ta},
+		PastNegative:       {s.negative + "なかった"},
+		Polite:             {s.masu + "ます"},
+		PoliteNegative:     {s.masu + "ません"},
+		PolitePast:         {s.masu + "ました"},
+		PolitePastNegative: {s.masu + "ませんでした"},
+		PoliteVolitional:   {s.masu + "ましょう"},
+		Te:                 {s.te},
+		NegativeTe:         {s.negative + "なくて"},
+		NegativeRequest:    {s.negative + "ないで"},
+		Desire:             {s.masu + "たい"},
+		Progressive:        {s.te + "いる"},
+		ConditionalBa:      {s.ba},
+		ConditionalTara:    {s.ta + "ら"},
+		Tari:               {s.ta + "り"},
+		Volitional:         {s.volitional},
+		Potential:          s.potential,
+		Passive:            {s.passive},
+		Causative:          {s.causative},
+		CausativePassive:   s.causativePassive,
+		Imperative:         s.imperative,
+		Prohibitive:        {s.plain + "な"},
+	}, nil
+}
+
+// godanStems inflects a godan verb, including the godan verbs with irregular
+// forms: 行く (行って), ある (ない) and the honorific verbs
+func godanStems(word string) (stems, error) {
+	last, size := utf8.DecodeLastRuneInString(word)
+	row := indexRune(uRow, last)
+	if row < 0 {
+		return stems{}, fmt.Errorf("%w: godan verb %q must end in an u-row kana", ErrInvalidWord, word)
+	}
+	base := word[:len(word)-size]
+	a, i, e, o := string(aRow[row]), string(iRow[row]), string(eRow[row]), string(oRow[row])
+
+	s := stems{
+		plain:      word,
+		negative:   base + a,
+		masu:       base + i,
+		ba:         base + e + "ば",
+		volitional: base + o + "う",
+		potential:  []string{base + e + "る"},
+		passive:    base + a + "れる",
+		causative:  base + a + "せる",
+		imperative: []string{base + e},
+	}
+
+	// The short causative passive (飲まされる) is the common one, except
+	// after す where only the long form exists
+	s.causativePassive = []string{base + a + "せられる"}
+	if last != 'す' {
+		s.causativePassive = []string{base + a + "される", base + a + "せられる"}
+	}
+
+	switch last {
+	case 'う', 'つ', 'る':
+		s.te, s.ta = base+"って", base+"った"
+	case 'む', 'ぶ', 'ぬ':
+		s.te, s.ta = base+"んで", base+"んだ"
+	case 'く':
+		s.te, s.ta = base+"いて", base+"いた"
+		if isIku(word) {
+			s.te, s.ta = base+"って", base+"った"
+		}
+	case 'ぐ':
+		s.te, s.ta = base+"いで", base+"いだ"
+	case 'す':
+		s.te, s.ta = base+"して", base+"した"
+	}
+
+	// ある has no negative stem: ない, not あらない
+	if isAru(word) {
+		s.negative = ""
+	}
+	if _, ok := cutSuffix(word, honorificVerbs...); ok {
+		s.masu = base + "い"
+		s.imperative = []string{base + "い"}
+	}
+
+	return s, nil
+}
+
+// ichidanStems inflects an ichidan verb
+func ichidanStems(word string) (stems, error) {
+	stem, ok := strings.CutSuffix(word, "る")
+	if !ok || stem == "" {
+		return stems{}, fmt.Errorf("%w: ichidan verb %q must end in る", ErrInvalidWord, word)
+	}
+	// The kana before る must be in the i- or e-row (見る, 食べる)
+	if r, _ := utf8.DecodeLastRuneInString(stem); kana.IsHiragana(r) && !strings.ContainsRune(ichidanEndings, r) {
+		return stems{}, fmt.Errorf("%w: ichidan verb %q must end in -iru or -eru", ErrInvalidWord, word)
+	}
+
+	return stems{
+		plain:            word,
+		negative:         stem,
+		masu:             stem,
+		te:               stem + "て",
+		ta:               stem + "た",
+		ba:               stem + "れば",
+		volitional:       stem + "よう",
+		potential:        []string{stem + "られる", stem + "れる"},
+		passive:          stem + "られる",
+		causative:        stem + "させる",
+		causativePassive: []string{stem + "させられる"},
+		imperative:       []string{stem + "ろ", stem + "よ"},
+	}, nil
+}
+
+// irregularStems inflects する and its compounds, 来る, and the godan verbs
+// with irregular forms
+func irregularStems(word string) (stems, error) {
+	if prefix, ok := strings.CutSuffix(word, "する"); ok {
+		potential := prefix + "できる"
+		return stems{
+			plain:            word,
+			negative:         prefix + "し",
+			masu:             prefix + "し",
+			te:               prefix + "して",
+			ta:               prefix + "した",
+			ba:               prefix + "すれば",
+			volitional:       prefix + "しよう",
+			potential:        []string{potential},
+			passive:          prefix + "される",
+			causative:        prefix + "させる",
+			causativePassive: []string{prefix + "させられる"},
+			imperative:       []string{prefix + "しろ", prefix + "せよ"},
+		}, nil
+	}
+
+	// 来る keeps its kanji while the kana reading changes: 来ない is こない
+	if prefix, ok := strings.CutSuffix(word, "来る"); ok {
+		return kuruStems(word, prefix+"来", prefix+"来", prefix+"来"), nil
+	}
+	if prefix, ok := strings.CutSuffix(word, "くる"); ok {
+		return kuruStems(word, prefix+"こ", prefix+"き", prefix+"く"), nil
+	}
+
+	if isIku(word) || isAru(word) {
+		return godanStems(word)
+	}
+	if _, ok := cutSuffix(word, honorificVerbs...); ok {
+		return godanStems(word)
+	}
+
+	return stems{}, fmt.Errorf("%w: %q is not a known irregular verb", ErrInvalidWord, word)
+}
+
+// kuruStems inflects 来る from its o-, i- and u-row stems (こ, き, く in kana)
+func kuruStems(word, ko, ki, ku string) stems {
+	return stems{
+		plain:            word,
+		negative:         ko,
+		masu:             ki,
+		te:               ki + "て",
+		ta:               ki + "た",
+		ba:               ku + "れば",
+		volitional:       ko + "よう",
+		potential:        []string{ko + "られる", ko + "れる"},
+		passive:          ko + "られる",
+		causative:        ko + "させる",
+		causativePassive: []string{ko + "させられる"},
+		imperative:       []string{ko + "い"},
+	}
+}
+
+// isIku reports whether a verb is 行く or a compound ending in it (持って行く)
+func isIku(word string) bool {
+	_, ok := cutSuffix(word, "行く", "いく")
+	return ok
+}
+
+// isAru reports whether a verb is ある
+func isAru(word string) bool {
+	return word == "ある" || word == "有る" || word == "在る"
+}
+
+// cutSuffix returns word without the first of the suffixes it ends with
+func cutSuffix(word string, suffixes ...string) (string, bool) {
+	for _, suffix := range suffixes {
+		if prefix, ok := strings.CutSuffix(word, suffix); ok {
+			return prefix, true
+		}
+	}
+	return word, false
+}
+
+// indexRune returns the index of r in row, or -1
+func indexRune(row []rune, r rune) int {
+	for i, c := range row {
+		if c == r {
+			return i
+		}
+	}
+	return -1
+}
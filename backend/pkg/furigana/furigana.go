@@ -0,0 +1,134 @@
+// Package furigana annotates Japanese text with the readings of its kanji as
+// ruby segments
+package furigana
+
+import (
+	"strings"
+
+	"github.com/joaosantos/jlpt5/pkg/kana"
+	"github.com/joaosantos/jlpt5/pkg/tokenizer"
+)
+
+// Segment is a piece of text with the reading shown above it. Reading is
+// empty for kana, punctuation and other text that needs no furigana.
+type Segment struct {
+	Text    string
+	Reading string
+}
+
+// Align splits a word into segments, matching the kana of the word against
+// its reading so that only the kanji get furigana: 食べ物 read たべもの
+// becomes 食(た) べ 物(もの). When the reading does not fit the word, the
+// whole word gets the reading.
+func Align(word, reading string) []Segment {
+	if reading == "" || !strings.ContainsFunc(word, kana.IsKanji) {
+		return []Segment{{Text: word}}
+	}
+
+	if segments, ok := alignBlocks(splitBlocks(word), []rune(kana.ToHiragana(reading))); ok {
+		return segments
+	}
+	return []Segment{{Text: word, Reading: kana.ToHiragana(reading)}}
+}
+
+// Annotate builds the segments of a tokenized text. Text between tokens,
+// such as spaces, is kept without a reading. readingOf may override the
+// reading of a token, e.g. with the reading of a known vocabulary item, and
+// returns "" to keep the tokenizer's reading.
+func Annotate(text string, tokens []tokenizer.Token, readingOf func(tokenizer.Token) string) []Segment {
+	runes := []rune(text)
+	var segments []Segment
+	offset := 0
+	for _, token := range tokens {
+		if token.Start > offset && token.Start <= len(runes) {
+			segments = appendSegments(segments, Segment{Text: string(runes[offset:token.Start])})
+		}
+
+		reading := token.Reading
+		if readingOf != nil {
+			if override := readingOf(token); override != "" {
+				reading = override
+			}
+		}
+		segments = appendSegments(segments, Align(token.Surface, reading)...)
+		offset = token.End
+	}
+	if offset < len(runes) {
+		segments = appendSegments(segments, Segment{Text: string(runes[offset:])})
+	}
+	return segments
+}
+
+// appendSegments appends segments, merging neighbouring segments without a reading
+func appendSegments(segments []Segment, more ...Segment) []Segment {
+	for _, s := range more {
+		if s.Text == "" {
+			continue
+		}
+		if n := len(segments); n > 0 && s.Reading == "" && segments[n-1].Reading == "" {
+			segments[n-1].Text += s.Text
+			continue
+		}
+		segments = append(segments, s)
+	}
+	return segments
+}
+
+// block is a run of kana or of other characters of a word
+type block struct {
+	text   string
+	isKana bool
+}
+
+// splitBlocks splits a word into alternating runs of kana and other characters
+func splitBlocks(word string) []block {
+	var blocks []block
+	for _, r := range word {
+		isKana := kana.IsKana(r)
+		if n := len(blocks); n > 0 && blocks[n-1].isKana == isKana {
+			blocks[n-1].text += string(r)
+			continue
+		}
+		blocks = append(blocks, block{text: string(r), isKana: isKana})
+	}
+	return blocks
+}
+
+// alignBlocks matches blocks against a hiragana reading. Kana blocks must
+// appear in the reading as written; every other block takes at least one
+// character of the reading, backtracking until the kana blocks fit.
+func alignBlocks(blocks []block, reading []rune) ([]Segment, bool) {
+	if len(blocks) == 0 {
+		return nil, len(reading) == 0
+	}
+
+	b := blocks[0]
+	if b.isKana {
+		written := []rune(kana.ToHiragana(b.text))
+		if len(reading) < len(written) || string(reading[:len(written)]) != string(written) {
+			return nil, false
+		}
+		rest, ok := alignBlocks(blocks[1:], reading[len(written):])
+		if !ok {
+			return nil, false
+		}
+		return append([]Segment{{Text: b.text}}, rest...), true
+	}
+
+	if len(blocks) == 1 {
+		if len(reading) == 0 {
+			return nil, false
+		}
+		return []Segment{{Text: b.text, Reading: string(reading)}}, true
+	}
+
+	for n := 1; n < len(reading); n++ {
+		if !strings.HasPrefix(string(reading[n:]), kana.ToHiragana(blocks[1].text)) {
+			continue
+		}
+		if rest, ok := alignBlocks(blocks[1:], reading[n:]); ok {
+			return append([]Segment{{Text: b.text, Reading: string(reading[:n])}}, rest...), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,118 @@
+package furigana
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joaosantos/jlpt5/pkg/tokenizer"
+)
+
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		name    string
+		word    string
+		reading string
+		want    []Segment
+	}{
+		// Okurigana
+		{"okurigana", "見る", "みる", []Segment{{"見", "み"}, {"る", ""}}},
+		{"okurigana between kanji", "食べ物", "たべもの", []Segment{{"食", "た"}, {"べ", ""}, {"物", "もの"}}},
+		{"long okurigana", "大人しい", "おとなしい", []Segment{{"大人", "おとな"}, {"しい", ""}}},
+		{"prefix kana", "お茶", "おちゃ", []Segment{{"お", ""}, {"茶", "ちゃ"}}},
+
+		// Kana in the middle of a word
+		{"kana between kanji", "行き先", "いきさき", []Segment{{"行", "い"}, {"き", ""}, {"先", "さき"}}},
+		{"several kana blocks", "取り扱い", "とりあつかい", []Segment{{"取", "と"}, {"り", ""}, {"扱", "あつか"}, {"い", ""}}},
+		{"particle-like kana", "間に合う", "まにあう", []Segment{{"間", "ま"}, {"に", ""}, {"合", "あ"}, {"う", ""}}},
+		{"katakana in the word", "消しゴム", "けしごむ", []Segment{{"消", "け"}, {"しゴム", ""}}},
+
+		// Katakana readings are shown in hiragana
+		{"katakana reading", "食べ物", "タベモノ", []Segment{{"食", "た"}, {"べ", ""}, {"物", "もの"}}},
+		{"katakana reading of kanji only", "東京", "トウキョウ", []Segment{{"東京", "とうきょう"}}},
+
+		// Readings that do not fit get the whole word
+		{"kanji only", "日本", "にほん", []Segment{{"日本", "にほん"}}},
+		{"wrong okurigana", "見る", "みた", []Segment{{"見る", "みた"}}},
+		{"reading too short", "食べ物", "たべ", []Segment{{"食べ物", "たべ"}}},
+		{"kana missing from the reading", "行き先", "ゆくさき", []Segment{{"行き先", "ゆくさき"}}},
+		{"katakana reading that does not fit", "見る", "ミタ", []Segment{{"見る", "みた"}}},
+
+		// Words that need no furigana
+		{"kana word", "ねこ", "ねこ", []Segment{{"ねこ", ""}}},
+		{"katakana word", "コーヒー", "こーひー", []Segment{{"コーヒー", ""}}},
+		{"no reading", "猫", "", []Segment{{"猫", ""}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Align(tt.word, tt.reading); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Align(%q, %q) = %v, want %v", tt.word, tt.reading, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlignBlocks(t *testing.T) {
+	tests := []struct {
+		word    string
+		reading string
+		want    []Segment
+		ok      bool
+	}{
+		{"食べ物", "たべもの", []Segment{{"食", "た"}, {"べ", ""}, {"物", "もの"}}, true},
+		// The first kanji block takes as little of the reading as lets the rest fit
+		{"子ども", "こども", []Segment{{"子", "こ"}, {"ども", ""}}, true},
+		{"日曜日", "にちようび", []Segment{{"日曜日", "にちようび"}}, true},
+		{"食べ物", "たべ", nil, false},
+		{"食べ物", "たべもの、", []Segment{{"食", "た"}, {"べ", ""}, {"物", "もの、"}}, true},
+		{"見る", "みるく", nil, false},
+		{"見る", "る", nil, false},
+		{"ねこ", "ねこ", []Segment{{"ねこ", ""}}, true},
+		{"ねこ", "いぬ", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word+" "+tt.reading, func(t *testing.T) {
+			got, ok := alignBlocks(splitBlocks(tt.word), []rune(tt.reading))
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("alignBlocks(%q, %q) = %v, %v, want %v, %v", tt.word, tt.reading, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	text := "私は 食べ物が好き"
+	tokens := []tokenizer.Token{
+		{Surface: "私", Reading: "わたし", Start: 0, End: 1},
+		{Surface: "は", Reading: "は", Start: 1, End: 2},
+		{Surface: "食べ物", Reading: "たべもの", Start: 3, End: 6},
+		{Surface: "が", Reading: "が", Start: 6, End: 7},
+		{Surface: "好き", Reading: "すき", Start: 7, End: 9},
+	}
+
+	want := []Segment{
+		{"私", "わたし"},
+		{"は ", ""},
+		{"食", "た"},
+		{"べ", ""},
+		{"物", "もの"},
+		{"が", ""},
+		{"好", "す"},
+		{"き", ""},
+	}
+	if got := Annotate(text, tokens, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("Annotate(%q) = %v, want %v", text, got, want)
+	}
+
+	// An override replaces the tokenizer's reading
+	readingOf := func(token tokenizer.Token) string {
+		if token.Surface == "私" {
+			return "わたくし"
+		}
+		return ""
+	}
+	if got := Annotate(text, tokens, readingOf); got[0] != (Segment{"私", "わたくし"}) {
+		t.Errorf("Annotate with an override starts with %v, want %v", got[0], Segment{"私", "わたくし"})
+	}
+}
@@ -0,0 +1,351 @@
+package kana
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	hiraganaStart = 'ぁ'
+	hiraganaEnd   = 'ゖ'
+	katakanaStart = 'ァ'
+	katakanaEnd   = 'ヶ'
+
+	// katakanaOffset is the distance between a katakana rune and its hiragana twin
+	katakanaOffset = katakanaStart - hiraganaStart
+)
+
+// halfWidthKatakana maps half-width katakana (U+FF61–U+FF9F) to full-width forms
+var halfWidthKatakana = map[rune]rune{
+	'｡': '。', '｢': '「', '｣': '」', '､': '、', '･': '・',
+	'ｦ': 'ヲ', 'ｧ': 'ァ', 'ｨ': 'ィ', 'ｩ': 'ゥ', 'ｪ': 'ェ', 'ｫ': 'ォ',
+	'ｬ': 'ャ', 'ｭ': 'ュ', 'ｮ': 'ョ', 'ｯ': 'ッ', 'ｰ': 'ー',
+	'ｱ': 'ア', 'ｲ': 'イ', 'ｳ': 'ウ', 'ｴ': 'エ', 'ｵ': 'オ',
+	'ｶ': 'カ', 'ｷ': 'キ', 'ｸ': 'ク', 'ｹ': 'ケ', 'ｺ': 'コ',
+	'ｻ': 'サ', 'ｼ': 'シ', 'ｽ': 'ス', 'ｾ': 'セ', 'ｿ': 'ソ',
+	'ﾀ': 'タ', 'ﾁ': 'チ', 'ﾂ': 'ツ', 'ﾃ': 'テ', 'ﾄ': 'ト',
+	'ﾅ': 'ナ', 'ﾆ': 'ニ', 'ﾇ': 'ヌ', 'ﾈ': 'ネ', 'ﾉ': 'ノ',
+	'ﾊ': 'ハ', 'ﾋ': 'ヒ', 'ﾌ': 'フ', 'ﾍ': 'ヘ', 'ﾎ': 'ホ',
+	'ﾏ': 'マ', 'ﾐ': 'ミ', 'ﾑ': 'ム', 'ﾒ': 'メ', 'ﾓ': 'モ',
+	'ﾔ': 'ヤ', 'ﾕ': 'ユ', 'ﾖ': 'ヨ',
+	'ﾗ': 'ラ', 'ﾘ': 'リ', 'ﾙ': 'ル', 'ﾚ': 'レ', 'ﾛ': 'ロ',
+	'ﾜ': 'ワ', 'ﾝ': 'ン',
+}
+
+// voicedForms maps an unvoiced kana to its dakuten (゛) form
+var voicedForms = map[rune]rune{
+	'か': 'が', 'き': 'ぎ', 'く': 'ぐ', 'け': 'げ', 'こ': 'ご',
+	'さ': 'ざ', 'し': 'じ', 'す': 'ず', 'せ': 'ぜ', 'そ': 'ぞ',
+	'た': 'だ', 'ち': 'ぢ', 'つ': 'づ', 'て': 'で', 'と': 'ど',
+	'は': 'ば', 'ひ': 'び', 'ふ': 'ぶ', 'へ': 'べ', 'ほ': 'ぼ',
+	'う': 'ゔ',
+	'カ': 'ガ', 'キ': 'ギ', 'ク': 'グ', 'ケ': 'ゲ', 'コ': 'ゴ',
+	'サ': 'ザ', 'シ': 'ジ', 'ス': 'ズ', 'セ': 'ゼ', 'ソ': 'ゾ',
+	'タ': 'ダ', 'チ': 'ヂ', 'ツ': 'ヅ', 'テ': 'デ', 'ト': 'ド',
+	'ハ': 'バ', 'ヒ': 'ビ', 'フ': 'ブ', 'ヘ': 'ベ', 'ホ': 'ボ',
+	'ウ': 'ヴ',
+}
+
+// semiVoicedForms maps a は-row kana to its handakuten (゜) form
+var semiVoicedForms = map[rune]rune{
+	'は': 'ぱ', 'ひ': 'ぴ', 'ふ': 'ぷ', 'へ': 'ぺ', 'ほ': 'ぽ',
+	'ハ': 'パ', 'ヒ': 'ピ', 'フ': 'プ', 'ヘ': 'ペ', 'ホ': 'ポ',
+}
+
+// unvoicedForms is the reverse of voicedForms and semiVoicedForms
+var unvoicedForms = func() map[rune]rune {
+	m := make(map[rune]rune, len(voicedForms)+len(semiVoicedForms))
+	for plain, voiced := range voicedForms {
+		m[voiced] = plain
+	}
+	for plain, semiVoiced := range semiVoicedForms {
+		m[semiVoiced] = plain
+	}
+	return m
+}()
+
+// smallKana maps small kana to their full-size counterparts
+var smallKana = map[rune]rune{
+	'ぁ': 'あ', 'ぃ': 'い', 'ぅ': 'う', 'ぇ': 'え', 'ぉ': 'お',
+	'っ': 'つ', 'ゃ': 'や', 'ゅ': 'ゆ', 'ょ': 'よ', 'ゎ': 'わ',
+	'ゕ': 'か', 'ゖ': 'け',
+	'ァ': 'ア', 'ィ': 'イ', 'ゥ': 'ウ', 'ェ': 'エ', 'ォ': 'オ',
+	'ッ': 'ツ', 'ャ': 'ヤ', 'ュ': 'ユ', 'ョ': 'ヨ', 'ヮ': 'ワ',
+	'ヵ': 'カ', 'ヶ': 'ケ',
+}
+
+// IsHiragana reports whether r is a hiragana letter
+func IsHiragana(r rune) bool {
+	return r >= hiraganaStart && r <= hiraganaEnd
+}
+
+// IsKatakana reports whether r is a full-width katakana letter
+func IsKatakana(r rune) bool {
+	return r >= katakanaStart && r <= katakanaEnd
+}
+
+// IsKana reports whether r is hiragana, katakana or the prolonged sound mark
+func IsKana(r rune) bool {
+	return IsHiragana(r) || IsKatakana(r) || r == 'ー'
+}
+
+// IsKanji reports whether r is a CJK ideograph (including the 々 repetition mark)
+func IsKanji(r rune) bool {
+	return unicode.Is(unicode.Han, r) || r == '々'
+}
+
+// ContainsKanji reports whether s contains at least one kanji
+func ContainsKanji(s string) bool {
+	for _, r := range s {
+		if IsKanji(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAllKana reports whether s is non-empty and made only of kana
+func IsAllKana(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !IsKana(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ToHiragana converts every katakana letter in s to hiragana, leaving other runes untouched
+func ToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if IsKatakana(r) {
+			return r - katakanaOffset
+		}
+		return r
+	}, s)
+}
+
+// ToKatakana converts every hiragana letter in s to katakana, leaving other runes untouched
+func ToKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if IsHiragana(r) {
+			return r + katakanaOffset
+		}
+		return r
+	}, s)
+}
+
+// NormalizeWidth folds full-width ASCII to half-width and half-width katakana to
+// full-width, so that "ＡＢＣ" becomes "ABC" and "ｶﾞｯｺｳ" becomes "ガッコウ"
+func NormalizeWidth(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '　':
+			b.WriteRune(' ')
+		case r >= '！' && r <= '～':
+			b.WriteRune(r - '！' + '!')
+		case r >= '｡' && r <= 'ﾟ':
+			full, ok := halfWidthKatakana[r]
+			if !ok {
+				// Stray sound marks without a base letter are dropped
+				continue
+			}
+			// Half-width text spells voiced kana as two runes (ｶ + ﾞ)
+			if i+1 < len(runes) {
+				switch runes[i+1] {
+				case 'ﾞ':
+					if voiced, ok := voicedForms[full]; ok {
+						full = voiced
+						i++
+					}
+				case 'ﾟ':
+					if semiVoiced, ok := semiVoicedForms[full]; ok {
+						full = semiVoiced
+						i++
+					}
+				}
+			}
+			b.WriteRune(full)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// StripDakuten removes dakuten and handakuten, turning "がっこう" into "かっこう"
+func StripDakuten(s string) string {
+	return strings.Map(func(r rune) rune {
+		if plain, ok := unvoicedForms[r]; ok {
+			return plain
+		}
+		return r
+	}, s)
+}
+
+// EnlargeSmallKana replaces small kana with their full-size forms ("きょう" -> "きよう")
+func EnlargeSmallKana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if large, ok := smallKana[r]; ok {
+			return large
+		}
+		return r
+	}, s)
+}
+
+// StripSokuon removes the small tsu (っ/ッ) used to mark geminate consonants
+func StripSokuon(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == 'っ' || r == 'ッ' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// StripLongVowels removes the prolonged sound mark (ー) and vowels that only
+// lengthen the previous syllable, so "がっこう" and "がっこ" compare equal
+func StripLongVowels(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	var prevVowel byte
+	for _, r := range s {
+		if r == 'ー' {
+			continue
+		}
+
+		vowel := vowelOf(r)
+		if prevVowel != 0 && isLengthening(prevVowel, vowel) && isVowelKana(r) {
+			continue
+		}
+
+		b.WriteRune(r)
+		if vowel != 0 {
+			prevVowel = vowel
+		}
+	}
+
+	return b.String()
+}
+
+// Morae splits kana into morae, the units of Japanese rhythm: small ゃ, ゅ,
+// ょ and the small vowels belong to the kana before them, while っ, ん and ー
+// are morae of their own. きょうと is きょ, う, と.
+func Morae(s string) []string {
+	var morae []string
+	for _, r := range s {
+		if n := len(morae); n > 0 && r != 'っ' && r != 'ッ' {
+			if _, small := smallKana[r]; small {
+				morae[n-1] += string(r)
+				continue
+			}
+		}
+		morae = append(morae, string(r))
+	}
+	return morae
+}
+
+// MoraSlips returns the hiragana spellings one mora away from s that
+// learners mistake it for: a dakuten added or dropped, a small tsu or a long
+// vowel added or dropped, a small kana written large. がっこう gives かっこう,
+// がこう, がっこ and more. s itself is not included.
+func MoraSlips(s string) []string {
+	morae := Morae(ToHiragana(s))
+	seen := map[string]bool{strings.Join(morae, ""): true}
+	var slips []string
+	add := func(i int, replacement ...string) {
+		slip := strings.Join(morae[:i], "") + strings.Join(replacement, "") + strings.Join(morae[i+1:], "")
+		if !seen[slip] {
+			seen[slip] = true
+			slips = append(slips, slip)
+		}
+	}
+
+	var prevVowel byte
+	for i, mora := range morae {
+		runes := []rune(mora)
+		first, rest := runes[0], string(runes[1:])
+		vowel := vowelOf(runes[len(runes)-1])
+
+		// ぢ, づ and ゔ are too rare to be mistaken for
+		if voiced, ok := voicedForms[first]; ok && first != 'う' && first != 'ち' && first != 'つ' {
+			add(i, string(voiced)+rest)
+		}
+		if semiVoiced, ok := semiVoicedForms[first]; ok {
+			add(i, string(semiVoiced)+rest)
+		}
+		if plain, ok := unvoicedForms[first]; ok {
+			add(i, string(plain)+rest)
+		}
+		if large, ok := smallKana[runes[len(runes)-1]]; ok && len(runes) > 1 {
+			add(i, string(runes[:len(runes)-1]), string(large))
+		}
+
+		switch {
+		case mora == "っ" || mora == "ー":
+			add(i)
+		case prevVowel != 0 && isVowelKana(first) && isLengthening(prevVowel, vowel):
+			add(i)
+		default:
+			if i > 0 && vowel != 0 && !isVowelKana(first) && !isSpecialMora(morae[i-1]) {
+				add(i, "っ", mora)
+			}
+			if vowel != 0 && (i+1 == len(morae) || !isVowelKana([]rune(morae[i+1])[0]) && !isSpecialMora(morae[i+1])) {
+				add(i, mora, lengtheningVowels[vowel])
+			}
+		}
+
+		prevVowel = vowel
+	}
+
+	return slips
+}
+
+// isSpecialMora reports whether a mora is っ, ん or ー, which only follow a syllable
+func isSpecialMora(mora string) bool {
+	return mora == "っ" || mora == "ん" || mora == "ー"
+}
+
+// lengtheningVowels is the kana that lengthens a syllable ending in a vowel
+var lengtheningVowels = map[byte]string{'a': "あ", 'i': "い", 'u': "う", 'e': "い", 'o': "う"}
+
+// isLengthening reports whether a vowel kana with vowel next extends a syllable
+// ending in prev: a repeated vowel, or the お+う and え+い spellings
+func isLengthening(prev, next byte) bool {
+	return prev == next || (prev == 'o' && next == 'u') || (prev == 'e' && next == 'i')
+}
+
+// isVowelKana reports whether r is one of the plain vowels あいうえお (in either script)
+func isVowelKana(r rune) bool {
+	switch ToHiragana(string(r)) {
+	case "あ", "い", "う", "え", "お":
+		return true
+	}
+	return false
+}
+
+// vowelOf returns the vowel a kana syllable ends in, or 0 for ん, っ and non-kana
+func vowelOf(r rune) byte {
+	syllable := ToHiragana(string(r))
+	if large, ok := smallKana[[]rune(syllable)[0]]; ok && syllable != "っ" {
+		syllable = string(large)
+	}
+	romaji, ok := hiraganaToRomaji[syllable]
+	if !ok {
+		return 0
+	}
+	last := romaji[len(romaji)-1]
+	if !isVowel(last) {
+		return 0
+	}
+	return last
+}
@@ -0,0 +1,264 @@
+package kana
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestIsAllKana(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"ひらがな", true},
+		{"カタカナ", true},
+		{"コーヒー", true},
+		{"ひらがなとカタカナ", true},
+		{"日本", false},
+		{"日本ご", false},
+		{"kana", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsAllKana(tt.input); got != tt.want {
+				t.Errorf("IsAllKana(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsKanji(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"日本", true},
+		{"日本ご", true},
+		{"たべ物", true},
+		{"にほん", false},
+		{"ニホン", false},
+		{"nihon", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ContainsKanji(tt.input); got != tt.want {
+				t.Errorf("ContainsKanji(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToHiraganaToKatakana(t *testing.T) {
+	tests := []struct {
+		hiragana string
+		katakana string
+	}{
+		{"がっこう", "ガッコウ"},
+		{"きょう", "キョウ"},
+		{"こんや", "コンヤ"},
+		{"ゔぁ", "ヴァ"},
+		{"日本ご", "日本ゴ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hiragana, func(t *testing.T) {
+			if got := ToKatakana(tt.hiragana); got != tt.katakana {
+				t.Errorf("ToKatakana(%q) = %q, want %q", tt.hiragana, got, tt.katakana)
+			}
+			if got := ToHiragana(tt.katakana); got != tt.hiragana {
+				t.Errorf("ToHiragana(%q) = %q, want %q", tt.katakana, got, tt.hiragana)
+			}
+		})
+	}
+
+	// The prolonged sound mark has no hiragana form
+	if got := ToHiragana("コーヒー"); got != "こーひー" {
+		t.Errorf("ToHiragana(%q) = %q, want %q", "コーヒー", got, "こーひー")
+	}
+}
+
+func TestNormalizeWidth(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"ＡＢＣ", "ABC"},
+		{"ｎｉｈｏｎ　ｇｏ", "nihon go"},
+		{"１２３！", "123!"},
+		{"ｶﾞｯｺｳ", "ガッコウ"},
+		{"ﾊﾟﾝ", "パン"},
+		{"ｺｰﾋｰ", "コーヒー"},
+		{"ｷｮｳ", "キョウ"},
+		{"ﾞｶ", "カ"},
+		{"ｱﾞ", "ア"},
+		{"がっこう", "がっこう"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeWidth(tt.input); got != tt.want {
+				t.Errorf("NormalizeWidth(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripDakuten(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"がっこう", "かっこう"},
+		{"ぱん", "はん"},
+		{"ばん", "はん"},
+		{"ガッコウ", "カッコウ"},
+		{"さくら", "さくら"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := StripDakuten(tt.input); got != tt.want {
+				t.Errorf("StripDakuten(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnlargeSmallKana(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"きょう", "きよう"},
+		{"しゃしん", "しやしん"},
+		{"きって", "きつて"},
+		{"ファイル", "フアイル"},
+		{"さくら", "さくら"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := EnlargeSmallKana(tt.input); got != tt.want {
+				t.Errorf("EnlargeSmallKana(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripSokuon(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"きって", "きて"},
+		{"まっちゃ", "まちゃ"},
+		{"キット", "キト"},
+		{"さくら", "さくら"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := StripSokuon(tt.input); got != tt.want {
+				t.Errorf("StripSokuon(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripLongVowels(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"がっこう", "がっこ"},
+		{"とうきょう", "ときょ"},
+		{"おかあさん", "おかさん"},
+		{"おねえさん", "おねさん"},
+		{"おにいさん", "おにさん"},
+		{"せんせい", "せんせ"},
+		{"コーヒー", "コヒ"},
+		{"かう", "かう"},
+		{"いえ", "いえ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := StripLongVowels(tt.input); got != tt.want {
+				t.Errorf("StripLongVowels(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMorae(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"きょうと", []string{"きょ", "う", "と"}},
+		{"がっこう", []string{"が", "っ", "こ", "う"}},
+		{"しんぶん", []string{"し", "ん", "ぶ", "ん"}},
+		{"コーヒー", []string{"コ", "ー", "ヒ", "ー"}},
+		{"ファイル", []string{"ファ", "イ", "ル"}},
+		{"まっちゃ", []string{"ま", "っ", "ちゃ"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Morae(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Morae(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoraSlips(t *testing.T) {
+	tests := []struct {
+		input    string
+		contains []string
+		excludes []string
+	}{
+		{
+			input:    "がっこう",
+			contains: []string{"かっこう", "がこう", "がっこ", "がっごう"},
+			excludes: []string{"がっこう"},
+		},
+		{
+			input:    "きょう",
+			contains: []string{"きよう", "ぎょう", "きょ"},
+			excludes: []string{"きょう"},
+		},
+		{
+			input:    "ほん",
+			contains: []string{"ぼん", "ぽん"},
+			excludes: []string{"ほん", "ほうん"},
+		},
+		{
+			input:    "コーヒー",
+			contains: []string{"こひー", "こーひ", "ごーひー"},
+			excludes: []string{"こーひー"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			slips := MoraSlips(tt.input)
+			for _, want := range tt.contains {
+				if !slices.Contains(slips, want) {
+					t.Errorf("MoraSlips(%q) = %q, missing %q", tt.input, slips, want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if slices.Contains(slips, unwanted) {
+					t.Errorf("MoraSlips(%q) = %q, should not contain %q", tt.input, slips, unwanted)
+				}
+			}
+		})
+	}
+}
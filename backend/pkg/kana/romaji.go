@@ -0,0 +1,411 @@
+package kana
+
+import "strings"
+
+// System selects the romanization used when converting kana to romaji
+type System string
+
+const (
+	// Hepburn romanization: し = shi, ち = chi, つ = tsu, ふ = fu, じ = ji
+	Hepburn System = "hepburn"
+	// Kunrei-shiki romanization: し = si, ち = ti, つ = tu, ふ = hu, じ = zi
+	Kunrei System = "kunrei"
+)
+
+// romajiToHiragana holds every spelling accepted by FromRomaji: Hepburn,
+// Kunrei/Nihon-shiki, the extended katakana spellings for loanwords and the
+// x/l prefixed small kana used by IMEs
+var romajiToHiragana = map[string]string{
+	// Vowels
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+
+	// Basic syllables (Hepburn)
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"sa": "さ", "shi": "し", "su": "す", "se": "せ", "so": "そ",
+	"ta": "た", "chi": "ち", "tsu": "つ", "te": "て", "to": "と",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"ha": "は", "hi": "ひ", "fu": "ふ", "he": "へ", "ho": "ほ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"wa": "わ", "wo": "を",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"za": "ざ", "ji": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"da": "だ", "de": "で", "do": "ど",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+
+	// Kunrei-shiki and Nihon-shiki spellings
+	"si": "し", "ti": "ち", "tu": "つ", "hu": "ふ", "zi": "じ",
+	"di": "ぢ", "du": "づ",
+
+	// Youon (Hepburn)
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"sha": "しゃ", "shu": "しゅ", "sho": "しょ",
+	"cha": "ちゃ", "chu": "ちゅ", "cho": "ちょ",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"ja": "じゃ", "ju": "じゅ", "jo": "じょ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+
+	// Youon (Kunrei-shiki, Nihon-shiki and IME spellings)
+	"sya": "しゃ", "syu": "しゅ", "syo": "しょ",
+	"tya": "ちゃ", "tyu": "ちゅ", "tyo": "ちょ",
+	"cya": "ちゃ", "cyu": "ちゅ", "cyo": "ちょ",
+	"zya": "じゃ", "zyu": "じゅ", "zyo": "じょ",
+	"jya": "じゃ", "jyu": "じゅ", "jyo": "じょ",
+	"dya": "ぢゃ", "dyu": "ぢゅ", "dyo": "ぢょ",
+
+	// Extended spellings used for loanwords
+	"fa": "ふぁ", "fi": "ふぃ", "fe": "ふぇ", "fo": "ふぉ",
+	"she": "しぇ", "je": "じぇ", "che": "ちぇ", "ye": "いぇ",
+	"wi": "うぃ", "we": "うぇ",
+	"va": "ゔぁ", "vi": "ゔぃ", "vu": "ゔ", "ve": "ゔぇ", "vo": "ゔぉ",
+	"tsa": "つぁ", "tsi": "つぃ", "tse": "つぇ", "tso": "つぉ",
+	"thi": "てぃ", "dhi": "でぃ", "twu": "とぅ", "dwu": "どぅ",
+
+	// Small kana typed explicitly
+	"xa": "ぁ", "xi": "ぃ", "xu": "ぅ", "xe": "ぇ", "xo": "ぉ",
+	"la": "ぁ", "li": "ぃ", "lu": "ぅ", "le": "ぇ", "lo": "ぉ",
+	"xya": "ゃ", "xyu": "ゅ", "xyo": "ょ",
+	"lya": "ゃ", "lyu": "ゅ", "lyo": "ょ",
+	"xtu": "っ", "ltu": "っ", "xtsu": "っ", "ltsu": "っ",
+	"xwa": "ゎ", "lwa": "ゎ",
+
+	"-": "ー",
+}
+
+// maxRomajiSyllable is the length of the longest key in romajiToHiragana
+const maxRomajiSyllable = 4
+
+// hiraganaToHepburn maps kana (single or youon pairs) to Hepburn romaji
+var hiraganaToHepburn = map[string]string{
+	"あ": "a", "い": "i", "う": "u", "え": "e", "お": "o",
+	"か": "ka", "き": "ki", "く": "ku", "け": "ke", "こ": "ko",
+	"さ": "sa", "し": "shi", "す": "su", "せ": "se", "そ": "so",
+	"た": "ta", "ち": "chi", "つ": "tsu", "て": "te", "と": "to",
+	"な": "na", "に": "ni", "ぬ": "nu", "ね": "ne", "の": "no",
+	"は": "ha", "ひ": "hi", "ふ": "fu", "へ": "he", "ほ": "ho",
+	"ま": "ma", "み": "mi", "む": "mu", "め": "me", "も": "mo",
+	"や": "ya", "ゆ": "yu", "よ": "yo",
+	"ら": "ra", "り": "ri", "る": "ru", "れ": "re", "ろ": "ro",
+	"わ": "wa", "ゐ": "i", "ゑ": "e", "を": "wo", "ん": "n",
+	"が": "ga", "ぎ": "gi", "ぐ": "gu", "げ": "ge", "ご": "go",
+	"ざ": "za", "じ": "ji", "ず": "zu", "ぜ": "ze", "ぞ": "zo",
+	"だ": "da", "ぢ": "ji", "づ": "zu", "で": "de", "ど": "do",
+	"ば": "ba", "び": "bi", "ぶ": "bu", "べ": "be", "ぼ": "bo",
+	"ぱ": "pa", "ぴ": "pi", "ぷ": "pu", "ぺ": "pe", "ぽ": "po",
+	"ゔ": "vu",
+
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho",
+	"にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo",
+	"みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"じゃ": "ja", "じゅ": "ju", "じょ": "jo",
+	"ぢゃ": "ja", "ぢゅ": "ju", "ぢょ": "jo",
+	"びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo",
+
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo",
+	"しぇ": "she", "じぇ": "je", "ちぇ": "che", "いぇ": "ye",
+	"うぃ": "wi", "うぇ": "we",
+	"ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo",
+	"つぁ": "tsa", "つぃ": "tsi", "つぇ": "tse", "つぉ": "tso",
+	"てぃ": "ti", "でぃ": "di", "とぅ": "tu", "どぅ": "du",
+
+	"ぁ": "xa", "ぃ": "xi", "ぅ": "xu", "ぇ": "xe", "ぉ": "xo",
+	"ゃ": "xya", "ゅ": "xyu", "ょ": "xyo", "ゎ": "xwa",
+	"ゕ": "xka", "ゖ": "xke",
+}
+
+// kunreiOverrides lists the kana whose Kunrei-shiki spelling differs from Hepburn
+var kunreiOverrides = map[string]string{
+	"し": "si", "ち": "ti", "つ": "tu", "ふ": "hu",
+	"じ": "zi", "ぢ": "zi", "づ": "zu", "を": "o",
+	"しゃ": "sya", "しゅ": "syu", "しょ": "syo",
+	"ちゃ": "tya", "ちゅ": "tyu", "ちょ": "tyo",
+	"じゃ": "zya", "じゅ": "zyu", "じょ": "zyo",
+	"ぢゃ": "zya", "ぢゅ": "zyu", "ぢょ": "zyo",
+}
+
+// macronVowels maps long vowels written with a macron or circumflex to their base vowel
+var macronVowels = map[rune]byte{
+	'ā': 'a', 'ī': 'i', 'ū': 'u', 'ē': 'e', 'ō': 'o',
+	'â': 'a', 'î': 'i', 'û': 'u', 'ê': 'e', 'ô': 'o',
+	'Ā': 'a', 'Ī': 'i', 'Ū': 'u', 'Ē': 'e', 'Ō': 'o',
+	'Â': 'a', 'Î': 'i', 'Û': 'u', 'Ê': 'e', 'Ô': 'o',
+}
+
+// hiraganaToRomaji is the Hepburn spelling of single kana, used to find the
+// vowel a syllable ends in
+var hiraganaToRomaji = func() map[string]string {
+	m := make(map[string]string, len(hiraganaToHepburn))
+	for kana, romaji := range hiraganaToHepburn {
+		if len([]rune(kana)) == 1 {
+			m[kana] = romaji
+		}
+	}
+	m["ー"] = "-"
+	return m
+}()
+
+// IsRomaji reports whether s looks like romaji input: non-empty and made only of
+// ASCII letters, long-vowel letters (ā, ô...), apostrophes, hyphens and spaces
+func IsRomaji(s string) bool {
+	hasLetter := false
+	for _, r := range s {
+		_, isMacron := macronVowels[r]
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', isMacron:
+			hasLetter = true
+		case r == '\'' || r == '-' || r == ' ':
+		default:
+			return false
+		}
+	}
+	return hasLetter
+}
+
+// FromRomaji converts romaji (Hepburn, Kunrei-shiki or IME-style) to hiragana.
+//
+//   - Doubled consonants become っ ("kitte" -> きって, "matcha" -> まっちゃ)
+//   - "n" before a consonant, at the end, "nn" and "n'" become ん; the apostrophe
+//     separates ん from a following vowel ("kin'en" -> きんえん, "kinen" -> きねん)
+//   - "m" before b, m or p is read as ん (traditional Hepburn "shimbun")
+//   - Macrons and circumflexes lengthen the vowel ("tōkyō" -> とうきょう)
+//   - "-" becomes the prolonged sound mark ー
+//
+// Letters that cannot be converted are kept as-is, which callers can detect
+// with IsAllKana.
+func FromRomaji(s string) string {
+	return fromRomaji(s, false)
+}
+
+// FromRomajiKatakana converts romaji to katakana. Long vowels written with a
+// macron become ー ("kōhī" -> コーヒー).
+func FromRomajiKatakana(s string) string {
+	return ToKatakana(fromRomaji(s, true))
+}
+
+func fromRomaji(s string, katakana bool) string {
+	input := expandMacrons(s, katakana)
+
+	var b strings.Builder
+	b.Grow(len(input) * 3)
+
+	for i := 0; i < len(input); {
+		c := input[i]
+
+		if c == 'n' {
+			size := moraicNasalLength(input, i)
+			if size > 0 {
+				b.WriteString("ん")
+				i += size
+				continue
+			}
+		}
+
+		// Traditional Hepburn writes ん as "m" before labials
+		if c == 'm' && i+1 < len(input) && isLabial(input[i+1]) {
+			b.WriteString("ん")
+			i++
+			continue
+		}
+
+		// Sokuon: a doubled consonant marks a geminate, "tch" is the Hepburn っち
+		if i+1 < len(input) && c == input[i+1] && isConsonant(c) && c != 'n' && c != 'm' {
+			b.WriteString("っ")
+			i++
+			continue
+		}
+		if strings.HasPrefix(input[i:], "tch") {
+			b.WriteString("っ")
+			i++
+			continue
+		}
+
+		matched := false
+		for size := maxRomajiSyllable; size > 0; size-- {
+			if i+size > len(input) {
+				continue
+			}
+			if kana, ok := romajiToHiragana[input[i:i+size]]; ok {
+				b.WriteString(kana)
+				i += size
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			// Keep unknown bytes (including multi-byte runes) untouched
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+// moraicNasalLength returns how many bytes starting at input[i] (an "n")
+// spell ん, or 0 when the "n" starts a syllable such as "na" or "nya"
+func moraicNasalLength(input string, i int) int {
+	if i+1 == len(input) {
+		return 1
+	}
+
+	next := input[i+1]
+	switch {
+	case next == '\'':
+		return 2
+	case next == 'n':
+		// "nn" is ん on its own unless the second n starts a syllable ("konnichiwa"),
+		// and an apostrophe after it belongs to the ん ("ann'i")
+		if i+2 < len(input) && input[i+2] == '\'' {
+			return 3
+		}
+		if i+2 == len(input) || (!isVowel(input[i+2]) && input[i+2] != 'y') {
+			return 2
+		}
+		return 1
+	case isVowel(next) || next == 'y':
+		return 0
+	default:
+		return 1
+	}
+}
+
+// expandMacrons lowercases s and spells out long vowels written with a macron
+// or circumflex: as a doubled vowel (ō -> ou) for hiragana, or "-" for katakana
+func expandMacrons(s string, katakana bool) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for _, r := range strings.ToLower(s) {
+		vowel, ok := macronVowels[r]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+
+		b.WriteByte(vowel)
+		switch {
+		case katakana:
+			b.WriteByte('-')
+		case vowel == 'o':
+			b.WriteByte('u')
+		default:
+			b.WriteByte(vowel)
+		}
+	}
+
+	return b.String()
+}
+
+// ToRomaji converts hiragana and katakana in s to romaji using the given system.
+// ん is written "n'" before a vowel or y, っ doubles the next consonant and ー
+// repeats the previous vowel. Runes that are not kana are kept as-is.
+func ToRomaji(s string, system System) string {
+	runes := []rune(ToHiragana(s))
+
+	var b strings.Builder
+	b.Grow(len(s))
+
+	geminate := false
+	var lastVowel byte
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case r == 'っ':
+			geminate = true
+			i++
+			continue
+		case r == 'ー':
+			if lastVowel != 0 {
+				b.WriteByte(lastVowel)
+			}
+			i++
+			continue
+		case r == 'ん':
+			b.WriteString("n")
+			if i+1 < len(runes) {
+				if next := romajiFor(string(runes[i+1]), system); next != "" && (isVowel(next[0]) || next[0] == 'y') {
+					b.WriteString("'")
+				}
+			}
+			lastVowel = 0
+			i++
+			continue
+		}
+
+		// Prefer the two-kana youon spelling when there is one
+		syllable := ""
+		size := 1
+		if i+1 < len(runes) {
+			if romaji := romajiFor(string(runes[i:i+2]), system); romaji != "" {
+				syllable, size = romaji, 2
+			}
+		}
+		if syllable == "" {
+			syllable = romajiFor(string(r), system)
+		}
+
+		if syllable == "" {
+			// Not kana: a pending っ has nothing to double
+			geminate = false
+			lastVowel = 0
+			b.WriteRune(r)
+			i++
+			continue
+		}
+
+		if geminate {
+			if system == Hepburn && strings.HasPrefix(syllable, "ch") {
+				b.WriteByte('t')
+			} else if isConsonant(syllable[0]) {
+				b.WriteByte(syllable[0])
+			}
+			geminate = false
+		}
+
+		b.WriteString(syllable)
+		lastVowel = syllable[len(syllable)-1]
+		if !isVowel(lastVowel) {
+			lastVowel = 0
+		}
+		i += size
+	}
+
+	return b.String()
+}
+
+// romajiFor returns the romaji for a hiragana syllable in the given system, or ""
+func romajiFor(syllable string, system System) string {
+	if system == Kunrei {
+		if romaji, ok := kunreiOverrides[syllable]; ok {
+			return romaji
+		}
+	}
+	return hiraganaToHepburn[syllable]
+}
+
+func isVowel(c byte) bool {
+	return c == 'a' || c == 'i' || c == 'u' || c == 'e' || c == 'o'
+}
+
+func isConsonant(c byte) bool {
+	return c >= 'a' && c <= 'z' && !isVowel(c)
+}
+
+func isLabial(c byte) bool {
+	return c == 'b' || c == 'm' || c == 'p'
+}
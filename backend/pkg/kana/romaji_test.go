@@ -0,0 +1,222 @@
+package kana
+
+import "testing"
+
+func TestFromRomaji(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		// Vowels and basic syllables
+		{"vowels", "aiueo", "あいうえお"},
+		{"basic word", "sakura", "さくら"},
+		{"uppercase", "SAKURA", "さくら"},
+		{"hepburn shi chi tsu fu ji", "shichitsufuji", "しちつふじ"},
+		{"kunrei si ti tu hu zi", "sitituhuzi", "しちつふじ"},
+		{"nihon-shiki di du", "didu", "ぢづ"},
+		{"particle wo", "wo", "を"},
+
+		// Sokuon
+		{"doubled k", "kitte", "きって"},
+		{"doubled p", "kippu", "きっぷ"},
+		{"doubled s", "zasshi", "ざっし"},
+		{"doubled t before ts", "mittsu", "みっつ"},
+		{"tch", "matcha", "まっちゃ"},
+		{"cch", "maccha", "まっちゃ"},
+		{"gakkou", "gakkou", "がっこう"},
+		{"explicit small tsu", "xtu", "っ"},
+
+		// Long vowels
+		{"doubled vowel", "okaasan", "おかあさん"},
+		{"ou", "toukyou", "とうきょう"},
+		{"macron o", "tōkyō", "とうきょう"},
+		{"macron u", "kūki", "くうき"},
+		{"macron a", "okāsan", "おかあさん"},
+		{"circumflex", "tôkyô", "とうきょう"},
+		{"uppercase macron", "ŌSAKA", "おうさか"},
+		{"hyphen", "ko-hi-", "こーひー"},
+
+		// Moraic nasal
+		{"n at the end", "hon", "ほん"},
+		{"n before a consonant", "kanji", "かんじ"},
+		{"n apostrophe before y", "kon'ya", "こんや"},
+		{"n before ya", "konya", "こにゃ"},
+		{"n apostrophe before a vowel", "kin'en", "きんえん"},
+		{"n before a vowel", "kinen", "きねん"},
+		{"nn before a syllable", "konnichiwa", "こんにちわ"},
+		{"nn before a vowel", "onna", "おんな"},
+		{"nn apostrophe", "ann'i", "あんい"},
+		{"nn at the end", "honn", "ほん"},
+		{"nn before a consonant", "onnsen", "おんせん"},
+		{"m before b", "shimbun", "しんぶん"},
+		{"m before p", "sampo", "さんぽ"},
+		{"m before m", "amma", "あんま"},
+
+		// Youon
+		{"kyo", "kyo", "きょ"},
+		{"sha", "sha", "しゃ"},
+		{"ja", "ja", "じゃ"},
+		{"cho", "cho", "ちょ"},
+		{"ryu", "ryuu", "りゅう"},
+		{"nyu", "gyuunyuu", "ぎゅうにゅう"},
+		{"kunrei sya", "sya", "しゃ"},
+		{"kunrei tyo", "tyotto", "ちょっと"},
+		{"kunrei zya", "zya", "じゃ"},
+		{"ime jya", "jya", "じゃ"},
+
+		// Loanword spellings and small kana
+		{"fa", "fairu", "ふぁいる"},
+		{"thi", "thi", "てぃ"},
+		{"small vowels", "xaxixuxexo", "ぁぃぅぇぉ"},
+		{"small ya", "lya", "ゃ"},
+
+		// Text that is not romaji
+		{"unknown letters", "qa", "qあ"},
+		{"kana untouched", "かな", "かな"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromRomaji(tt.input); got != tt.want {
+				t.Errorf("FromRomaji(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromRomajiKatakana(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"kōhī", "コーヒー"},
+		{"ko-hi-", "コーヒー"},
+		{"terebi", "テレビ"},
+		{"kitto", "キット"},
+		{"paathii", "パアティイ"},
+		{"pāthī", "パーティー"},
+		{"fōku", "フォーク"},
+		{"vaiorin", "ヴァイオリン"},
+		{"kon'ya", "コンヤ"},
+		{"shatsu", "シャツ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := FromRomajiKatakana(tt.input); got != tt.want {
+				t.Errorf("FromRomajiKatakana(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToRomaji(t *testing.T) {
+	tests := []struct {
+		input   string
+		hepburn string
+		kunrei  string
+	}{
+		// Basic syllables
+		{"さくら", "sakura", "sakura"},
+		{"しちつふじ", "shichitsufuji", "sitituhuzi"},
+		{"ふじさん", "fujisan", "huzisan"},
+		{"を", "wo", "o"},
+
+		// Sokuon
+		{"きって", "kitte", "kitte"},
+		{"まっちゃ", "matcha", "mattya"},
+		{"ちょっと", "chotto", "tyotto"},
+		{"がっこう", "gakkou", "gakkou"},
+		{"ざっし", "zasshi", "zassi"},
+		{"みっつ", "mittsu", "mittu"},
+		{"っ", "", ""},
+
+		// Long vowels
+		{"とうきょう", "toukyou", "toukyou"},
+		{"おかあさん", "okaasan", "okaasan"},
+		{"コーヒー", "koohii", "koohii"},
+		{"ラーメン", "raamen", "raamen"},
+
+		// Moraic nasal
+		{"ほん", "hon", "hon"},
+		{"こんや", "kon'ya", "kon'ya"},
+		{"きんえん", "kin'en", "kin'en"},
+		{"あんい", "an'i", "an'i"},
+		{"こんにちは", "konnichiha", "konnitiha"},
+		{"しんぶん", "shinbun", "sinbun"},
+
+		// Youon
+		{"きょう", "kyou", "kyou"},
+		{"しゃしん", "shashin", "syasin"},
+		{"じゃ", "ja", "zya"},
+		{"ぢゃ", "ja", "zya"},
+		{"ちゅうい", "chuui", "tyuui"},
+		{"りょこう", "ryokou", "ryokou"},
+
+		// Katakana and mixed text
+		{"テレビ", "terebi", "terebi"},
+		{"パーティー", "paatii", "paatii"},
+		{"ファイル", "fairu", "fairu"},
+		{"日本ご", "日本go", "日本go"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ToRomaji(tt.input, Hepburn); got != tt.hepburn {
+				t.Errorf("ToRomaji(%q, Hepburn) = %q, want %q", tt.input, got, tt.hepburn)
+			}
+			if got := ToRomaji(tt.input, Kunrei); got != tt.kunrei {
+				t.Errorf("ToRomaji(%q, Kunrei) = %q, want %q", tt.input, got, tt.kunrei)
+			}
+		})
+	}
+}
+
+func TestRomajiRoundTrip(t *testing.T) {
+	words := []string{
+		"きって", "まっちゃ", "ちょっと", "がっこう", "ざっし",
+		"とうきょう", "おかあさん", "こうこう",
+		"こんや", "きんえん", "あんい", "ほんや", "こんにちは", "おんな", "しんぶん", "せんせい",
+		"きょう", "しゃしん", "じゃあ", "りょこう", "ぎゅうにゅう", "ひゃく", "びょういん",
+	}
+
+	for _, word := range words {
+		t.Run(word, func(t *testing.T) {
+			for _, system := range []System{Hepburn, Kunrei} {
+				romaji := ToRomaji(word, system)
+				if got := FromRomaji(romaji); got != word {
+					t.Errorf("FromRomaji(ToRomaji(%q, %s)) = FromRomaji(%q) = %q", word, system, romaji, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIsRomaji(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"sakura", true},
+		{"kon'ya", true},
+		{"tōkyō", true},
+		{"ko-hi-", true},
+		{"nihon go", true},
+		{"", false},
+		{"'-", false},
+		{"さくら", false},
+		{"sakura1", false},
+		{"日本", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsRomaji(tt.input); got != tt.want {
+				t.Errorf("IsRomaji(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package numbers
+
+// Counter is a suffix used to count things, such as 本 for long, thin
+// objects. Most counts are read as the number followed by the counter;
+// the sound changes are listed per counter.
+type Counter struct {
+	Symbol string // 本
+	Kana   string // ほん, the reading without sound changes
+	Usage  string // What the counter counts
+	Max    int    // Largest count the counter takes, 0 when unbounded
+
+	// Endings gives the readings of the last element of a count (a digit, or
+	// 10, 100, 1000 or 10000 when the count ends on that unit) followed by
+	// the counter, when they are not simply the element's reading followed
+	// by Kana. The standard reading comes first.
+	Endings map[int][]string
+
+	// Exact gives the readings of whole counts that follow no rule
+	Exact map[int][]string
+}
+
+// Reading returns the standard reading of a count, e.g. 3 → さんぼん
+func (c *Counter) Reading(n int) (string, error) {
+	readings, err := c.Readings(n)
+	if err != nil {
+		return "", err
+	}
+	return readings[0], nil
+}
+
+// Readings returns every accepted reading of a count, the standard one first
+func (c *Counter) Readings(n int) ([]string, error) {
+	if n < 1 || n > c.max() {
+		return nil, ErrOutOfRange
+	}
+	if exact, ok := c.Exact[n]; ok {
+		return append([]string(nil), exact...), nil
+	}
+
+	prefix, last, element := split(n)
+	endings, ok := c.Endings[element]
+	if !ok {
+		return []string{prefix + last + c.Kana}, nil
+	}
+
+	readings := make([]string, len(endings))
+	for i, ending := range endings {
+		readings[i] = prefix + applyEnding(last, element, ending)
+	}
+	return readings, nil
+}
+
+func (c *Counter) max() int {
+	if c.Max > 0 {
+		return c.Max
+	}
+	return MaxNumber
+}
+
+// Counters taught at JLPT N5
+var (
+	Hon = &Counter{
+		Symbol: "本", Kana: "ほん", Usage: "long, thin objects",
+		Endings: map[int][]string{
+			1:     {"いっぽん"},
+			3:     {"さんぼん"},
+			6:     {"ろっぽん"},
+			8:     {"はっぽん", "はちほん"},
+			10:    {"じゅっぽん", "じっぽん"},
+			100:   {"ひゃっぽん"},
+			1000:  {"せんぼん"},
+			10000: {"まんぼん"},
+		},
+	}
+	Mai = &Counter{
+		Symbol: "枚", Kana: "まい", Usage: "flat objects",
+	}
+	Nin = &Counter{
+		Symbol: "人", Kana: "にん", Usage: "people",
+		Endings: map[int][]string{
+			4: {"よにん"},
+			7: {"ななにん", "しちにん"},
+			9: {"きゅうにん", "くにん"},
+		},
+		Exact: map[int][]string{
+			1: {"ひとり"},
+			2: {"ふたり"},
+		},
+	}
+	Tsu = &Counter{
+		Symbol: "つ", Kana: "つ", Usage: "general things", Max: 10,
+		Exact: map[int][]string{
+			1:  {"ひとつ"},
+			2:  {"ふたつ"},
+			3:  {"みっつ"},
+			4:  {"よっつ"},
+			5:  {"いつつ"},
+			6:  {"むっつ"},
+			7:  {"ななつ"},
+			8:  {"やっつ"},
+			9:  {"ここのつ"},
+			10: {"とお"},
+		},
+	}
+	Ko = &Counter{
+		Symbol: "個", Kana: "こ", Usage: "small objects",
+		Endings: map[int][]string{
+			1:   {"いっこ"},
+			6:   {"ろっこ"},
+			8:   {"はっこ", "はちこ"},
+			10:  {"じゅっこ", "じっこ"},
+			100: {"ひゃっこ"},
+		},
+	}
+	Kai = &Counter{
+		Symbol: "回", Kana: "かい", Usage: "times, occurrences",
+		Endings: map[int][]string{
+			1:   {"いっかい"},
+			6:   {"ろっかい"},
+			8:   {"はっかい", "はちかい"},
+			10:  {"じゅっかい", "じっかい"},
+			100: {"ひゃっかい"},
+		},
+	}
+	Floor = &Counter{
+		Symbol: "階", Kana: "かい", Usage: "floors of a building",
+		Endings: map[int][]string{
+			1:   {"いっかい"},
+			3:   {"さんがい", "さんかい"},
+			6:   {"ろっかい"},
+			8:   {"はっかい", "はちかい"},
+			10:  {"じゅっかい", "じっかい"},
+			100: {"ひゃっかい"},
+		},
+	}
+	Sai = &Counter{
+		Symbol: "歳", Kana: "さい", Usage: "years of age",
+		Endings: map[int][]string{
+			1:  {"いっさい"},
+			8:  {"はっさい"},
+			10: {"じゅっさい", "じっさい"},
+		},
+		Exact: map[int][]string{
+			20: {"はたち", "にじゅっさい", "にじっさい"},
+		},
+	}
+	Satsu = &Counter{
+		Symbol: "冊", Kana: "さつ", Usage: "books",
+		Endings: map[int][]string{
+			1:  {"いっさつ"},
+			8:  {"はっさつ"},
+			10: {"じゅっさつ", "じっさつ"},
+		},
+	}
+	Hiki = &Counter{
+		Symbol: "匹", Kana: "ひき", Usage: "small animals",
+		Endings: map[int][]string{
+			1:     {"いっぴき"},
+			3:     {"さんびき"},
+			6:     {"ろっぴき"},
+			8:     {"はっぴき"},
+			10:    {"じゅっぴき", "じっぴき"},
+			100:   {"ひゃっぴき"},
+			1000:  {"せんびき"},
+			10000: {"まんびき"},
+		},
+	}
+	Hai = &Counter{
+		Symbol: "杯", Kana: "はい", Usage: "cups and glasses",
+		Endings: map[int][]string{
+			1:     {"いっぱい"},
+			3:     {"さんばい"},
+			6:     {"ろっぱい"},
+			8:     {"はっぱい"},
+			10:    {"じゅっぱい", "じっぱい"},
+			100:   {"ひゃっぱい"},
+			1000:  {"せんばい"},
+			10000: {"まんばい"},
+		},
+	}
+	Dai = &Counter{
+		Symbol: "台", Kana: "だい", Usage: "machines and vehicles",
+	}
+	En = &Counter{
+		Symbol: "円", Kana: "えん", Usage: "yen",
+		Endings: map[int][]string{
+			4: {"よえん"},
+		},
+	}
+	Nen = &Counter{
+		Symbol: "年", Kana: "ねん", Usage: "years",
+		Endings: map[int][]string{
+			4: {"よねん"},
+			7: {"ななねん", "しちねん"},
+			9: {"きゅうねん", "くねん"},
+		},
+	}
+)
+
+// Counters for dates and clock times
+var (
+	Month = &Counter{
+		Symbol: "月", Kana: "がつ", Usage: "months of the year", Max: 12,
+		Endings: map[int][]string{
+			4: {"しがつ"},
+			7: {"しちがつ"},
+			9: {"くがつ"},
+		},
+	}
+	Day = &Counter{
+		Symbol: "日", Kana: "にち", Usage: "days of the month", Max: 31,
+		Endings: map[int][]string{
+			7: {"しちにち"},
+			9: {"くにち"},
+		},
+		Exact: map[int][]string{
+			1:  {"ついたち"},
+			2:  {"ふつか"},
+			3:  {"みっか"},
+			4:  {"よっか"},
+			5:  {"いつか"},
+			6:  {"むいか"},
+			7:  {"なのか", "なぬか"},
+			8:  {"ようか"},
+			9:  {"ここのか"},
+			10: {"とおか"},
+			14: {"じゅうよっか"},
+			20: {"はつか"},
+			24: {"にじゅうよっか"},
+		},
+	}
+	Hour = &Counter{
+		Symbol: "時", Kana: "じ", Usage: "hours of the clock", Max: 24,
+		Endings: map[int][]string{
+			4: {"よじ"},
+			7: {"しちじ"},
+			9: {"くじ"},
+		},
+	}
+	Minute = &Counter{
+		Symbol: "分", Kana: "ふん", Usage: "minutes",
+		Endings: map[int][]string{
+			1:    {"いっぷん"},
+			3:    {"さんぷん"},
+			4:    {"よんぷん"},
+			6:    {"ろっぷん"},
+			8:    {"はっぷん", "はちふん"},
+			10:   {"じゅっぷん", "じっぷん"},
+			100:  {"ひゃっぷん"},
+			1000: {"せんぷん"},
+		},
+	}
+)
+
+// Counters lists the counters of things drilled by counter questions
+var Counters = []*Counter{Hon, Mai, Nin, Tsu, Ko, Kai, Floor, Sai, Satsu, Hiki, Hai, Dai, En, Nen}
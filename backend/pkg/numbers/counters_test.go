@@ -0,0 +1,119 @@
+package numbers
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCounterReadings(t *testing.T) {
+	tests := []struct {
+		counter *Counter
+		n       int
+		want    []string
+	}{
+		{Hon, 1, []string{"いっぽん"}},
+		{Hon, 2, []string{"にほん"}},
+		{Hon, 3, []string{"さんぼん"}},
+		{Hon, 6, []string{"ろっぽん"}},
+		{Hon, 8, []string{"はっぽん", "はちほん"}},
+		{Hon, 10, []string{"じゅっぽん", "じっぽん"}},
+		{Hon, 13, []string{"じゅうさんぼん"}},
+		{Hon, 100, []string{"ひゃっぽん"}},
+		{Hon, 300, []string{"さんびゃっぽん"}},
+
+		{Nin, 1, []string{"ひとり"}},
+		{Nin, 2, []string{"ふたり"}},
+		{Nin, 3, []string{"さんにん"}},
+		{Nin, 4, []string{"よにん"}},
+		{Nin, 7, []string{"ななにん", "しちにん"}},
+		{Nin, 11, []string{"じゅういちにん"}},
+		{Nin, 14, []string{"じゅうよにん"}},
+
+		{Tsu, 1, []string{"ひとつ"}},
+		{Tsu, 9, []string{"ここのつ"}},
+		{Tsu, 10, []string{"とお"}},
+
+		{Day, 1, []string{"ついたち"}},
+		{Day, 4, []string{"よっか"}},
+		{Day, 8, []string{"ようか"}},
+		{Day, 11, []string{"じゅういちにち"}},
+		{Day, 14, []string{"じゅうよっか"}},
+		{Day, 17, []string{"じゅうしちにち"}},
+		{Day, 19, []string{"じゅうくにち"}},
+		{Day, 20, []string{"はつか"}},
+		{Day, 24, []string{"にじゅうよっか"}},
+
+		{Month, 1, []string{"いちがつ"}},
+		{Month, 4, []string{"しがつ"}},
+		{Month, 7, []string{"しちがつ"}},
+		{Month, 9, []string{"くがつ"}},
+		{Month, 12, []string{"じゅうにがつ"}},
+
+		{Hour, 4, []string{"よじ"}},
+		{Hour, 7, []string{"しちじ"}},
+		{Hour, 9, []string{"くじ"}},
+		{Hour, 12, []string{"じゅうにじ"}},
+
+		{Minute, 1, []string{"いっぷん"}},
+		{Minute, 2, []string{"にふん"}},
+		{Minute, 3, []string{"さんぷん"}},
+		{Minute, 4, []string{"よんぷん"}},
+		{Minute, 5, []string{"ごふん"}},
+		{Minute, 6, []string{"ろっぷん"}},
+		{Minute, 8, []string{"はっぷん", "はちふん"}},
+		{Minute, 10, []string{"じゅっぷん", "じっぷん"}},
+		{Minute, 20, []string{"にじゅっぷん", "にじっぷん"}},
+
+		{Sai, 1, []string{"いっさい"}},
+		{Sai, 8, []string{"はっさい"}},
+		{Sai, 20, []string{"はたち", "にじゅっさい", "にじっさい"}},
+
+		{Floor, 1, []string{"いっかい"}},
+		{Floor, 3, []string{"さんがい", "さんかい"}},
+
+		{Hiki, 1, []string{"いっぴき"}},
+		{Hiki, 3, []string{"さんびき"}},
+		{En, 4, []string{"よえん"}},
+		{En, 10000, []string{"いちまんえん"}},
+		{Nen, 4, []string{"よねん"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.n)+tt.counter.Symbol, func(t *testing.T) {
+			got, err := tt.counter.Readings(tt.n)
+			if err != nil {
+				t.Fatalf("Readings(%d) of %s error: %v", tt.n, tt.counter.Symbol, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Readings(%d) of %s = %q, want %q", tt.n, tt.counter.Symbol, got, tt.want)
+			}
+			if standard, _ := tt.counter.Reading(tt.n); standard != tt.want[0] {
+				t.Errorf("Reading(%d) of %s = %q, want %q", tt.n, tt.counter.Symbol, standard, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestCounterOutOfRange(t *testing.T) {
+	tests := []struct {
+		counter *Counter
+		n       int
+	}{
+		{Hon, 0},
+		{Hon, MaxNumber + 1},
+		{Tsu, 11},
+		{Day, 32},
+		{Month, 13},
+		{Hour, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.n)+tt.counter.Symbol, func(t *testing.T) {
+			if _, err := tt.counter.Reading(tt.n); !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("Reading(%d) of %s error = %v, want %v", tt.n, tt.counter.Symbol, err, ErrOutOfRange)
+			}
+		})
+	}
+}
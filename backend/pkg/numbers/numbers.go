@@ -0,0 +1,149 @@
+// Package numbers reads Japanese numbers, counters, dates and clock times,
+// including the sound changes that make them hard to memorize (3本 is
+// さんぼん, 8日 is ようか), and generates randomized practice questions
+// from them.
+package numbers
+
+import (
+	"errors"
+	"strings"
+)
+
+// MaxNumber is the largest number that can be read
+const MaxNumber = 99_999_999
+
+// ErrOutOfRange is returned for a number that cannot be read, or a count a
+// counter does not take
+var ErrOutOfRange = errors.New("number out of range")
+
+var (
+	digits    = [...]string{"", "いち", "に", "さん", "よん", "ご", "ろく", "なな", "はち", "きゅう"}
+	tens      = [...]string{"", "じゅう", "にじゅう", "さんじゅう", "よんじゅう", "ごじゅう", "ろくじゅう", "ななじゅう", "はちじゅう", "きゅうじゅう"}
+	hundreds  = [...]string{"", "ひゃく", "にひゃく", "さんびゃく", "よんひゃく", "ごひゃく", "ろっぴゃく", "ななひゃく", "はっぴゃく", "きゅうひゃく"}
+	thousands = [...]string{"", "せん", "にせん", "さんぜん", "よんせん", "ごせん", "ろくせん", "ななせん", "はっせん", "きゅうせん"}
+
+	// digitAlternatives are the other accepted readings of a final digit
+	digitAlternatives = map[int][]string{
+		4: {"し"},
+		7: {"しち"},
+		9: {"く"},
+	}
+
+	// elements are the bare readings of the last element of a number: a
+	// digit, or the unit it ends on
+	elements = map[int]string{
+		1: "いち", 2: "に", 3: "さん", 4: "よん", 5: "ご", 6: "ろく", 7: "なな", 8: "はち", 9: "きゅう",
+		10: "じゅう", 100: "ひゃく", 1000: "せん", 10000: "まん",
+	}
+)
+
+// Reading returns the standard reading of n in hiragana, e.g. 3600 →
+// さんぜんろっぴゃく
+func Reading(n int) (string, error) {
+	readings, err := Readings(n)
+	if err != nil {
+		return "", err
+	}
+	return readings[0], nil
+}
+
+// Readings returns every accepted reading of n, the standard one first.
+// A final 4, 7 or 9 may also be read し, しち or く.
+func Readings(n int) ([]string, error) {
+	if n < 0 || n > MaxNumber {
+		return nil, ErrOutOfRange
+	}
+	if n == 0 {
+		return []string{"ぜろ", "れい"}, nil
+	}
+
+	readings := []string{reading(n)}
+	prefix, _, element := split(n)
+	for _, alternative := range digitAlternatives[element] {
+		readings = append(readings, prefix+alternative)
+	}
+	return readings, nil
+}
+
+// reading reads a number from 1 to MaxNumber, and 0 as ""
+func reading(n int) string {
+	var b strings.Builder
+	if man := n / 10000; man > 0 {
+		// 10,000,000 is いっせんまん, not せんまん
+		if man%10000/1000 == 1 {
+			b.WriteString("いっ")
+		}
+		if man == 1 {
+			b.WriteString("いち")
+		} else {
+			b.WriteString(reading(man))
+		}
+		b.WriteString("まん")
+	}
+
+	n %= 10000
+	b.WriteString(thousands[n/1000])
+	b.WriteString(hundreds[n%1000/100])
+	b.WriteString(tens[n%100/10])
+	b.WriteString(digits[n%10])
+	return b.String()
+}
+
+// naiveReading reads n without any sound change, the way learners often
+// get it wrong: さんせんろくひゃく for 3600
+func naiveReading(n int) string {
+	var b strings.Builder
+	if man := n / 10000; man > 0 {
+		b.WriteString(naiveReading(man))
+		b.WriteString("まん")
+	}
+
+	n %= 10000
+	units := []struct {
+		value   int
+		reading string
+	}{{1000, "せん"}, {100, "ひゃく"}, {10, "じゅう"}}
+	for _, unit := range units {
+		if d := n / unit.value % 10; d > 0 {
+			if d > 1 {
+				b.WriteString(digits[d])
+			}
+			b.WriteString(unit.reading)
+		}
+	}
+	b.WriteString(digits[n%10])
+	return b.String()
+}
+
+// split splits the reading of n into the reading of its last element and
+// what comes before. The element is the last digit when it is not zero,
+// otherwise the unit n ends on (10, 100, 1000 or 10000), and last is that
+// element as it is read in n: 300 splits into "", "さんびゃく", 100.
+func split(n int) (prefix, last string, element int) {
+	switch {
+	case n%10 != 0:
+		return reading(n - n%10), digits[n%10], n % 10
+	case n%100 != 0:
+		return reading(n - n%100), tens[n%100/10], 10
+	case n%1000 != 0:
+		return reading(n - n%1000), hundreds[n%1000/100], 100
+	case n%10000 != 0:
+		return reading(n - n%10000), thousands[n%10000/1000], 1000
+	default:
+		return "", reading(n), 10000
+	}
+}
+
+// applyEnding rewrites last, the reading of an element within a number,
+// the way ending rewrites the element's bare reading. With the bare reading
+// ひゃく and the ending ひゃっぽん, さんびゃく becomes さんびゃっぽん.
+func applyEnding(last string, element int, ending string) string {
+	bare, rewritten := []rune(elements[element]), []rune(ending)
+
+	common := 0
+	for common < len(bare) && common < len(rewritten) && bare[common] == rewritten[common] {
+		common++
+	}
+
+	return strings.TrimSuffix(last, string(bare[common:])) + string(rewritten[common:])
+}
@@ -0,0 +1,61 @@
+package numbers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReadings(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{0, []string{"ぜろ", "れい"}},
+		{1, []string{"いち"}},
+		{4, []string{"よん", "し"}},
+		{7, []string{"なな", "しち"}},
+		{9, []string{"きゅう", "く"}},
+		{10, []string{"じゅう"}},
+		{11, []string{"じゅういち"}},
+		{14, []string{"じゅうよん", "じゅうし"}},
+		{100, []string{"ひゃく"}},
+		{300, []string{"さんびゃく"}},
+		{600, []string{"ろっぴゃく"}},
+		{800, []string{"はっぴゃく"}},
+		{1000, []string{"せん"}},
+		{3000, []string{"さんぜん"}},
+		{8000, []string{"はっせん"}},
+		{3600, []string{"さんぜんろっぴゃく"}},
+		{10000, []string{"いちまん"}},
+		{20000, []string{"にまん"}},
+		{10_000_000, []string{"いっせんまん"}},
+		{MaxNumber, []string{
+			"きゅうせんきゅうひゃくきゅうじゅうきゅうまんきゅうせんきゅうひゃくきゅうじゅうきゅう",
+			"きゅうせんきゅうひゃくきゅうじゅうきゅうまんきゅうせんきゅうひゃくきゅうじゅうく",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(formatNumber(tt.n), func(t *testing.T) {
+			got, err := Readings(tt.n)
+			if err != nil {
+				t.Fatalf("Readings(%d) error: %v", tt.n, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Readings(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+			if standard, _ := Reading(tt.n); standard != tt.want[0] {
+				t.Errorf("Reading(%d) = %q, want %q", tt.n, standard, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestReadingOutOfRange(t *testing.T) {
+	for _, n := range []int{-1, MaxNumber + 1} {
+		if _, err := Reading(n); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Reading(%d) error = %v, want %v", n, err, ErrOutOfRange)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package numbers
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is a kind of practice question
+type Kind string
+
+const (
+	// KindNumber asks for the reading of a number: 3600 → さんぜんろっぴゃく
+	KindNumber Kind = "number"
+	// KindCounter asks for the reading of a count: 3本 → さんぼん
+	KindCounter Kind = "counter"
+	// KindDate asks for the reading of a date: 4月8日 → しがつようか
+	KindDate Kind = "date"
+	// KindTime asks for the reading of a clock time: 9時10分 → くじじゅっぷん
+	KindTime Kind = "time"
+)
+
+// Kinds lists every kind of question
+var Kinds = []Kind{KindNumber, KindCounter, KindDate, KindTime}
+
+// ErrUnknownKind is returned for a kind that is not one of Kinds
+var ErrUnknownKind = errors.New("unknown question kind")
+
+// ParseKind returns the kind with the given name
+func ParseKind(name string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == name {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownKind, name)
+}
+
+// Question asks for the reading of a number, count, date or time
+type Question struct {
+	Kind    Kind
+	Prompt  string   // e.g. 3本
+	Usage   string   // What a counter counts, e.g. long, thin objects
+	Answers []string // Accepted readings, the standard one first
+
+	// mistakes are plausible wrong readings, used as distractors
+	mistakes []string
+}
+
+// Answer returns the standard reading
+func (q Question) Answer() string {
+	return q.Answers[0]
+}
+
+// part is the reading of one piece of a question, such as the month of a date
+type part struct {
+	answers  []string
+	mistakes []string
+}
+
+// Generator generates randomized questions
+type Generator struct {
+	rng *rand.Rand
+}
+
+// NewGenerator creates a generator drawing from rng. A Generator is not
+// safe for concurrent use.
+func NewGenerator(rng *rand.Rand) *Generator {
+	return &Generator{rng: rng}
+}
+
+// Question generates a random question of the given kind
+func (g *Generator) Question(kind Kind) (Question, error) {
+	switch kind {
+	case KindNumber:
+		return g.numberQuestion(), nil
+	case KindCounter:
+		return g.counterQuestion(), nil
+	case KindDate:
+		return g.dateQuestion(), nil
+	case KindTime:
+		return g.timeQuestion(), nil
+	default:
+		return Question{}, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+}
+
+// Distractors returns up to n plausible wrong readings for q in random
+// order, such as さんほん for 3本, none of which is an accepted answer
+func (g *Generator) Distractors(q Question, n int) []string {
+	excluded := make(map[string]bool, len(q.Answers)+len(q.mistakes))
+	for _, answer := range q.Answers {
+		excluded[answer] = true
+	}
+
+	var candidates []string
+	for _, mistake := range q.mistakes {
+		if !excluded[mistake] {
+			excluded[mistake] = true
+			candidates = append(candidates, mistake)
+		}
+	}
+
+	g.rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
+
+// numberQuestion asks for a number of one to six digits
+func (g *Generator) numberQuestion() Question {
+	size := 1 + g.rng.IntN(6)
+	low := 1
+	for i := 1; i < size; i++ {
+		low *= 10
+	}
+	n := low + g.rng.IntN(low*9)
+
+	answers, _ := Readings(n)
+	mistakes := []string{naiveReading(n)}
+	for _, other := range []int{n - 1, n + 1, n + low} {
+		if reading, err := Reading(other); err == nil {
+			mistakes = append(mistakes, reading)
+		}
+	}
+
+	return build(KindNumber, formatNumber(n), "", part{answers, mistakes})
+}
+
+// counterQuestion asks for a count of a random counter. Sound changes
+// happen on small counts, so most counts are at most 10.
+func (g *Generator) counterQuestion() Question {
+	counter := Counters[g.rng.IntN(len(Counters))]
+
+	largest := counter.max()
+	if largest > 100 {
+		largest = 100
+	}
+	if largest > 10 && g.rng.IntN(10) < 7 {
+		largest = 10
+	}
+	n := 1 + g.rng.IntN(largest)
+
+	return build(KindCounter, formatNumber(n)+counter.Symbol, counter.Usage, counterPart(counter, n))
+}
+
+// dateQuestion asks for a day of the month, or a full date
+func (g *Generator) dateQuestion() Question {
+	month := 1 + g.rng.IntN(12)
+	day := 1 + g.rng.IntN(daysIn(month))
+
+	if g.rng.IntN(3) == 0 {
+		return build(KindDate, strconv.Itoa(day)+Day.Symbol, Day.Usage, counterPart(Day, day))
+	}
+
+	prompt := strconv.Itoa(month) + Month.Symbol + strconv.Itoa(day) + Day.Symbol
+	return build(KindDate, prompt, "", counterPart(Month, month), counterPart(Day, day))
+}
+
+// timeQuestion asks for a time on a twelve-hour clock
+func (g *Generator) timeQuestion() Question {
+	hour := 1 + g.rng.IntN(12)
+	minute := g.rng.IntN(60)
+
+	prompt := strconv.Itoa(hour) + Hour.Symbol
+	if minute == 0 {
+		return build(KindTime, prompt, "", counterPart(Hour, hour))
+	}
+
+	minutes := counterPart(Minute, minute)
+	if minute == 30 {
+		minutes.answers = append(minutes.answers, "はん")
+	}
+	prompt += strconv.Itoa(minute) + Minute.Symbol
+	return build(KindTime, prompt, "", counterPart(Hour, hour), minutes)
+}
+
+// counterPart reads a count, with the mistakes learners make on it: no
+// sound change, the wrong one, or the reading of a neighbouring count
+func counterPart(c *Counter, n int) part {
+	answers, _ := c.Readings(n)
+
+	prefix, last, element := split(n)
+	mistakes := []string{reading(n) + c.Kana}
+	for _, changed := range soundChanges(c.Kana) {
+		mistakes = append(mistakes, prefix+last+changed)
+		if geminating[element] {
+			mistakes = append(mistakes, prefix+geminate(last)+changed)
+		}
+	}
+	for _, alternative := range digitAlternatives[element] {
+		mistakes = append(mistakes, prefix+alternative+c.Kana)
+	}
+	for _, other := range []int{n - 1, n + 1} {
+		if reading, err := c.Reading(other); err == nil {
+			mistakes = append(mistakes, reading)
+		}
+	}
+
+	return part{answers, mistakes}
+}
+
+// build assembles a question from its parts. Accepted answers combine the
+// accepted readings of every part; mistakes get one part wrong.
+func build(kind Kind, prompt, usage string, parts ...part) Question {
+	answers := []string{""}
+	for _, p := range parts {
+		var combined []string
+		for _, head := range answers {
+			for _, answer := range p.answers {
+				combined = append(combined, head+answer)
+			}
+		}
+		answers = combined
+	}
+
+	var mistakes []string
+	for i, p := range parts {
+		for _, mistake := range p.mistakes {
+			var b strings.Builder
+			for j, other := range parts {
+				if i == j {
+					b.WriteString(mistake)
+				} else {
+					b.WriteString(other.answers[0])
+				}
+			}
+			mistakes = append(mistakes, b.String())
+		}
+	}
+
+	return Question{Kind: kind, Prompt: prompt, Usage: usage, Answers: answers, mistakes: mistakes}
+}
+
+// voicing maps the first kana of a counter to its voiced and half-voiced forms
+var voicing = map[rune][]rune{
+	'か': {'が'}, 'き': {'ぎ'}, 'く': {'ぐ'}, 'け': {'げ'}, 'こ': {'ご'},
+	'さ': {'ざ'}, 'し': {'じ'}, 'す': {'ず'}, 'せ': {'ぜ'}, 'そ': {'ぞ'},
+	'は': {'ば', 'ぱ'}, 'ひ': {'び', 'ぴ'}, 'ふ': {'ぶ', 'ぷ'}, 'へ': {'べ', 'ぺ'}, 'ほ': {'ぼ', 'ぽ'},
+}
+
+// geminating are the elements whose last kana may become a small っ before
+// a counter: いっ, ろっ, はっ, じゅっ, ひゃっ
+var geminating = map[int]bool{1: true, 6: true, 8: true, 10: true, 100: true}
+
+// soundChanges returns a counter reading with each possible voicing of its
+// first kana, or nil when the counter does not change
+func soundChanges(counterReading string) []string {
+	runes := []rune(counterReading)
+	if len(runes) == 0 {
+		return nil
+	}
+
+	voiced, ok := voicing[runes[0]]
+	if !ok {
+		return nil
+	}
+
+	changes := []string{counterReading}
+	for _, r := range voiced {
+		changes = append(changes, string(r)+string(runes[1:]))
+	}
+	return changes
+}
+
+// geminate replaces the last kana of a reading with a small っ: いち → いっ
+func geminate(reading string) string {
+	runes := []rune(reading)
+	if len(runes) < 2 {
+		return reading
+	}
+	return string(runes[:len(runes)-1]) + "っ"
+}
+
+// formatNumber writes n with thousands separators: 3,600
+func formatNumber(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// daysIn returns the number of days of a month, counting 29 for February
+func daysIn(month int) int {
+	return time.Date(2024, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
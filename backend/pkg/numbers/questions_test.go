@@ -0,0 +1,42 @@
+package numbers
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestGeneratorQuestions(t *testing.T) {
+	g := NewGenerator(rand.New(rand.NewPCG(1, 2)))
+
+	for _, kind := range Kinds {
+		t.Run(string(kind), func(t *testing.T) {
+			for range 200 {
+				q, err := g.Question(kind)
+				if err != nil {
+					t.Fatalf("Question(%s) error: %v", kind, err)
+				}
+				if q.Kind != kind || q.Prompt == "" || len(q.Answers) == 0 {
+					t.Fatalf("Question(%s) = %+v, want a prompt and answers", kind, q)
+				}
+				for _, distractor := range g.Distractors(q, 3) {
+					if slices.Contains(q.Answers, distractor) {
+						t.Errorf("Distractors of %s include the accepted answer %q", q.Prompt, distractor)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	for _, kind := range Kinds {
+		if got, err := ParseKind(string(kind)); err != nil || got != kind {
+			t.Errorf("ParseKind(%q) = %q, %v", kind, got, err)
+		}
+	}
+	if _, err := ParseKind("fraction"); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("ParseKind(%q) error = %v, want %v", "fraction", err, ErrUnknownKind)
+	}
+}
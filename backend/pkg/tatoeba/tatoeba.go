@@ -0,0 +1,155 @@
+// Package tatoeba reads Japanese-English sentence pairs from the exports of
+// the Tatoeba project (https://tatoeba.org/downloads). Tatoeba sentences are
+// licensed CC BY 2.0 FR and must be attributed to the project.
+package tatoeba
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxLineLength is the longest line the readers accept
+const maxLineLength = 1 << 20
+
+// Pair is a Japanese sentence with an English translation
+type Pair struct {
+	JapaneseID int
+	Japanese   string
+	EnglishID  int
+	English    string
+}
+
+// SentenceURL returns the Tatoeba page of a sentence, used for attribution
+func SentenceURL(id int) string {
+	return "https://tatoeba.org/en/sentences/show/" + strconv.Itoa(id)
+}
+
+// ReadPairs reads a sentence pairs file as downloaded from Tatoeba's
+// "sentence pairs" page, with lines of tab-separated Japanese sentence ID,
+// Japanese text, English sentence ID and English text. Sentences with several
+// translations keep the one with the lowest ID.
+func ReadPairs(r io.Reader) ([]Pair, error) {
+	pairs := make(map[int]Pair)
+	err := eachLine(r, 4, func(fields []string) error {
+		jpnID, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return fmt.Errorf("invalid sentence ID %q", fields[0])
+		}
+		engID, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return fmt.Errorf("invalid sentence ID %q", fields[2])
+		}
+
+		if p, ok := pairs[jpnID]; !ok || engID < p.EnglishID {
+			pairs[jpnID] = Pair{JapaneseID: jpnID, Japanese: fields[1], EnglishID: engID, English: fields[3]}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedPairs(pairs), nil
+}
+
+// ReadExport reads Japanese-English pairs from the full sentences.csv export
+// (tab-separated ID, language and text) and the links.csv export (pairs of
+// IDs of sentences that translate each other). Sentences with several
+// translations keep the one with the lowest ID.
+func ReadExport(sentences, links io.Reader) ([]Pair, error) {
+	japanese := make(map[int]string)
+	english := make(map[int]string)
+	err := eachLine(sentences, 3, func(fields []string) error {
+		var target map[int]string
+		switch fields[1] {
+		case "jpn":
+			target = japanese
+		case "eng":
+			target = english
+		default:
+			return nil
+		}
+
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return fmt.Errorf("invalid sentence ID %q", fields[0])
+		}
+		target[id] = fields[2]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading sentences: %w", err)
+	}
+
+	pairs := make(map[int]Pair)
+	err = eachLine(links, 2, func(fields []string) error {
+		jpnID, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return fmt.Errorf("invalid sentence ID %q", fields[0])
+		}
+		engID, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fmt.Errorf("invalid sentence ID %q", fields[1])
+		}
+
+		jpn, ok := japanese[jpnID]
+		if !ok {
+			return nil
+		}
+		eng, ok := english[engID]
+		if !ok {
+			return nil
+		}
+
+		if p, ok := pairs[jpnID]; !ok || engID < p.EnglishID {
+			pairs[jpnID] = Pair{JapaneseID: jpnID, Japanese: jpn, EnglishID: engID, English: eng}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading links: %w", err)
+	}
+
+	return sortedPairs(pairs), nil
+}
+
+// eachLine calls fn with the tab-separated fields of every line of r, which
+// must have n fields, the last one taking the rest of the line. Tatoeba
+// files are not quoted, so encoding/csv cannot read them.
+func eachLine(r io.Reader, n int, fn func(fields []string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineLength)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+
+		fields := strings.SplitN(text, "\t", n)
+		if len(fields) < n {
+			return fmt.Errorf("line %d: expected %d tab-separated fields", line, n)
+		}
+		if err := fn(fields); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// sortedPairs returns the pairs ordered by Japanese sentence ID
+func sortedPairs(pairs map[int]Pair) []Pair {
+	sorted := make([]Pair, 0, len(pairs))
+	for _, p := range pairs {
+		sorted = append(sorted, p)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].JapaneseID < sorted[j].JapaneseID })
+	return sorted
+}
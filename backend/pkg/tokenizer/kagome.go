@@ -0,0 +1,111 @@
+package tokenizer
+
+import (
+	"fmt"
+
+	"github.com/ikawaha/kagome-dict/ipa"
+	kagome "github.com/ikawaha/kagome/v2/tokenizer"
+
+	"github.com/joaosantos/jlpt5/pkg/kana"
+)
+
+// ipaPOS maps the top-level IPADIC part of speech tags to coarse parts of speech
+var ipaPOS = map[string]string{
+	"名詞":   POSNoun,
+	"動詞":   POSVerb,
+	"形容詞":  POSIAdjective,
+	"副詞":   POSAdverb,
+	"連体詞":  POSAdnominal,
+	"接続詞":  POSConjunction,
+	"感動詞":  POSInterjection,
+	"助詞":   POSParticle,
+	"助動詞":  POSAuxiliaryVerb,
+	"接頭詞":  POSPrefix,
+	"記号":   POSSymbol,
+	"フィラー": POSInterjection,
+}
+
+// ipaNounPOS refines nouns by their second IPADIC tag
+var ipaNounPOS = map[string]string{
+	"代名詞":    POSPronoun,
+	"数":      POSNumeral,
+	"形容動詞語幹": POSNaAdjective,
+	"接尾":     POSSuffix,
+}
+
+// Kagome tokenizes text with the kagome morphological analyzer and the
+// IPADIC dictionary embedded in the binary
+type Kagome struct {
+	tokenizer *kagome.Tokenizer
+}
+
+// NewKagome loads the dictionary and creates a tokenizer. Loading takes a
+// moment and a few dozen megabytes, so create one tokenizer and share it.
+func NewKagome() (*Kagome, error) {
+	t, err := kagome.New(ipa.Dict(), kagome.OmitBosEos())
+	if err != nil {
+		return nil, fmt.Errorf("error loading tokenizer dictionary: %w", err)
+	}
+	return &Kagome{tokenizer: t}, nil
+}
+
+// Tokenize splits text into tokens
+func (k *Kagome) Tokenize(text string) []Token {
+	morphs := k.tokenizer.Tokenize(text)
+	tokens := make([]Token, 0, len(morphs))
+	for _, m := range morphs {
+		token := Token{
+			Surface:  m.Surface,
+			BaseForm: m.Surface,
+			POSTags:  trimPOS(m.POS()),
+			Start:    m.Start,
+			End:      m.End,
+			Known:    m.Class == kagome.KNOWN || m.Class == kagome.USER,
+		}
+
+		// IPADIC marks missing features with "*"
+		if base, ok := m.BaseForm(); ok && base != "*" {
+			token.BaseForm = base
+		}
+		if reading, ok := m.Reading(); ok && reading != "*" {
+			token.Reading = kana.ToHiragana(reading)
+		} else if kana.IsAllKana(m.Surface) {
+			token.Reading = kana.ToHiragana(m.Surface)
+		}
+		token.POS = coarsePOS(token.POSTags)
+		if token.POS == POSSymbol {
+			token.Reading = ""
+		}
+
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// trimPOS drops the "*" placeholders of unused part of speech levels
+func trimPOS(tags []string) []string {
+	trimmed := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag == "*" || tag == "" {
+			break
+		}
+		trimmed = append(trimmed, tag)
+	}
+	return trimmed
+}
+
+// coarsePOS maps IPADIC part of speech tags to a coarse part of speech
+func coarsePOS(tags []string) string {
+	if len(tags) == 0 {
+		return POSOther
+	}
+	if tags[0] == "名詞" && len(tags) > 1 {
+		if pos, ok := ipaNounPOS[tags[1]]; ok {
+			return pos
+		}
+	}
+	if pos, ok := ipaPOS[tags[0]]; ok {
+		return pos
+	}
+	return POSOther
+}
@@ -0,0 +1,41 @@
+// Package tokenizer splits Japanese text into words with their dictionary
+// forms, readings and parts of speech
+package tokenizer
+
+// Parts of speech of tokens, in the vocabulary's naming
+const (
+	POSNoun          = "noun"
+	POSPronoun       = "pronoun"
+	POSNumeral       = "numeral"
+	POSVerb          = "verb"
+	POSIAdjective    = "i-adjective"
+	POSNaAdjective   = "na-adjective"
+	POSAdverb        = "adverb"
+	POSAdnominal     = "adnominal"
+	POSConjunction   = "conjunction"
+	POSInterjection  = "interjection"
+	POSParticle      = "particle"
+	POSAuxiliaryVerb = "auxiliary verb"
+	POSPrefix        = "prefix"
+	POSSuffix        = "suffix"
+	POSSymbol        = "symbol"
+	POSOther         = "other"
+)
+
+// Token is a word of a text
+type Token struct {
+	Surface  string   // The text as written
+	BaseForm string   // Dictionary form, e.g. 食べる for 食べ
+	Reading  string   // Reading of the surface in hiragana, empty when unknown
+	POS      string   // Coarse part of speech, one of the POS constants
+	POSTags  []string // Part of speech tags of the dictionary, most general first
+	Start    int      // Offset of the first character in the text, in runes
+	End      int      // Offset after the last character, in runes
+	Known    bool     // Whether the word is in the dictionary
+}
+
+// Tokenizer splits text into tokens. Implementations must be safe for
+// concurrent use.
+type Tokenizer interface {
+	Tokenize(text string) []Token
+}
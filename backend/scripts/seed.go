@@ -80,11 +80,11 @@ func seedVocabulary(ctx context.Context, db *database.DB) error {
 		{"学生", "がくせい", "student", "noun", strPtr("私は学生です。(I am a student.)")},
 		{"友達", "ともだち", "friend", "noun", strPtr("友達と遊びます。(I play with friends.)")},
 		{"本", "ほん", "book", "noun", strPtr("本を読みます。(I read books.)")},
-		{"食べる", "たべる", "to eat", "verb", strPtr("朝ごはんを食べます。(I eat breakfast.)")},
-		{"飲む", "のむ", "to drink", "verb", strPtr("水を飲みます。(I drink water.)")},
-		{"見る", "みる", "to see, to watch", "verb", strPtr("テレビを見ます。(I watch TV.)")},
-		{"行く", "いく", "to go", "verb", strPtr("学校に行きます。(I go to school.)")},
-		{"来る", "くる", "to come", "verb", strPtr("友達が来ます。(A friend is coming.)")},
+		{"食べる", "たべる", "to eat", "ichidan verb", strPtr("朝ごはんを食べます。(I eat breakfast.)")},
+		{"飲む", "のむ", "to drink", "godan verb", strPtr("水を飲みます。(I drink water.)")},
+		{"見る", "みる", "to see, to watch", "ichidan verb", strPtr("テレビを見ます。(I watch TV.)")},
+		{"行く", "いく", "to go", "irregular verb", strPtr("学校に行きます。(I go to school.)")},
+		{"来る", "くる", "to come", "irregular verb", strPtr("友達が来ます。(A friend is coming.)")},
 	}
 
 	for _, v := range vocabulary {
@@ -29,6 +29,7 @@ export interface VocabularyListResponse {
 }
 
 export interface ReviewRequest {
+  self_graded: true; // Flashcard reviews are graded by the learner
   quality: number; // 0-5 for SM-2 algorithm
 }
 
@@ -36,7 +36,7 @@ export class VocabularyService {
   }
 
   submitReview(vocabularyId: number, quality: number): Observable<ReviewResponse> {
-    const request: ReviewRequest = { quality };
+    const request: ReviewRequest = { self_graded: true, quality };
     return this.apiService.post<ReviewResponse>(`/vocabulary/${vocabularyId}/review`, request);
   }
 }